@@ -32,6 +32,14 @@ const (
 // available for the requested height for any reason.
 var ErrBeaconNotAvailable = errors.New(ModuleName, 1, "beacon: random beacon not available")
 
+// ErrEpochBlockMismatch is the error returned when the block height at the
+// start of an epoch does not map back to the same epoch.
+var ErrEpochBlockMismatch = errors.New(ModuleName, 2, "beacon: epoch block mismatch")
+
+// ErrHeightNotRetained is the error returned when a block height required
+// for verification has been pruned.
+var ErrHeightNotRetained = errors.New(ModuleName, 3, "beacon: height not retained")
+
 // EpochTime is the number of intervals (epochs) since a fixed instant
 // in time/block height (epoch date/height).
 type EpochTime uint64
@@ -97,6 +105,9 @@ type Backend interface {
 	// return the beacon for the latest finalized block.
 	GetBeacon(context.Context, int64) ([]byte, error)
 
+	// GetEpochBeacon gets the beacon generated for the provided epoch.
+	GetEpochBeacon(context.Context, EpochTime) ([]byte, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(context.Context, int64) (*Genesis, error)
 
@@ -112,6 +123,65 @@ type SetableBackend interface {
 	SetEpoch(context.Context, EpochTime) error
 }
 
+// EpochChangeHook is a hook invoked on epoch transitions.
+type EpochChangeHook func(epoch EpochTime) error
+
+// HookableBackend is a Backend that supports registering epoch change hooks.
+//
+// Hooks are only supported by backends running in the same process as the
+// consensus backend.
+type HookableBackend interface {
+	Backend
+
+	// RegisterOnEpochChange registers a hook that is synchronously invoked
+	// on each epoch transition before the new epoch is broadcast to any
+	// watchers. Hooks are invoked in registration order. Any errors returned
+	// by hooks are logged and do not prevent other hooks from being invoked.
+	//
+	// The returned function can be used to deregister the hook.
+	RegisterOnEpochChange(hook EpochChangeHook) (cancel func())
+}
+
+// VerifyEpochBlock checks that the given height is the first block of the
+// given epoch, that is that the height maps back to the epoch and that (unless
+// the epoch is the base epoch) the preceding height belongs to an earlier
+// epoch. This catches off-by-one errors at epoch boundaries.
+//
+// In case any of the required heights is below lastRetainedHeight,
+// ErrHeightNotRetained is returned.
+func VerifyEpochBlock(ctx context.Context, b Backend, epoch EpochTime, height, lastRetainedHeight int64) error {
+	if height < lastRetainedHeight {
+		return fmt.Errorf("%w: height %d (last retained: %d)", ErrHeightNotRetained, height, lastRetainedHeight)
+	}
+	heightEpoch, err := b.GetEpoch(ctx, height)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to query epoch at height %d: %w", height, err)
+	}
+	if heightEpoch != epoch {
+		return fmt.Errorf("%w: height %d is in epoch %d, expected %d", ErrEpochBlockMismatch, height, heightEpoch, epoch)
+	}
+
+	base, err := b.GetBaseEpoch(ctx)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to query base epoch: %w", err)
+	}
+	if epoch == base {
+		return nil
+	}
+
+	if height-1 < lastRetainedHeight {
+		return fmt.Errorf("%w: height %d (last retained: %d)", ErrHeightNotRetained, height-1, lastRetainedHeight)
+	}
+	prevEpoch, err := b.GetEpoch(ctx, height-1)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to query epoch at height %d: %w", height-1, err)
+	}
+	if prevEpoch >= epoch {
+		return fmt.Errorf("%w: height %d is not the first block of epoch %d", ErrEpochBlockMismatch, height, epoch)
+	}
+	return nil
+}
+
 // Genesis is the genesis state.
 type Genesis struct {
 	// Base is the starting epoch.
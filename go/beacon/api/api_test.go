@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -33,3 +34,87 @@ func TestDiff(t *testing.T) {
 		require.Equal(tc.e1.AbsDiff(tc.e2), tc.diff)
 	}
 }
+
+// testEpochBackend is a Backend where epochs start at fixed heights.
+type testEpochBackend struct {
+	Backend
+
+	base EpochTime
+	// starts are the heights at which each epoch (starting with base) begins.
+	starts []int64
+	// offset is added to the height returned by GetEpochBlock.
+	offset int64
+}
+
+func (b *testEpochBackend) GetBaseEpoch(ctx context.Context) (EpochTime, error) {
+	return b.base, nil
+}
+
+func (b *testEpochBackend) GetEpoch(ctx context.Context, height int64) (EpochTime, error) {
+	epoch := EpochInvalid
+	for i, start := range b.starts {
+		if height < start {
+			break
+		}
+		epoch = b.base + EpochTime(i)
+	}
+	return epoch, nil
+}
+
+func (b *testEpochBackend) GetEpochBlock(ctx context.Context, epoch EpochTime) (int64, error) {
+	return b.starts[epoch-b.base] + b.offset, nil
+}
+
+func TestVerifyEpochBlock(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	b := &testEpochBackend{
+		base:   10,
+		starts: []int64{5, 15, 25, 26, 40},
+	}
+
+	for epoch := b.base; epoch < b.base+EpochTime(len(b.starts)); epoch++ {
+		height, err := b.GetEpochBlock(ctx, epoch)
+		require.NoError(err, "GetEpochBlock")
+		err = VerifyEpochBlock(ctx, b, epoch, height, 1)
+		require.NoError(err, "VerifyEpochBlock(%d, %d)", epoch, height)
+	}
+
+	// Heights inside an epoch, but not at its start, should be rejected.
+	err := VerifyEpochBlock(ctx, b, 11, 16, 1)
+	require.ErrorIs(err, ErrEpochBlockMismatch, "height after epoch start")
+	err = VerifyEpochBlock(ctx, b, 11, 24, 1)
+	require.ErrorIs(err, ErrEpochBlockMismatch, "last height of epoch")
+
+	// Heights from a different epoch should be rejected.
+	err = VerifyEpochBlock(ctx, b, 12, 24, 1)
+	require.ErrorIs(err, ErrEpochBlockMismatch, "height before epoch start")
+	err = VerifyEpochBlock(ctx, b, 12, 26, 1)
+	require.ErrorIs(err, ErrEpochBlockMismatch, "height of next epoch")
+
+	// Off-by-one errors in GetEpochBlock should be detected for all non-base epochs.
+	for _, offset := range []int64{-1, 1} {
+		b.offset = offset
+		for epoch := b.base + 1; epoch < b.base+EpochTime(len(b.starts)); epoch++ {
+			height, err := b.GetEpochBlock(ctx, epoch)
+			require.NoError(err, "GetEpochBlock")
+			err = VerifyEpochBlock(ctx, b, epoch, height, 1)
+			require.ErrorIs(err, ErrEpochBlockMismatch, "VerifyEpochBlock(%d, %d)", epoch, height)
+		}
+	}
+	b.offset = 0
+
+	// Verification should fail with a typed error when the preceding height
+	// has been pruned.
+	err = VerifyEpochBlock(ctx, b, 12, 25, 24)
+	require.NoError(err, "preceding height retained")
+	err = VerifyEpochBlock(ctx, b, 12, 25, 25)
+	require.ErrorIs(err, ErrHeightNotRetained, "preceding height pruned")
+	err = VerifyEpochBlock(ctx, b, 12, 25, 26)
+	require.ErrorIs(err, ErrHeightNotRetained, "height pruned")
+
+	// The base epoch does not require the preceding height.
+	err = VerifyEpochBlock(ctx, b, 10, 5, 5)
+	require.NoError(err, "base epoch at last retained height")
+}
@@ -26,6 +26,8 @@ var (
 	methodWaitEpoch = serviceName.NewMethod("WaitEpoch", EpochTime(0))
 	// methodGetBeacon is the GetBeacon method.
 	methodGetBeacon = serviceName.NewMethod("GetBeacon", int64(0))
+	// methodGetEpochBeacon is the GetEpochBeacon method.
+	methodGetEpochBeacon = serviceName.NewMethod("GetEpochBeacon", EpochTime(0))
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
@@ -65,6 +67,10 @@ var (
 				MethodName: methodGetBeacon.ShortName(),
 				Handler:    handlerGetBeacon,
 			},
+			{
+				MethodName: methodGetEpochBeacon.ShortName(),
+				Handler:    handlerGetEpochBeacon,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -222,6 +228,29 @@ func handlerGetBeacon( //nolint:golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetEpochBeacon( //nolint:golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var epoch EpochTime
+	if err := dec(&epoch); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetEpochBeacon(ctx, epoch)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEpochBeacon.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetEpochBeacon(ctx, req.(EpochTime))
+	}
+	return interceptor(ctx, epoch, info, handler)
+}
+
 func handlerStateToGenesis( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -417,6 +446,14 @@ func (c *beaconClient) GetBeacon(ctx context.Context, height int64) ([]byte, err
 	return rsp, nil
 }
 
+func (c *beaconClient) GetEpochBeacon(ctx context.Context, epoch EpochTime) ([]byte, error) {
+	var rsp []byte
+	if err := c.conn.Invoke(ctx, methodGetEpochBeacon.FullName(), epoch, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *beaconClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -3,6 +3,8 @@ package tests
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -23,12 +25,28 @@ func BeaconImplementationTests(t *testing.T, backend api.SetableBackend) {
 	require.NoError(err, "GetBeacon")
 	require.Len(beacon, api.BeaconSize, "GetBeacon - length")
 
-	_ = MustAdvanceEpoch(t, backend, 1)
+	epoch, err := backend.GetEpoch(context.Background(), consensus.HeightLatest)
+	require.NoError(err, "GetEpoch")
+	epochBeacon, err := backend.GetEpochBeacon(context.Background(), epoch)
+	require.NoError(err, "GetEpochBeacon")
+	require.Equal(beacon, epochBeacon, "GetEpochBeacon should return the current beacon")
+
+	newEpoch := MustAdvanceEpoch(t, backend, 1)
 
 	newBeacon, err := backend.GetBeacon(context.Background(), consensus.HeightLatest)
 	require.NoError(err, "GetBeacon")
 	require.Len(newBeacon, api.BeaconSize, "GetBeacon - length")
 	require.NotEqual(beacon, newBeacon, "After epoch transition, new beacon should be generated.")
+
+	// Beacons of past epochs should remain stable.
+	epochBeacon, err = backend.GetEpochBeacon(context.Background(), epoch)
+	require.NoError(err, "GetEpochBeacon")
+	require.Equal(beacon, epochBeacon, "GetEpochBeacon should be stable for the same epoch")
+
+	newEpochBeacon, err := backend.GetEpochBeacon(context.Background(), newEpoch)
+	require.NoError(err, "GetEpochBeacon")
+	require.Equal(newBeacon, newEpochBeacon, "GetEpochBeacon should return the new beacon")
+	require.NotEqual(epochBeacon, newEpochBeacon, "GetEpochBeacon should differ across epochs")
 }
 
 // EpochtimeSetableImplementationTest exercises the basic functionality of
@@ -90,6 +108,67 @@ func EpochtimeSetableImplementationTest(t *testing.T, backend api.Backend) {
 	e, err = timeSource.GetEpoch(context.Background(), consensus.HeightLatest)
 	require.NoError(err, "GetEpoch after set")
 	require.Equal(epoch, e, "GetEpoch after set, epoch")
+
+	// Advance to an absolute epoch.
+	epoch += 2
+	e = MustSetEpoch(t, timeSource, epoch)
+	require.Equal(epoch, e, "MustSetEpoch")
+
+	e, err = timeSource.GetEpoch(context.Background(), consensus.HeightLatest)
+	require.NoError(err, "GetEpoch after MustSetEpoch")
+	require.Equal(epoch, e, "GetEpoch after MustSetEpoch, epoch")
+}
+
+// EpochChangeHookImplementationTest exercises the epoch change hooks of
+// a setable backend.
+func EpochChangeHookImplementationTest(t *testing.T, backend api.SetableBackend) {
+	require := require.New(t)
+
+	require.Implements((*api.HookableBackend)(nil), backend, "backend supports epoch change hooks")
+	hookable := backend.(api.HookableBackend)
+
+	type hookCall struct {
+		hook  int
+		epoch api.EpochTime
+	}
+	var (
+		callsLock sync.Mutex
+		calls     []hookCall
+	)
+	makeHook := func(hook int) api.EpochChangeHook {
+		return func(epoch api.EpochTime) error {
+			callsLock.Lock()
+			defer callsLock.Unlock()
+			calls = append(calls, hookCall{hook: hook, epoch: epoch})
+			if hook == 1 {
+				return fmt.Errorf("hook %d failed", hook)
+			}
+			return nil
+		}
+	}
+	getCalls := func() []hookCall {
+		callsLock.Lock()
+		defer callsLock.Unlock()
+		result := calls
+		calls = nil
+		return result
+	}
+
+	cancel0 := hookable.RegisterOnEpochChange(makeHook(0))
+	defer cancel0()
+	cancel1 := hookable.RegisterOnEpochChange(makeHook(1))
+	defer cancel1()
+	cancel2 := hookable.RegisterOnEpochChange(makeHook(2))
+	defer cancel2()
+
+	// All hooks should be invoked in registration order, even if some fail.
+	epoch := MustAdvanceEpoch(t, backend, 1)
+	require.Equal([]hookCall{{0, epoch}, {1, epoch}, {2, epoch}}, getCalls(), "hooks should be invoked in order")
+
+	// Deregistered hooks should no longer be invoked.
+	cancel1()
+	epoch = MustAdvanceEpoch(t, backend, 1)
+	require.Equal([]hookCall{{0, epoch}, {2, epoch}}, getCalls(), "deregistered hooks should not be invoked")
 }
 
 // MustAdvanceEpoch advances the epoch by the specified increment, and returns
@@ -103,10 +182,25 @@ func MustAdvanceEpoch(t *testing.T, backend api.SetableBackend, increment uint64
 	epoch, err := backend.GetEpoch(ctx, consensus.HeightLatest)
 	require.NoError(err, "GetEpoch")
 
+	return MustSetEpoch(t, backend, epoch+api.EpochTime(increment))
+}
+
+// MustSetEpoch advances the epoch to the specified absolute epoch, and
+// returns the new epoch. The target epoch must not be in the past.
+func MustSetEpoch(t *testing.T, backend api.SetableBackend, target api.EpochTime) api.EpochTime {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	epoch, err := backend.GetEpoch(ctx, consensus.HeightLatest)
+	require.NoError(err, "GetEpoch")
+	require.True(target >= epoch, "target epoch %d is in the past (current: %d)", target, epoch)
+
 	// While using a timeout here would be nice, the correct timeout value
 	// depends on the block interval and all the various internal timekeeping
 	// periods so it's not easy to set one.
-	for i := uint64(0); i < increment; i++ {
+	for epoch < target {
 		epoch++
 		err = backend.SetEpoch(context.Background(), epoch)
 		require.NoError(err, "SetEpoch")
@@ -0,0 +1,154 @@
+// Package wallclock implements an in-memory epoch time source driven by the
+// wall clock.
+//
+// This is useful for lightweight tools and simulators that need epoch
+// transitions without running consensus. It MUST NOT be used by nodes that
+// participate in consensus as epochs are not synchronized with the chain.
+package wallclock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+)
+
+// Backend is a wall-clock driven in-memory epoch time source.
+type Backend struct {
+	logger *logging.Logger
+
+	base     beacon.EpochTime
+	start    time.Time
+	interval time.Duration
+
+	notifier       *pubsub.Broker
+	latestNotifier *pubsub.Broker
+}
+
+// GetBaseEpoch returns the base epoch.
+func (b *Backend) GetBaseEpoch(ctx context.Context) (beacon.EpochTime, error) {
+	return b.base, nil
+}
+
+// GetEpoch returns the current epoch. The height is ignored as the epoch is
+// derived from the wall clock.
+func (b *Backend) GetEpoch(ctx context.Context, height int64) (beacon.EpochTime, error) {
+	return b.epochAt(time.Now()), nil
+}
+
+// WaitEpoch waits for a specific epoch.
+//
+// Note that an epoch is considered reached even if any epoch greater than the
+// one specified is reached (e.g., that the current epoch is already in the
+// future).
+func (b *Backend) WaitEpoch(ctx context.Context, epoch beacon.EpochTime) error {
+	ch, sub, err := b.WatchLatestEpoch(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-ch:
+			if !ok {
+				return context.Canceled
+			}
+			if e >= epoch {
+				return nil
+			}
+		}
+	}
+}
+
+// WatchEpochs returns a channel that produces a stream of messages on epoch
+// transitions.
+//
+// Upon subscription the current epoch is sent immediately.
+func (b *Backend) WatchEpochs(ctx context.Context) (<-chan beacon.EpochTime, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan beacon.EpochTime)
+	sub := b.notifier.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
+// WatchLatestEpoch returns a channel that produces a stream of messages on
+// epoch transitions. If an epoch transition happens before the previous epoch
+// is read from the channel, the old epochs are overwritten.
+//
+// Upon subscription the current epoch is sent immediately.
+func (b *Backend) WatchLatestEpoch(ctx context.Context) (<-chan beacon.EpochTime, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan beacon.EpochTime)
+	sub := b.latestNotifier.SubscribeBuffered(1)
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
+func (b *Backend) epochAt(t time.Time) beacon.EpochTime {
+	elapsed := t.Sub(b.start)
+	if elapsed < 0 {
+		return b.base
+	}
+	return b.base + beacon.EpochTime(elapsed/b.interval)
+}
+
+func (b *Backend) worker(ctx context.Context) {
+	epoch := b.base
+	for {
+		// Wait until the start of the next epoch.
+		next := b.start.Add(time.Duration(epoch-b.base+1) * b.interval)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Make sure not to skip any epochs in case the timer fired late.
+		current := b.epochAt(time.Now())
+		for epoch < current {
+			epoch++
+
+			b.logger.Debug("epoch transition",
+				"epoch", epoch,
+			)
+			b.notifier.Broadcast(epoch)
+			b.latestNotifier.Broadcast(epoch)
+		}
+	}
+}
+
+// New creates a new wall-clock driven epoch time source starting at the given
+// base epoch and advancing by one epoch every interval. Epoch transitions stop
+// being emitted once the passed context is canceled.
+func New(ctx context.Context, base beacon.EpochTime, interval time.Duration) (*Backend, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("beacon/wallclock: invalid epoch interval: %s", interval)
+	}
+	if base == beacon.EpochInvalid {
+		return nil, fmt.Errorf("beacon/wallclock: invalid base epoch")
+	}
+
+	b := &Backend{
+		logger:         logging.GetLogger("beacon/wallclock"),
+		base:           base,
+		start:          time.Now(),
+		interval:       interval,
+		notifier:       pubsub.NewBroker(true),
+		latestNotifier: pubsub.NewBroker(true),
+	}
+	b.notifier.Broadcast(base)
+	b.latestNotifier.Broadcast(base)
+
+	go b.worker(ctx)
+
+	return b, nil
+}
@@ -0,0 +1,66 @@
+package wallclock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
+func TestWallclockBackend(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := New(ctx, 0, 0)
+	require.Error(err, "New should fail with an invalid interval")
+
+	const (
+		base     = beacon.EpochTime(42)
+		interval = 100 * time.Millisecond
+	)
+	b, err := New(ctx, base, interval)
+	require.NoError(err, "New")
+
+	baseEpoch, err := b.GetBaseEpoch(ctx)
+	require.NoError(err, "GetBaseEpoch")
+	require.EqualValues(base, baseEpoch)
+
+	ch, sub, err := b.WatchEpochs(ctx)
+	require.NoError(err, "WatchEpochs")
+	defer sub.Close()
+
+	// The current epoch should be sent immediately, followed by all subsequent epochs at the
+	// configured interval.
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		select {
+		case epoch := <-ch:
+			require.EqualValues(base+beacon.EpochTime(i), epoch, "epochs should advance by one")
+		case <-time.After(10 * interval):
+			t.Fatalf("failed to receive epoch")
+		}
+	}
+	require.GreaterOrEqual(int64(time.Since(start)), int64(3*interval)-int64(interval/2), "epochs should not advance faster than the interval")
+
+	epoch, err := b.GetEpoch(ctx, 0)
+	require.NoError(err, "GetEpoch")
+	require.GreaterOrEqual(uint64(epoch), uint64(base+3), "GetEpoch should return the current epoch")
+
+	// Waiting for a past epoch should return immediately.
+	err = b.WaitEpoch(ctx, base)
+	require.NoError(err, "WaitEpoch")
+
+	// Waiting for a future epoch should block until it is reached.
+	waitCtx, waitCancel := context.WithTimeout(ctx, 10*interval)
+	defer waitCancel()
+	err = b.WaitEpoch(waitCtx, epoch+1)
+	require.NoError(err, "WaitEpoch")
+	current, err := b.GetEpoch(ctx, 0)
+	require.NoError(err, "GetEpoch")
+	require.GreaterOrEqual(uint64(current), uint64(epoch+1), "WaitEpoch should wait for the epoch")
+}
@@ -107,6 +107,26 @@ func UnmarshalTrusted(data []byte, dst interface{}) error {
 	return decModeTrusted.Unmarshal(data, dst)
 }
 
+// UnmarshalWithLimit deserializes a CBOR byte vector into a given type, failing in case any array
+// or map in the input has more than maxElements elements. This makes it possible to use tighter
+// bounds than the defaults when the expected size of the decoded structure is known.
+//
+// The limit must be at least 16.
+func UnmarshalWithLimit(data []byte, dst interface{}, maxElements int) error {
+	if data == nil {
+		return nil
+	}
+
+	opts := decOptions
+	opts.MaxArrayElements = maxElements
+	opts.MaxMapPairs = maxElements
+	dm, err := opts.DecMode()
+	if err != nil {
+		return err
+	}
+	return dm.Unmarshal(data, dst)
+}
+
 // MustUnmarshal deserializes a CBOR byte vector into a given type.
 // Panics if unmarshal fails.
 func MustUnmarshal(data []byte, dst interface{}) {
@@ -23,6 +23,34 @@ func TestOutOfMem2(t *testing.T) {
 	require.Error(err, "Invalid CBOR input should fail")
 }
 
+func TestUnmarshalWithLimit(t *testing.T) {
+	require := require.New(t)
+
+	small := make(map[uint64]uint64)
+	large := make(map[uint64]uint64)
+	for i := uint64(0); i < 32; i++ {
+		if i < 16 {
+			small[i] = i
+		}
+		large[i] = i
+	}
+
+	var dec map[uint64]uint64
+	err := UnmarshalWithLimit(Marshal(small), &dec, 16)
+	require.NoError(err, "UnmarshalWithLimit should succeed within the limit")
+	require.EqualValues(small, dec, "decoded value should be correct")
+
+	err = UnmarshalWithLimit(Marshal(large), &dec, 16)
+	require.Error(err, "UnmarshalWithLimit should fail for oversized maps")
+
+	var decSlice []uint64
+	err = UnmarshalWithLimit(Marshal(make([]uint64, 32)), &decSlice, 16)
+	require.Error(err, "UnmarshalWithLimit should fail for oversized arrays")
+
+	err = UnmarshalWithLimit(Marshal(large), &dec, 1)
+	require.Error(err, "UnmarshalWithLimit should fail for invalid limits")
+}
+
 func TestEncoderDecoder(t *testing.T) {
 	require := require.New(t)
 
@@ -62,3 +90,63 @@ func TestDecodeUnknowField(t *testing.T) {
 	err = UnmarshalTrusted(raw, &dec)
 	require.NoError(err, "unknown fields from trusted sources should pass")
 }
+
+func TestToJSON(t *testing.T) {
+	require := require.New(t)
+
+	type inner struct {
+		Data  []byte            `json:"data"`
+		Items []uint64          `json:"items"`
+		Tags  map[string]string `json:"tags"`
+	}
+	type outer struct {
+		Name    string            `json:"name"`
+		Nested  inner             `json:"nested"`
+		List    []inner           `json:"list"`
+		ByBytes map[string][]byte `json:"by_bytes"`
+		Signed  int64             `json:"signed"`
+		Flag    bool              `json:"flag"`
+	}
+
+	v := outer{
+		Name: "test",
+		Nested: inner{
+			Data:  []byte{0xde, 0xad, 0xbe, 0xef},
+			Items: []uint64{1, 2, 3},
+			Tags:  map[string]string{"a": "b"},
+		},
+		List: []inner{
+			{Data: []byte{0x01}},
+			{Items: []uint64{42}},
+		},
+		ByBytes: map[string][]byte{"key": {0xca, 0xfe}},
+		Signed:  -5,
+		Flag:    true,
+	}
+
+	raw, err := ToJSON(Marshal(v))
+	require.NoError(err, "ToJSON")
+	require.JSONEq(`{
+		"name": "test",
+		"nested": {"data": "deadbeef", "items": [1, 2, 3], "tags": {"a": "b"}},
+		"list": [
+			{"data": "01", "items": null, "tags": null},
+			{"data": null, "items": [42], "tags": null}
+		],
+		"by_bytes": {"key": "cafe"},
+		"signed": -5,
+		"flag": true
+	}`, string(raw))
+
+	// Maps with non-string keys should have their keys converted.
+	raw, err = ToJSON(Marshal(map[uint64][]byte{1: {0x01}, 2: {0x02}}))
+	require.NoError(err, "ToJSON")
+	require.JSONEq(`{"1": "01", "2": "02"}`, string(raw))
+
+	raw, err = ToJSON(Marshal([][]byte{{0xaa}, {}, {0xbb, 0xcc}}))
+	require.NoError(err, "ToJSON")
+	require.JSONEq(`["aa", "", "bbcc"]`, string(raw))
+
+	_, err = ToJSON([]byte{0xff})
+	require.Error(err, "ToJSON should fail on invalid CBOR")
+}
@@ -0,0 +1,76 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ToJSON transcodes an arbitrary CBOR-encoded value into indented JSON.
+//
+// Map keys are converted to strings and byte strings are converted to
+// hex-encoded strings. This is meant for debugging tools that need to
+// inspect stored values without knowing their concrete types.
+//
+// This method MUST ONLY BE USED FOR TRUSTED INPUTS as it relaxes some decoding restrictions.
+func ToJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := decModeTrusted.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("cbor: failed to decode: %w", err)
+	}
+
+	jv, err := toJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(jv, "", "  ")
+}
+
+func toJSONValue(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case []byte:
+		return hex.EncodeToString(t), nil
+	case []interface{}:
+		arr := make([]interface{}, 0, len(t))
+		for _, item := range t {
+			jv, err := toJSONValue(item)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, jv)
+		}
+		return arr, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			key, err := toJSONKey(k)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := m[key]; exists {
+				return nil, fmt.Errorf("cbor: duplicate map key after conversion: %s", key)
+			}
+			jv, err := toJSONValue(item)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = jv
+		}
+		return m, nil
+	default:
+		return v, nil
+	}
+}
+
+func toJSONKey(k interface{}) (string, error) {
+	switch t := k.(type) {
+	case string:
+		return t, nil
+	case []byte:
+		return hex.EncodeToString(t), nil
+	case uint64, int64, bool:
+		return fmt.Sprintf("%v", t), nil
+	default:
+		return "", fmt.Errorf("cbor: unsupported map key type: %T", k)
+	}
+}
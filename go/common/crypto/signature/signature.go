@@ -55,6 +55,15 @@ var (
 	// fails when opening a signed blob.
 	ErrVerifyFailed = errors.New("signed: signature verification failed")
 
+	// ErrThresholdNotMet is the error returned when a threshold multi-signed
+	// blob does not have enough valid signatures.
+	ErrThresholdNotMet = errors.New("signature: signature threshold not met")
+
+	// ErrDuplicateSigner is the error returned when the authorized signer set
+	// or the signatures of a threshold multi-signed blob contain the same
+	// signer more than once.
+	ErrDuplicateSigner = errors.New("signature: duplicate signer")
+
 	errKeyMismatch = errors.New("signature: public key PEM is not for private key")
 
 	_ encoding.BinaryMarshaler   = PublicKey{}
@@ -99,7 +108,17 @@ func (k PublicKey) Verify(context Context, message, sig []byte) bool {
 		return false
 	}
 
-	return cachingVerifier.VerifyWithOptions(k[:], data, sig, defaultOptions)
+	key := newVerifiedCacheKey(k, data, sig)
+	if _, ok := verifiedCache.Get(key); ok {
+		return true
+	}
+
+	if !cachingVerifier.VerifyWithOptions(k[:], data, sig, defaultOptions) {
+		return false
+	}
+	_ = verifiedCache.Put(key, struct{}{})
+
+	return true
 }
 
 // MarshalBinary encodes a public key into binary form.
@@ -348,6 +367,21 @@ func (s *Signature) Verify(context Context, message []byte) bool {
 	return s.PublicKey.Verify(context, message, s.Signature[:])
 }
 
+// findContext attempts to find a registered context for which the signature
+// is valid over the given message.
+func (s *Signature) findContext(message []byte) (Context, bool) {
+	var found Context
+	registeredContexts.Range(func(k, _ interface{}) bool {
+		ctx := k.(Context)
+		if s.Verify(ctx, message) {
+			found = ctx
+			return false
+		}
+		return true
+	})
+	return found, found != ""
+}
+
 // SanityCheck checks if the signature appears to be well formed.
 func (s *Signature) SanityCheck(expectedPubKey PublicKey) error {
 	if len(s.PublicKey) != PublicKeySize {
@@ -430,9 +464,18 @@ func SignSigned(signer Signer, context Context, src interface{}) (*Signed, error
 }
 
 // Open first verifies the blob signature and then unmarshals the blob.
+//
+// In case context mismatch diagnostics are enabled and the signature
+// verification fails, the returned error will name the registered context
+// that the blob was actually signed with, if any.
 func (s *Signed) Open(context Context, dst interface{}) error {
 	// Verify signature first.
 	if !s.Signature.Verify(context, s.Blob) {
+		if isContextMismatchDiagnosticsEnabled() {
+			if actual, ok := s.Signature.findContext(s.Blob); ok {
+				return fmt.Errorf("%w: signed with context '%s' instead of '%s'", ErrVerifyFailed, actual, context)
+			}
+		}
 		return ErrVerifyFailed
 	}
 
@@ -563,6 +606,72 @@ func SignMultiSigned(signers []Signer, context Context, src interface{}) (*Multi
 	return ms, nil
 }
 
+// MultiSignedThreshold is a blob signed by multiple signers, that is valid
+// when at least a threshold number of authorized signers have signed it.
+type MultiSignedThreshold struct {
+	// Blob is the signed blob.
+	Blob []byte `json:"untrusted_raw_value"`
+
+	// Signatures are the signatures over the blob.
+	Signatures []Signature `json:"signatures"`
+}
+
+// OpenThreshold first verifies that at least k of the authorized signers
+// have validly signed the blob, and then unmarshals the blob.
+//
+// Signatures by keys that are not in the authorized set are ignored and do
+// not count towards the threshold.
+func (s *MultiSignedThreshold) OpenThreshold(context Context, authorized []PublicKey, k int, dst interface{}) error {
+	if k <= 0 || k > len(authorized) {
+		return fmt.Errorf("signature: invalid threshold %d for %d authorized signers", k, len(authorized))
+	}
+
+	authorizedSet := make(map[PublicKey]bool, len(authorized))
+	for _, pk := range authorized {
+		if authorizedSet[pk] {
+			return ErrDuplicateSigner
+		}
+		authorizedSet[pk] = true
+	}
+
+	signed := make(map[PublicKey]bool, len(s.Signatures))
+	for _, sig := range s.Signatures {
+		if !authorizedSet[sig.PublicKey] {
+			continue
+		}
+		if signed[sig.PublicKey] {
+			return ErrDuplicateSigner
+		}
+		if !sig.Verify(context, s.Blob) {
+			return ErrVerifyFailed
+		}
+		signed[sig.PublicKey] = true
+	}
+	if len(signed) < k {
+		return ErrThresholdNotMet
+	}
+
+	return cbor.Unmarshal(s.Blob, dst)
+}
+
+// SignMultiSignedThreshold generates a MultiSignedThreshold signed by the
+// Signers over the context and CBOR-serialized message.
+func SignMultiSignedThreshold(signers []Signer, context Context, src interface{}) (*MultiSignedThreshold, error) {
+	ms := &MultiSignedThreshold{
+		Blob: cbor.Marshal(src),
+	}
+
+	for _, v := range signers {
+		sig, err := Sign(v, context, ms.Blob)
+		if err != nil {
+			return nil, err
+		}
+		ms.Signatures = append(ms.Signatures, *sig)
+	}
+
+	return ms, nil
+}
+
 // PrettyMultiSigned is used for pretty-printing multi-signed messages
 // so that the actual content is displayed instead of the binary blob.
 //
@@ -623,6 +732,7 @@ func VerifyManyToOne(context Context, message []byte, sigs []Signature) bool {
 		return false
 	}
 
+	var keys []verifiedCacheKey
 	verifier := ed25519.NewBatchVerifier()
 
 	for i := range sigs {
@@ -631,10 +741,27 @@ func VerifyManyToOne(context Context, message []byte, sigs []Signature) bool {
 			return false
 		}
 
+		// Skip signatures that have already been verified (e.g., by VerifyBatchDetailed).
+		key := newVerifiedCacheKey(v.PublicKey, msg, v.Signature[:])
+		if _, ok := verifiedCache.Get(key); ok {
+			continue
+		}
+		keys = append(keys, key)
+
 		cachingVerifier.AddWithOptions(verifier, v.PublicKey[:], msg, v.Signature[:], defaultOptions)
 	}
+	if len(keys) == 0 {
+		return true
+	}
 
-	return verifier.VerifyBatchOnly(rand.Reader)
+	if !verifier.VerifyBatchOnly(rand.Reader) {
+		return false
+	}
+	for _, key := range keys {
+		_ = verifiedCache.Put(key, struct{}{})
+	}
+
+	return true
 }
 
 // VerifyBatch verifies multiple signatures, made by multiple public keys,
@@ -665,6 +792,58 @@ func VerifyBatch(context Context, messages [][]byte, sigs []Signature) bool {
 	return verifier.VerifyBatchOnly(rand.Reader)
 }
 
+// VerifyBatchDetailed verifies multiple signatures, made by multiple public
+// keys, against multiple contexts and messages, returning the validity of
+// each individual signature.
+//
+// Valid signatures are added to the verified signature cache, so that opening
+// the signed blobs afterwards does not need to verify them again.
+func VerifyBatchDetailed(contexts []Context, messages [][]byte, sigs []Signature) ([]bool, error) {
+	if len(contexts) != len(sigs) || len(messages) != len(sigs) {
+		return nil, fmt.Errorf("signature: VerifyBatchDetailed context/message/signature count mismatch")
+	}
+
+	valid := make([]bool, len(sigs))
+	indexes := make([]int, 0, len(sigs))
+	keys := make([]verifiedCacheKey, 0, len(sigs))
+	verifier := ed25519.NewBatchVerifier()
+
+	for i := range sigs {
+		v := sigs[i] // This is deliberate.
+		if v.PublicKey.IsBlacklisted() {
+			continue
+		}
+
+		msg, err := PrepareSignerMessage(contexts[i], messages[i])
+		if err != nil {
+			continue
+		}
+
+		key := newVerifiedCacheKey(v.PublicKey, msg, v.Signature[:])
+		if _, ok := verifiedCache.Get(key); ok {
+			valid[i] = true
+			continue
+		}
+
+		cachingVerifier.AddWithOptions(verifier, v.PublicKey[:], msg, v.Signature[:], defaultOptions)
+		indexes = append(indexes, i)
+		keys = append(keys, key)
+	}
+	if len(indexes) == 0 {
+		return valid, nil
+	}
+
+	_, results := verifier.Verify(rand.Reader)
+	for j, i := range indexes {
+		valid[i] = results[j]
+		if results[j] {
+			_ = verifiedCache.Put(keys[j], struct{}{})
+		}
+	}
+
+	return valid, nil
+}
+
 // NewPublicKey creates a new public key from the given hex representation or
 // panics.
 func NewPublicKey(hex string) (pk PublicKey) {
@@ -0,0 +1,238 @@
+package signature
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+var (
+	openTestContext      = NewContext("test: open context")
+	openTestOtherContext = NewContext("test: open other context")
+)
+
+func newOpenTestSigned(t *testing.T, context Context, src interface{}) *Signed {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, "GenerateKey")
+
+	blob := cbor.Marshal(src)
+	data, err := PrepareSignerMessage(context, blob)
+	require.NoError(t, err, "PrepareSignerMessage")
+
+	var signed Signed
+	signed.Blob = blob
+	copy(signed.Signature.PublicKey[:], pub)
+	copy(signed.Signature.Signature[:], ed25519.Sign(priv, data))
+	return &signed
+}
+
+func TestSignedOpenContextMismatch(t *testing.T) {
+	require := require.New(t)
+
+	signed := newOpenTestSigned(t, openTestContext, "message")
+
+	var msg string
+	err := signed.Open(openTestContext, &msg)
+	require.NoError(err, "Open with the correct context")
+	require.Equal("message", msg)
+
+	// Without diagnostics, a generic error should be returned.
+	err = signed.Open(openTestOtherContext, &msg)
+	require.Equal(ErrVerifyFailed, err, "Open with the wrong context")
+
+	SetContextMismatchDiagnostics(true)
+	defer SetContextMismatchDiagnostics(false)
+
+	// With diagnostics, the error should name the context used for signing.
+	err = signed.Open(openTestOtherContext, &msg)
+	require.Error(err, "Open with the wrong context")
+	require.True(errors.Is(err, ErrVerifyFailed), "error should wrap ErrVerifyFailed")
+	require.Contains(err.Error(), "'"+string(openTestContext)+"'", "error should name the signing context")
+
+	// Invalid signatures should still result in a generic error.
+	signed.Signature.Signature[0] ^= 0xa5
+	err = signed.Open(openTestOtherContext, &msg)
+	require.Equal(ErrVerifyFailed, err, "Open with an invalid signature")
+}
+
+type testSigner struct {
+	priv ed25519.PrivateKey
+}
+
+func (s *testSigner) Public() PublicKey {
+	var pk PublicKey
+	copy(pk[:], s.priv.Public().(ed25519.PublicKey))
+	return pk
+}
+
+func (s *testSigner) ContextSign(context Context, message []byte) ([]byte, error) {
+	data, err := PrepareSignerMessage(context, message)
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.Sign(s.priv, data), nil
+}
+
+func (s *testSigner) String() string {
+	return "test signer"
+}
+
+func (s *testSigner) Reset() {
+}
+
+func newTestSigners(t *testing.T, n int) ([]Signer, []PublicKey) {
+	var (
+		signers []Signer
+		pks     []PublicKey
+	)
+	for i := 0; i < n; i++ {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "GenerateKey")
+
+		signer := &testSigner{priv: priv}
+		signers = append(signers, signer)
+		pks = append(pks, signer.Public())
+	}
+	return signers, pks
+}
+
+func TestMultiSignedThreshold(t *testing.T) {
+	require := require.New(t)
+
+	signers, pks := newTestSigners(t, 3)
+	otherSigners, _ := newTestSigners(t, 2)
+
+	// Threshold met.
+	ms, err := SignMultiSignedThreshold(signers[:2], openTestContext, "message")
+	require.NoError(err, "SignMultiSignedThreshold")
+
+	var msg string
+	err = ms.OpenThreshold(openTestContext, pks, 2, &msg)
+	require.NoError(err, "OpenThreshold (2 of 3)")
+	require.Equal("message", msg)
+	err = ms.OpenThreshold(openTestContext, pks, 1, &msg)
+	require.NoError(err, "OpenThreshold (1 of 3)")
+
+	// Threshold not met.
+	err = ms.OpenThreshold(openTestContext, pks, 3, &msg)
+	require.Equal(ErrThresholdNotMet, err, "OpenThreshold (3 of 3)")
+
+	// Invalid thresholds.
+	err = ms.OpenThreshold(openTestContext, pks, 0, &msg)
+	require.Error(err, "OpenThreshold should fail with a zero threshold")
+	err = ms.OpenThreshold(openTestContext, pks, 4, &msg)
+	require.Error(err, "OpenThreshold should fail with a threshold above the number of authorized signers")
+
+	// Wrong context.
+	err = ms.OpenThreshold(openTestOtherContext, pks, 2, &msg)
+	require.Equal(ErrVerifyFailed, err, "OpenThreshold with the wrong context")
+
+	// Duplicate signatures must not count towards the threshold.
+	ms, err = SignMultiSignedThreshold([]Signer{signers[0], signers[0]}, openTestContext, "message")
+	require.NoError(err, "SignMultiSignedThreshold")
+	err = ms.OpenThreshold(openTestContext, pks, 2, &msg)
+	require.Equal(ErrDuplicateSigner, err, "OpenThreshold with duplicate signatures")
+
+	// Duplicate authorized signers must be rejected.
+	ms, err = SignMultiSignedThreshold(signers[:2], openTestContext, "message")
+	require.NoError(err, "SignMultiSignedThreshold")
+	err = ms.OpenThreshold(openTestContext, []PublicKey{pks[0], pks[0], pks[1]}, 2, &msg)
+	require.Equal(ErrDuplicateSigner, err, "OpenThreshold with duplicate authorized signers")
+
+	// Signatures from unauthorized signers must not count towards the threshold.
+	ms, err = SignMultiSignedThreshold(append([]Signer{signers[0]}, otherSigners...), openTestContext, "message")
+	require.NoError(err, "SignMultiSignedThreshold")
+	err = ms.OpenThreshold(openTestContext, pks, 2, &msg)
+	require.Equal(ErrThresholdNotMet, err, "OpenThreshold with unauthorized signers")
+	err = ms.OpenThreshold(openTestContext, pks, 1, &msg)
+	require.NoError(err, "OpenThreshold ignoring unauthorized signers")
+}
+
+func newBatchTestSignatures(t testing.TB, n int) ([]Context, [][]byte, []Signature) {
+	var (
+		contexts []Context
+		messages [][]byte
+		sigs     []Signature
+	)
+	for i := 0; i < n; i++ {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "GenerateKey")
+		signer := &testSigner{priv: priv}
+
+		context := openTestContext
+		if i%2 == 1 {
+			context = openTestOtherContext
+		}
+		message := []byte{byte(i), 0xaa, 0xbb}
+
+		sig, err := Sign(signer, context, message)
+		require.NoError(t, err, "Sign")
+
+		contexts = append(contexts, context)
+		messages = append(messages, message)
+		sigs = append(sigs, *sig)
+	}
+	return contexts, messages, sigs
+}
+
+func TestVerifyBatchDetailed(t *testing.T) {
+	require := require.New(t)
+
+	contexts, messages, sigs := newBatchTestSignatures(t, 8)
+
+	valid, err := VerifyBatchDetailed(contexts, messages, sigs)
+	require.NoError(err, "VerifyBatchDetailed")
+	require.Equal([]bool{true, true, true, true, true, true, true, true}, valid)
+
+	// Valid signatures should be cached so that opening does not verify them again.
+	for i := range sigs {
+		msg, merr := PrepareSignerMessage(contexts[i], messages[i])
+		require.NoError(merr, "PrepareSignerMessage")
+		_, cached := verifiedCache.Peek(newVerifiedCacheKey(sigs[i].PublicKey, msg, sigs[i].Signature[:]))
+		require.True(cached, "valid signature should be cached")
+	}
+
+	// Invalidate some of the signatures in different ways.
+	sigs[1].Signature[0] ^= 0xa5
+	messages[4] = []byte("other message")
+	contexts[6] = openTestOtherContext
+	contexts[7] = Context("test: unregistered batch context")
+
+	valid, err = VerifyBatchDetailed(contexts, messages, sigs)
+	require.NoError(err, "VerifyBatchDetailed")
+	require.Equal([]bool{true, false, true, true, false, true, false, false}, valid)
+
+	_, err = VerifyBatchDetailed(contexts[:1], messages, sigs)
+	require.Error(err, "VerifyBatchDetailed should fail on count mismatch")
+
+	valid, err = VerifyBatchDetailed(nil, nil, nil)
+	require.NoError(err, "VerifyBatchDetailed with an empty batch")
+	require.Empty(valid)
+}
+
+func BenchmarkVerifyBatchDetailed(b *testing.B) {
+	contexts, messages, sigs := newBatchTestSignatures(b, 64)
+
+	b.Run("Batch", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			verifiedCache.Clear()
+			_, _ = VerifyBatchDetailed(contexts, messages, sigs)
+		}
+	})
+	b.Run("Sequential", func(b *testing.B) {
+		// Bypass the verified signature cache for a fair comparison.
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for j := range sigs {
+				msg, _ := PrepareSignerMessage(contexts[j], messages[j])
+				_ = cachingVerifier.VerifyWithOptions(sigs[j].PublicKey[:], msg, sigs[j].Signature[:], defaultOptions)
+			}
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
 )
@@ -45,6 +46,7 @@ var (
 
 	registeredContexts        sync.Map
 	allowUnregisteredContexts bool
+	diagnoseContextMismatch   uint32
 
 	chainContextLock sync.RWMutex
 	chainContext     Context
@@ -183,6 +185,24 @@ func IsUnsafeUnregisteredContextsAllowed() bool {
 	return allowUnregisteredContexts
 }
 
+// SetContextMismatchDiagnostics enables or disables context mismatch
+// diagnostics when opening signed blobs.
+//
+// When enabled, a failed signature verification is retried with all of the
+// registered contexts, so that the error can name the context that was
+// likely intended. This is expensive and is only meant to aid debugging.
+func SetContextMismatchDiagnostics(enabled bool) {
+	var v uint32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreUint32(&diagnoseContextMismatch, v)
+}
+
+func isContextMismatchDiagnosticsEnabled() bool {
+	return atomic.LoadUint32(&diagnoseContextMismatch) == 1
+}
+
 // SetChainContext configures the chain domain separation context that is
 // used with any contexts constructed using the WithChainSeparation option.
 func SetChainContext(rawContext string) {
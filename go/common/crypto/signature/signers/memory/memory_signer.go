@@ -126,18 +126,52 @@ func NewFromSeed(seed []byte) (signature.Signer, error) {
 	}, nil
 }
 
+// NewDeterministicSigner creates a new signer whose key is a pure function of
+// the given seed, registers it as a test key, and returns the signer.
+//
+// The RFC 8032 private key seed is derived as SHA-512/256(seed), which is the
+// same derivation as used by the Rust runtime's PrivateKey::from_test_seed, so
+// the resulting keys can be used for cross-language test vectors. This
+// derivation is stable and MUST NOT be changed.
+func NewDeterministicSigner(seed []byte) (signature.Signer, error) {
+	if len(seed) == 0 {
+		return nil, fmt.Errorf("signature/signer/memory: empty deterministic seed")
+	}
+
+	signer := newDeterministicSigner(seed)
+	signature.RegisterTestPublicKey(signer.Public())
+
+	return signer, nil
+}
+
+// DeterministicPublicKey returns the public key of the signer that would be
+// created by NewDeterministicSigner for the given seed, for the purpose of
+// generating test vectors. Unlike NewDeterministicSigner, it does not register
+// the public key as a test key.
+func DeterministicPublicKey(seed []byte) (signature.PublicKey, error) {
+	if len(seed) == 0 {
+		return signature.PublicKey{}, fmt.Errorf("signature/signer/memory: empty deterministic seed")
+	}
+
+	signer := newDeterministicSigner(seed)
+	defer signer.Reset()
+
+	return signer.Public(), nil
+}
+
 // NewTestSigner generates a new signer deterministically from
 // a test key name string, registers it as a test key, and returns
 // the signer.
-//
-// This routine will panic on failure.
 func NewTestSigner(name string) signature.Signer {
-	seed := sha512.Sum512_256([]byte(name))
-
-	signer := &Signer{
-		privateKey: ed25519.NewKeyFromSeed(seed[:]),
-	}
+	signer := newDeterministicSigner([]byte(name))
 	signature.RegisterTestPublicKey(signer.Public())
 
 	return signer
 }
+
+func newDeterministicSigner(seed []byte) *Signer {
+	derived := sha512.Sum512_256(seed)
+	return &Signer{
+		privateKey: ed25519.NewKeyFromSeed(derived[:]),
+	}
+}
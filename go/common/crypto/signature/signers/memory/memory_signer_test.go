@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+func TestDeterministicSigner(t *testing.T) {
+	require := require.New(t)
+
+	// These test vectors MUST NOT change, as they are shared with other
+	// implementations (e.g., the Rust runtime's PrivateKey::from_test_seed).
+	for _, tc := range []struct {
+		seed      string
+		publicKey string
+	}{
+		{"oasis-core deterministic signer test vector", "8e806e00313c54cc2b452c067ea38670139ccaee8da64425dffaf9cc68d5d2e8"},
+		{"seed", "b8f0cae2ea75374e6ffc8d597e76743613828b7b17a3d890eff358486b2bbf2a"},
+	} {
+		var expected signature.PublicKey
+		err := expected.UnmarshalHex(tc.publicKey)
+		require.NoError(err, "UnmarshalHex")
+
+		signer, err := NewDeterministicSigner([]byte(tc.seed))
+		require.NoError(err, "NewDeterministicSigner")
+		require.Equal(expected, signer.Public(), "public key should be a function of the seed")
+
+		pk, err := DeterministicPublicKey([]byte(tc.seed))
+		require.NoError(err, "DeterministicPublicKey")
+		require.Equal(expected, pk, "DeterministicPublicKey should match the signer")
+
+		require.Equal(expected, NewTestSigner(tc.seed).Public(), "NewTestSigner should use the same derivation")
+	}
+
+	_, err := NewDeterministicSigner(nil)
+	require.Error(err, "NewDeterministicSigner should fail with an empty seed")
+	_, err = DeterministicPublicKey(nil)
+	require.Error(err, "DeterministicPublicKey should fail with an empty seed")
+	require.NotPanics(func() { NewTestSigner("") }, "NewTestSigner should support an empty name")
+}
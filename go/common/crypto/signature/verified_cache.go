@@ -0,0 +1,44 @@
+package signature
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+)
+
+// verifiedCacheCapacity is the maximum number of entries in the verified
+// signature cache.
+const verifiedCacheCapacity = 8192
+
+// verifiedCache caches successful signature verifications, so that repeated
+// verification of the same signature (e.g., storage receipts in the runtime
+// genesis during sanity checks and re-validations) does not need to redo the
+// expensive curve operations.
+//
+// Only successful verifications are cached, so the cache can only be filled
+// with signatures that are actually valid.
+var verifiedCache = mustNewVerifiedCache()
+
+// verifiedCacheKey is the content address of a verified signature.
+type verifiedCacheKey struct {
+	publicKey PublicKey
+	// digest is the digest of the domain separated message, as returned by
+	// PrepareSignerMessage, which covers both the context and the message.
+	digest    [32]byte
+	signature RawSignature
+}
+
+func newVerifiedCacheKey(publicKey PublicKey, digest, sig []byte) verifiedCacheKey {
+	key := verifiedCacheKey{
+		publicKey: publicKey,
+	}
+	copy(key.digest[:], digest)
+	copy(key.signature[:], sig)
+	return key
+}
+
+func mustNewVerifiedCache() *lru.Cache {
+	cache, err := lru.New(lru.Capacity(verifiedCacheCapacity, false))
+	if err != nil {
+		panic(err)
+	}
+	return cache
+}
@@ -0,0 +1,94 @@
+package signature
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/stretchr/testify/require"
+)
+
+var verifiedCacheTestContext = NewContext("test: verified cache")
+
+func newVerifiedCacheTestSignature(t testing.TB, message []byte) (PublicKey, []byte) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, "GenerateKey")
+
+	data, err := PrepareSignerMessage(verifiedCacheTestContext, message)
+	require.NoError(t, err, "PrepareSignerMessage")
+
+	var pk PublicKey
+	copy(pk[:], pub)
+	return pk, ed25519.Sign(priv, data)
+}
+
+func TestVerifiedCache(t *testing.T) {
+	require := require.New(t)
+
+	message := []byte("verified cache test message")
+	pk, sig := newVerifiedCacheTestSignature(t, message)
+
+	data, err := PrepareSignerMessage(verifiedCacheTestContext, message)
+	require.NoError(err, "PrepareSignerMessage")
+	key := newVerifiedCacheKey(pk, data, sig)
+	verifiedCache.Remove(key)
+
+	require.True(pk.Verify(verifiedCacheTestContext, message, sig), "signature should verify")
+	_, cached := verifiedCache.Peek(key)
+	require.True(cached, "successful verification should be cached")
+	require.True(pk.Verify(verifiedCacheTestContext, message, sig), "cached signature should verify")
+
+	// Anything not matching the cached entry must not verify.
+	require.False(pk.Verify(verifiedCacheTestContext, []byte("other message"), sig), "other message should not verify")
+	badSig := append([]byte{}, sig...)
+	badSig[0] ^= 0xa5
+	require.False(pk.Verify(verifiedCacheTestContext, message, badSig), "modified signature should not verify")
+	otherPk, _ := newVerifiedCacheTestSignature(t, message)
+	require.False(otherPk.Verify(verifiedCacheTestContext, message, sig), "other public key should not verify")
+
+	// Failed verifications must not be cached.
+	_, cached = verifiedCache.Peek(newVerifiedCacheKey(pk, data, badSig))
+	require.False(cached, "failed verification should not be cached")
+
+	// Blacklisted keys must not verify, even if cached.
+	blPk, blSig := newVerifiedCacheTestSignature(t, message)
+	require.True(blPk.Verify(verifiedCacheTestContext, message, blSig), "signature should verify")
+	require.NoError(blPk.Blacklist(), "Blacklist")
+	defer blacklistedPublicKeys.Delete(blPk)
+	require.False(blPk.Verify(verifiedCacheTestContext, message, blSig), "blacklisted key should not verify")
+
+	// The cache must be bounded.
+	require.LessOrEqual(verifiedCache.Size(), uint64(verifiedCacheCapacity), "cache should be bounded")
+}
+
+func BenchmarkVerifyRepeated(b *testing.B) {
+	const numSignatures = 16
+
+	message := []byte("verified cache benchmark message")
+	pks := make([]PublicKey, numSignatures)
+	sigs := make([][]byte, numSignatures)
+	for i := range pks {
+		pks[i], sigs[i] = newVerifiedCacheTestSignature(b, message)
+	}
+
+	b.Run("Uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			verifiedCache.Clear()
+			for j := range pks {
+				if !pks[j].Verify(verifiedCacheTestContext, message, sigs[j]) {
+					b.Fatalf("signature verification failed")
+				}
+			}
+		}
+	})
+	b.Run("Cached", func(b *testing.B) {
+		verifiedCache.Clear()
+		for i := 0; i < b.N; i++ {
+			for j := range pks {
+				if !pks[j].Verify(verifiedCacheTestContext, message, sigs[j]) {
+					b.Fatalf("signature verification failed")
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,70 @@
+// Package testkeys implements deterministic derivation of test keys.
+//
+// The derived keys are NOT suitable for use outside of tests.
+package testkeys
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/drbg"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+// defaultPersonalization is the HMAC_DRBG personalization string used by
+// DeterministicFactory.
+var defaultPersonalization = []byte("oasis-core/testkeys: deterministic factory")
+
+// Factory is a deterministic test key factory which produces a stable
+// sequence of memory backed signers.
+//
+// The derivation only depends on HMAC_DRBG (with SHA-512) and RFC 8032 seed
+// expansion, so the sequence is stable across Go versions.
+type Factory struct {
+	rng *drbg.Drbg
+}
+
+// Next returns the next signer in the sequence.
+func (fac *Factory) Next() (signature.Signer, error) {
+	var seed [memorySigner.SeedSize]byte
+	if _, err := fac.rng.Read(seed[:]); err != nil {
+		return nil, fmt.Errorf("testkeys: failed to derive seed: %w", err)
+	}
+	return memorySigner.NewFromSeed(seed[:])
+}
+
+// MustNext returns the next signer in the sequence.
+//
+// This routine will panic on failure.
+func (fac *Factory) MustNext() signature.Signer {
+	signer, err := fac.Next()
+	if err != nil {
+		panic(err)
+	}
+	return signer
+}
+
+// NewFactory creates a new deterministic test key factory using the given
+// seed, nonce and personalization string.
+//
+// Factories created with the same arguments produce the same sequence of
+// signers.
+func NewFactory(seed, nonce, personalization []byte) (*Factory, error) {
+	h := crypto.SHA512.New()
+	_, _ = h.Write(seed)
+
+	rng, err := drbg.New(crypto.SHA512, h.Sum(nil), nonce, personalization)
+	if err != nil {
+		return nil, fmt.Errorf("testkeys: failed to initialize DRBG: %w", err)
+	}
+	return &Factory{
+		rng: rng,
+	}, nil
+}
+
+// DeterministicFactory creates a new deterministic test key factory using
+// the given seed.
+func DeterministicFactory(seed []byte) (*Factory, error) {
+	return NewFactory(seed, nil, defaultPersonalization)
+}
@@ -0,0 +1,71 @@
+package testkeys
+
+import (
+	"crypto"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/drbg"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+func TestDeterministicFactory(t *testing.T) {
+	require := require.New(t)
+
+	seed := []byte("testkeys deterministic factory test seed")
+
+	fac1, err := DeterministicFactory(seed)
+	require.NoError(err, "DeterministicFactory")
+	fac2, err := DeterministicFactory(seed)
+	require.NoError(err, "DeterministicFactory")
+	fac3, err := DeterministicFactory([]byte("some other seed"))
+	require.NoError(err, "DeterministicFactory")
+
+	seen := make(map[string]bool)
+	for i := 0; i < 16; i++ {
+		pk1 := fac1.MustNext().Public()
+		pk2 := fac2.MustNext().Public()
+		pk3 := fac3.MustNext().Public()
+
+		require.EqualValues(pk1, pk2, "same seed should yield identical public keys")
+		require.NotEqualValues(pk1, pk3, "different seeds should yield different public keys")
+		require.False(seen[pk1.String()], "public keys in a sequence should be unique")
+		seen[pk1.String()] = true
+	}
+
+	// The derivation must be stable, so check against known answers.
+	fac, err := DeterministicFactory(seed)
+	require.NoError(err, "DeterministicFactory")
+	for _, expected := range []string{
+		"6e6847422f654ba0de92a579b53781bd629f89d1374ba07e9f8b9a14e5dc2c15",
+		"95bd92dd0c13f7cd38af6b248a100336f71b222ad95d1d7f71fb23e734865938",
+	} {
+		pk := fac.MustNext().Public()
+		require.Equal(expected, hex.EncodeToString(pk[:]), "known answer public key")
+	}
+}
+
+func TestFactoryCompatibility(t *testing.T) {
+	require := require.New(t)
+
+	seed := []byte("testkeys compatibility test seed")
+	nonce := []byte("nonce")
+	pers := []byte("personalization")
+
+	fac, err := NewFactory(seed, nonce, pers)
+	require.NoError(err, "NewFactory")
+
+	// Signers should match the ones generated directly from a DRBG.
+	h := crypto.SHA512.New()
+	_, _ = h.Write(seed)
+	rng, err := drbg.New(crypto.SHA512, h.Sum(nil), nonce, pers)
+	require.NoError(err, "drbg.New")
+
+	for i := 0; i < 4; i++ {
+		expected, serr := memorySigner.NewSigner(rng)
+		require.NoError(serr, "NewSigner")
+		require.EqualValues(expected.Public(), fac.MustNext().Public(), "derived public key")
+	}
+}
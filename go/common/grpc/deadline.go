@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deadlineInterceptor imposes a server-side deadline on unary calls that do
+// not already carry a deadline set by the client.
+//
+// Streaming calls (e.g., the various Watch methods) are long-running by design
+// and are never subject to the default deadline.
+type deadlineInterceptor struct {
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
+}
+
+func (d *deadlineInterceptor) timeout(method string) time.Duration {
+	if timeout, ok := d.methodTimeouts[method]; ok {
+		return timeout
+	}
+	return d.defaultTimeout
+}
+
+func (d *deadlineInterceptor) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	timeout := d.timeout(info.FullMethod)
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return handler(ctx, req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rsp, err := handler(ctx, req)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return nil, status.Errorf(codes.DeadlineExceeded, "server deadline of %s exceeded: %s", timeout, err)
+	}
+	return rsp, err
+}
+
+func newDeadlineInterceptor(defaultTimeout time.Duration, methodTimeouts map[string]time.Duration) *deadlineInterceptor {
+	return &deadlineInterceptor{
+		defaultTimeout: defaultTimeout,
+		methodTimeouts: methodTimeouts,
+	}
+}
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	stallTestMethod       = "/StallTestService/Stall"
+	stallTestExemptMethod = "/StallTestService/StallExempt"
+)
+
+type StallTestRequest struct {
+}
+
+type StallTestResponse struct {
+}
+
+type StallTestService interface {
+	Stall(context.Context, *StallTestRequest) (*StallTestResponse, error)
+}
+
+// stallTestServer is a backend which never responds until the request context is done.
+type stallTestServer struct {
+}
+
+func (s *stallTestServer) Stall(ctx context.Context, req *StallTestRequest) (*StallTestResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func newStallTestHandler(method string) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(
+		srv interface{},
+		ctx context.Context,
+		dec func(interface{}) error,
+		interceptor grpc.UnaryServerInterceptor,
+	) (interface{}, error) {
+		req := new(StallTestRequest)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return srv.(StallTestService).Stall(ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: method,
+		}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.(StallTestService).Stall(ctx, req.(*StallTestRequest))
+		}
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+var stallTestServiceDesc = grpc.ServiceDesc{
+	ServiceName: "StallTestService",
+	HandlerType: (*StallTestService)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Stall",
+			Handler:    newStallTestHandler(stallTestMethod),
+		},
+		{
+			MethodName: "StallExempt",
+			Handler:    newStallTestHandler(stallTestExemptMethod),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func TestDefaultDeadline(t *testing.T) {
+	require := require.New(t)
+
+	// Generate temporary filename for the socket.
+	f, err := ioutil.TempFile("", "oasis-grpc-deadline-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+	defer os.Remove(f.Name())
+
+	const defaultTimeout = 100 * time.Millisecond
+	grpcServer, err := NewServer(&ServerConfig{
+		Path:           f.Name(),
+		DefaultTimeout: defaultTimeout,
+		MethodTimeouts: map[string]time.Duration{
+			stallTestExemptMethod: 0,
+		},
+	})
+	require.NoError(err, "NewServer")
+	grpcServer.Server().RegisterService(&stallTestServiceDesc, &stallTestServer{})
+	err = grpcServer.Start()
+	require.NoError(err, "Start")
+	defer grpcServer.Stop()
+
+	conn, err := Dial("unix:"+f.Name(), grpc.WithInsecure())
+	require.NoError(err, "Dial")
+	defer conn.Close()
+
+	// A call without a client deadline should be aborted by the server.
+	start := time.Now()
+	err = conn.Invoke(context.Background(), stallTestMethod, &StallTestRequest{}, new(StallTestResponse))
+	require.Error(err, "stalled call should fail")
+	require.True(IsErrorCode(err, codes.DeadlineExceeded), "stalled call should fail with DeadlineExceeded")
+	require.True(time.Since(start) >= defaultTimeout, "server deadline should not fire early")
+
+	// Exempt methods should not be subject to the server deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 3*defaultTimeout)
+	defer cancel()
+	start = time.Now()
+	err = conn.Invoke(ctx, stallTestExemptMethod, &StallTestRequest{}, new(StallTestResponse))
+	require.Error(err, "stalled call should fail")
+	require.True(IsErrorCode(err, codes.DeadlineExceeded), "stalled call should fail with DeadlineExceeded")
+	require.True(time.Since(start) >= 3*defaultTimeout, "client deadline should be used for exempt methods")
+}
@@ -31,11 +31,15 @@ import (
 const (
 	// CfgLogDebug enables verbose gRPC debug output.
 	CfgLogDebug = "grpc.log.debug"
+	// CfgServerDefaultTimeout configures the default deadline for unary calls
+	// without a client deadline.
+	CfgServerDefaultTimeout = "grpc.server.default_timeout"
 
 	maxRecvMsgSize = 104857600 // 100 MiB
 	maxSendMsgSize = 104857600 // 100 MiB
 
 	gracefulStopWaitPeriod = 5 * time.Second
+	staleSocketDialTimeout = 1 * time.Second
 )
 
 var (
@@ -174,10 +178,10 @@ func (l *grpcLogAdapter) V(level int) bool {
 }
 
 func (l *grpcLogAdapter) unaryLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-	// TODO: Pull useful things out of ctx for logging.
 	seq := atomic.AddUint64(&l.reqSeq, 1)
+	reqLogger := LoggerFromContext(ctx, l.reqLogger)
 	if l.isDebug {
-		l.reqLogger.Debug("request",
+		reqLogger.Debug("request",
 			"method", info.FullMethod,
 			"req_seq", seq,
 			"req", req,
@@ -192,14 +196,14 @@ func (l *grpcLogAdapter) unaryLogger(ctx context.Context, req interface{}, info
 	switch err {
 	case nil:
 		if l.isDebug {
-			l.reqLogger.Debug("request succeeded",
+			reqLogger.Debug("request succeeded",
 				"method", info.FullMethod,
 				"req_seq", seq,
 				"resp", resp,
 			)
 		}
 	default:
-		l.reqLogger.Error("request failed",
+		reqLogger.Error("request failed",
 			"method", info.FullMethod,
 			"req_seq", seq,
 			"err", err,
@@ -282,8 +286,9 @@ func (l *grpcLogAdapter) streamClientLogger(ctx context.Context,
 
 func (l *grpcLogAdapter) streamLogger(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 	seq := atomic.AddUint64(&l.streamSeq, 1)
+	reqLogger := LoggerFromContext(ss.Context(), l.reqLogger)
 	if l.isDebug {
-		l.reqLogger.Debug("stream",
+		reqLogger.Debug("stream",
 			"method", info.FullMethod,
 			"stream_seq", seq,
 		)
@@ -303,12 +308,12 @@ func (l *grpcLogAdapter) streamLogger(srv interface{}, ss grpc.ServerStream, inf
 	if l.isDebug {
 		switch err {
 		case nil:
-			l.reqLogger.Debug("stream closed",
+			reqLogger.Debug("stream closed",
 				"method", info.FullMethod,
 				"stream_seq", seq,
 			)
 		default:
-			l.reqLogger.Error("stream closed (failure)",
+			reqLogger.Error("stream closed (failure)",
 				"method", info.FullMethod,
 				"stream_seq", seq,
 				"err", err,
@@ -413,6 +418,8 @@ type Server struct {
 	startedListeners []net.Listener
 	server           *grpc.Server
 	errCh            chan error
+	startedCh        chan struct{}
+	started          bool
 
 	unsafeDebug bool
 
@@ -442,6 +449,12 @@ type ServerConfig struct { // nolint: maligned
 	ClientCommonName string
 	// CustomOptions is an array of extra options for the grpc server.
 	CustomOptions []grpc.ServerOption
+	// DefaultTimeout is the server-side deadline imposed on unary calls that
+	// do not carry a client deadline. Zero disables the default deadline.
+	DefaultTimeout time.Duration
+	// MethodTimeouts overrides DefaultTimeout for specific (full) method names.
+	// A zero timeout exempts the method from the default deadline.
+	MethodTimeouts map[string]time.Duration
 }
 
 type listenerConfig struct {
@@ -458,6 +471,9 @@ func (s *Server) Start() error {
 		// Could happen if Stop is called before Start.
 		return fmt.Errorf("gRPC server has already been stopped")
 	}
+	if s.started {
+		return fmt.Errorf("gRPC server has already been started")
+	}
 	server := s.server
 
 	s.Logger.Info("starting gRPC server")
@@ -487,9 +503,20 @@ func (s *Server) Start() error {
 		}()
 	}
 
+	s.started = true
+	close(s.startedCh)
+
 	return nil
 }
 
+// Started returns a channel that is closed once the server has been started
+// and all of its listeners are accepting connections.
+//
+// The channel is never closed in case the server fails to start.
+func (s *Server) Started() <-chan struct{} {
+	return s.startedCh
+}
+
 // Stop stops the Server.
 func (s *Server) Stop() {
 	s.Lock()
@@ -539,6 +566,35 @@ func (s *Server) Server() *grpc.Server {
 	return s.server
 }
 
+// removeStaleSocket removes the unix socket at the given path in case it is stale, i.e. it has
+// been left behind by a process that is no longer running. It refuses to remove sockets that are
+// still being listened on (e.g., by another node sharing the same data directory) and files which
+// are not sockets.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return nil
+	default:
+		return fmt.Errorf("grpc: failed to stat local socket '%s': %w", path, err)
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("grpc: local socket path '%s' exists and is not a socket", path)
+	}
+
+	conn, err := net.DialTimeout("unix", path, staleSocketDialTimeout)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("grpc: local socket '%s' is in use by another process", path)
+	}
+
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("grpc: failed to remove stale local socket '%s': %w", path, err)
+	}
+	return nil
+}
+
 // NewServer constructs a new gRPC server service listening on
 // a specific TCP port or local socket path.
 //
@@ -560,8 +616,10 @@ func NewServer(config *ServerConfig) (*Server, error) {
 	} else {
 		// Local server.
 
-		// Remove any existing socket files first.
-		_ = os.Remove(config.Path)
+		// Remove any stale socket files first.
+		if err := removeStaleSocket(config.Path); err != nil {
+			return nil, err
+		}
 
 		listenerParams = append(listenerParams, listenerConfig{
 			network: "unix",
@@ -602,11 +660,14 @@ func NewServer(config *ServerConfig) (*Server, error) {
 	}
 	var wrapper *grpcWrapper
 	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		serverUnaryRequestID,
 		logAdapter.unaryLogger,
+		newDeadlineInterceptor(config.DefaultTimeout, config.MethodTimeouts).unaryInterceptor,
 		serverUnaryErrorMapper,
 		auth.UnaryServerInterceptor(config.AuthFunc),
 	}
 	streamInterceptors := []grpc.StreamServerInterceptor{
+		serverStreamRequestID,
 		logAdapter.streamLogger,
 		serverStreamErrorMapper,
 		auth.StreamServerInterceptor(config.AuthFunc),
@@ -649,6 +710,7 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		startedListeners:      []net.Listener{},
 		server:                grpc.NewServer(sOpts...),
 		errCh:                 make(chan error, len(listenerParams)),
+		startedCh:             make(chan struct{}),
 		unsafeDebug:           unsafeDebug,
 		wrapper:               wrapper,
 	}, nil
@@ -676,8 +738,8 @@ func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	logAdapter := newGrpcLogAdapter(logger)
 	dialOpts := []grpc.DialOption{
 		grpc.WithDefaultCallOptions(grpc.ForceCodec(&CBORCodec{})),
-		grpc.WithChainUnaryInterceptor(logAdapter.unaryClientLogger, clientUnaryErrorMapper),
-		grpc.WithChainStreamInterceptor(logAdapter.streamClientLogger, clientStreamErrorMapper),
+		grpc.WithChainUnaryInterceptor(clientUnaryRequestID, logAdapter.unaryClientLogger, clientUnaryErrorMapper),
+		grpc.WithChainStreamInterceptor(clientStreamRequestID, logAdapter.streamClientLogger, clientStreamErrorMapper),
 	}
 	dialOpts = append(dialOpts, opts...)
 	return grpc.Dial(target, dialOpts...)
@@ -686,6 +748,7 @@ func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 func init() {
 	Flags.Bool(CfgLogDebug, false, "gRPC request/responses in debug logs (very verbose)")
 	_ = Flags.MarkHidden(CfgLogDebug)
+	Flags.Duration(CfgServerDefaultTimeout, 0, "default deadline for unary gRPC calls without a client deadline (0 = disabled)")
 
 	_ = viper.BindPFlags(Flags)
 }
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerStartStop(t *testing.T) {
+	require := require.New(t)
+
+	// Generate temporary filename for the socket.
+	f, err := ioutil.TempFile("", "oasis-grpc-start-stop-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+	defer os.Remove(f.Name())
+
+	for i := 0; i < 10; i++ {
+		var grpcServer *Server
+		grpcServer, err = NewServer(&ServerConfig{Path: f.Name()})
+		require.NoError(err, "NewServer")
+
+		select {
+		case <-grpcServer.Started():
+			t.Fatalf("server should not be started before Start is called")
+		default:
+		}
+
+		err = grpcServer.Start()
+		require.NoError(err, "Start")
+
+		select {
+		case <-grpcServer.Started():
+		default:
+			t.Fatalf("server should be started once Start returns")
+		}
+
+		// Starting an already started server should fail without panicking.
+		require.NotPanics(func() {
+			err = grpcServer.Start()
+		}, "second Start")
+		require.Error(err, "second Start should fail")
+
+		// Stopping immediately after start should not panic.
+		require.NotPanics(func() {
+			grpcServer.Stop()
+			grpcServer.Cleanup()
+		}, "Stop")
+	}
+
+	// Starting a stopped server should fail and the server should never be reported as started.
+	grpcServer, err := NewServer(&ServerConfig{Path: f.Name()})
+	require.NoError(err, "NewServer")
+	grpcServer.Stop()
+	err = grpcServer.Start()
+	require.Error(err, "Start after Stop should fail")
+	select {
+	case <-grpcServer.Started():
+		t.Fatalf("stopped server should not be reported as started")
+	default:
+	}
+}
+
+func TestRemoveStaleSocket(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "oasis-grpc-stale-socket-test")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	// Missing socket.
+	path := filepath.Join(dir, "test.sock")
+	err = removeStaleSocket(path)
+	require.NoError(err, "removeStaleSocket should succeed with a missing socket")
+
+	// Stale socket.
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(err, "ListenUnix")
+	l.SetUnlinkOnClose(false)
+	l.Close()
+	_, err = os.Lstat(path)
+	require.NoError(err, "stale socket should exist")
+
+	err = removeStaleSocket(path)
+	require.NoError(err, "removeStaleSocket should succeed with a stale socket")
+	_, err = os.Lstat(path)
+	require.True(os.IsNotExist(err), "stale socket should be removed")
+
+	// Live socket.
+	l, err = net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(err, "ListenUnix")
+	defer l.Close()
+
+	err = removeStaleSocket(path)
+	require.Error(err, "removeStaleSocket should fail with a live socket")
+	_, err = os.Lstat(path)
+	require.NoError(err, "live socket should not be removed")
+
+	_, err = NewServer(&ServerConfig{Path: path})
+	require.Error(err, "NewServer should fail with a live socket")
+
+	// Not a socket.
+	path = filepath.Join(dir, "not-a-socket")
+	err = ioutil.WriteFile(path, []byte("not a socket"), 0o600)
+	require.NoError(err, "WriteFile")
+	err = removeStaleSocket(path)
+	require.Error(err, "removeStaleSocket should fail with a regular file")
+}
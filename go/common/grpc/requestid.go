@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+// RequestIDMetadataKey is the gRPC metadata key used to propagate request
+// identifiers between clients and servers.
+//
+// Servers also return the request identifier in the trailer metadata of all
+// responses (including failed ones) so that clients can correlate errors with
+// server-side logs.
+const RequestIDMetadataKey = "oasis-request-id"
+
+// maxRequestIDLength is the maximum length of a client-provided request identifier.
+const maxRequestIDLength = 64
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of the context carrying the given request
+// identifier. Clients created via Dial propagate the identifier to servers.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request identifier carried by the context.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// LoggerFromContext returns a logger which includes the request identifier
+// carried by the context (if any) in all log entries.
+func LoggerFromContext(ctx context.Context, logger *logging.Logger) *logging.Logger {
+	id, ok := RequestIDFromContext(ctx)
+	if !ok {
+		return logger
+	}
+	return logger.With("request_id", id)
+}
+
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// incomingRequestID returns the request identifier provided by the client in
+// the incoming metadata or generates a new one.
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDMetadataKey); len(ids) > 0 && ids[0] != "" && len(ids[0]) <= maxRequestIDLength {
+			return ids[0]
+		}
+	}
+	return newRequestID()
+}
+
+func serverUnaryRequestID(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	id := incomingRequestID(ctx)
+	ctx = ContextWithRequestID(ctx, id)
+	// Setting the trailer can only fail in case there is no server transport in
+	// the context, which means there is nobody to return the identifier to.
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(RequestIDMetadataKey, id))
+
+	return handler(ctx, req)
+}
+
+type requestIDServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func serverStreamRequestID(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	id := incomingRequestID(ss.Context())
+	ss.SetTrailer(metadata.Pairs(RequestIDMetadataKey, id))
+
+	return handler(srv, &requestIDServerStream{
+		ServerStream: ss,
+		ctx:          ContextWithRequestID(ss.Context(), id),
+	})
+}
+
+func clientUnaryRequestID(
+	ctx context.Context,
+	method string,
+	req, rsp interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, id)
+	}
+	return invoker(ctx, method, req, rsp, cc, opts...)
+}
+
+func clientStreamRequestID(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, id)
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
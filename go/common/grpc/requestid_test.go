@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+func TestRequestIDInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/RequestIDTest/Test"}
+	var handlerID string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		var ok bool
+		handlerID, ok = RequestIDFromContext(ctx)
+		require.True(ok, "request ID should be available to handlers")
+		return nil, nil
+	}
+
+	// Request ID should be generated when not provided by the client.
+	_, err := serverUnaryRequestID(context.Background(), nil, info, handler)
+	require.NoError(err, "serverUnaryRequestID")
+	require.Len(handlerID, 32, "generated request ID should be used")
+	generatedID := handlerID
+
+	_, err = serverUnaryRequestID(context.Background(), nil, info, handler)
+	require.NoError(err, "serverUnaryRequestID")
+	require.NotEqual(generatedID, handlerID, "generated request IDs should be unique")
+
+	// Request ID provided by the client should be used.
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, "client-id"))
+	_, err = serverUnaryRequestID(ctx, nil, info, handler)
+	require.NoError(err, "serverUnaryRequestID")
+	require.Equal("client-id", handlerID, "client-provided request ID should be used")
+
+	// Oversized request IDs should be replaced.
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, strings.Repeat("a", maxRequestIDLength+1)))
+	_, err = serverUnaryRequestID(ctx, nil, info, handler)
+	require.NoError(err, "serverUnaryRequestID")
+	require.Len(handlerID, 32, "oversized request ID should be replaced")
+
+	// Loggers should include the request ID.
+	logger := logging.GetLogger("grpc/test")
+	require.Equal(logger, LoggerFromContext(context.Background(), logger), "logger without request ID should be unchanged")
+	require.NotEqual(logger, LoggerFromContext(ContextWithRequestID(context.Background(), "id"), logger), "logger should include request ID")
+}
+
+func TestRequestIDPropagation(t *testing.T) {
+	require := require.New(t)
+
+	// Generate temporary filename for the socket.
+	f, err := ioutil.TempFile("", "oasis-grpc-request-id-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+
+	grpcServer, err := NewServer(&ServerConfig{Path: f.Name()})
+	require.NoError(err, "NewServer")
+	defer os.Remove(f.Name())
+
+	grpcServer.Server().RegisterService(&errorTestServiceDesc, &errorTestServer{})
+	err = grpcServer.Start()
+	require.NoError(err, "Start")
+	defer grpcServer.Stop()
+
+	conn, err := Dial("unix:"+f.Name(), grpc.WithInsecure())
+	require.NoError(err, "Dial")
+	defer conn.Close()
+
+	// The request ID should be returned even for failed requests.
+	var trailer metadata.MD
+	err = conn.Invoke(context.Background(), "/ErrorTestService/ErrorTest", &ErrorTestRequest{}, new(ErrorTestResponse), grpc.Trailer(&trailer))
+	require.ErrorIs(err, errTest, "ErrorTest should fail")
+	require.Len(trailer.Get(RequestIDMetadataKey), 1, "request ID should be returned in the trailer")
+	require.Len(trailer.Get(RequestIDMetadataKey)[0], 32, "generated request ID should be returned")
+
+	// The request ID should be propagated from the client context.
+	ctx := ContextWithRequestID(context.Background(), "my-request-id")
+	trailer = nil
+	err = conn.Invoke(ctx, "/ErrorTestService/ErrorTest", &ErrorTestRequest{}, new(ErrorTestResponse), grpc.Trailer(&trailer))
+	require.ErrorIs(err, errTest, "ErrorTest should fail")
+	require.Equal([]string{"my-request-id"}, trailer.Get(RequestIDMetadataKey), "client request ID should be used")
+}
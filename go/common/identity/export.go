@@ -0,0 +1,183 @@
+package identity
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/oasisprotocol/deoxysii"
+	"golang.org/x/crypto/argon2"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+const (
+	exportVersion = 1
+
+	exportSaltSize = 32
+
+	// Argon2id parameters used to derive the archive encryption key.
+	exportKDFTime    = 1
+	exportKDFMemory  = 64 * 1024
+	exportKDFThreads = 4
+)
+
+var (
+	// ErrExportNoKeys is the error returned when there are no node keys to export.
+	ErrExportNoKeys = errors.New("identity", 2, "identity: no node keys to export")
+
+	// ErrExportMalformed is the error returned when an identity archive is malformed or the
+	// passphrase is incorrect.
+	ErrExportMalformed = errors.New("identity", 3, "identity: malformed archive or incorrect passphrase")
+
+	// ErrExportExists is the error returned when importing an identity into a data directory that
+	// already contains some of the identity files.
+	ErrExportExists = errors.New("identity", 4, "identity: identity files already exist")
+
+	// exportFilenames are the identity files that are included in an exported archive.
+	exportFilenames = []string{
+		fileSigner.FileIdentityKey,
+		fileSigner.FileP2PKey,
+		fileSigner.FileConsensusKey,
+		NodeKeyPubFilename,
+		P2PKeyPubFilename,
+		ConsensusKeyPubFilename,
+		beaconScalarFilename,
+		tlsKeyFilename,
+		tlsCertFilename,
+		tlsEphemeralKeyBaseFilename + tlsEphemeralGenCurrent + ".pem",
+		tlsEphemeralKeyBaseFilename + tlsEphemeralGenNext + ".pem",
+		tlsSentryClientKeyFilename,
+		tlsSentryClientCertFilename,
+	}
+)
+
+type exportedIdentity struct {
+	cbor.Versioned
+
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func exportCipherKey(passphrase, salt []byte) []byte {
+	return argon2.IDKey(passphrase, salt, exportKDFTime, exportKDFMemory, exportKDFThreads, deoxysii.KeySize)
+}
+
+// Export bundles all of the node's identity files from the given data directory into a single
+// archive encrypted with the given passphrase and writes it to w.
+//
+// Only identity keys that are stored in files are exported.
+func Export(dataDir string, w io.Writer, passphrase []byte) error {
+	files := make(map[string][]byte)
+	for _, fn := range exportFilenames {
+		data, err := ioutil.ReadFile(filepath.Join(dataDir, fn))
+		switch {
+		case err == nil:
+			files[fn] = data
+		case os.IsNotExist(err):
+		default:
+			return fmt.Errorf("identity: failed to read %s: %w", fn, err)
+		}
+	}
+	if files[fileSigner.FileIdentityKey] == nil {
+		return ErrExportNoKeys
+	}
+
+	ex := exportedIdentity{
+		Versioned: cbor.NewVersioned(exportVersion),
+		Salt:      make([]byte, exportSaltSize),
+		Nonce:     make([]byte, deoxysii.NonceSize),
+	}
+	if _, err := io.ReadFull(rand.Reader, ex.Salt); err != nil {
+		return fmt.Errorf("identity: failed to generate salt: %w", err)
+	}
+	if _, err := io.ReadFull(rand.Reader, ex.Nonce); err != nil {
+		return fmt.Errorf("identity: failed to generate nonce: %w", err)
+	}
+
+	aead, err := deoxysii.New(exportCipherKey(passphrase, ex.Salt))
+	if err != nil {
+		return err
+	}
+	ex.Ciphertext = aead.Seal(nil, ex.Nonce, cbor.Marshal(files), nil)
+
+	if _, err = w.Write(cbor.Marshal(&ex)); err != nil {
+		return fmt.Errorf("identity: failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// Import decrypts an identity archive created by Export using the given passphrase and writes
+// the contained identity files into the given data directory.
+//
+// Importing fails without writing anything if any of the identity files already exist.
+func Import(dataDir string, r io.Reader, passphrase []byte) error {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("identity: failed to read archive: %w", err)
+	}
+
+	var ex exportedIdentity
+	if err = cbor.Unmarshal(raw, &ex); err != nil {
+		return ErrExportMalformed
+	}
+	if ex.V != exportVersion || len(ex.Nonce) != deoxysii.NonceSize {
+		return ErrExportMalformed
+	}
+
+	aead, err := deoxysii.New(exportCipherKey(passphrase, ex.Salt))
+	if err != nil {
+		return err
+	}
+	plaintext, err := aead.Open(nil, ex.Nonce, ex.Ciphertext, nil)
+	if err != nil {
+		return ErrExportMalformed
+	}
+
+	var files map[string][]byte
+	if err = cbor.Unmarshal(plaintext, &files); err != nil {
+		return ErrExportMalformed
+	}
+	if files[fileSigner.FileIdentityKey] == nil {
+		return ErrExportMalformed
+	}
+
+	// Make sure that the archive only contains known files and that none of them exist yet.
+	allowed := make(map[string]bool, len(exportFilenames))
+	for _, fn := range exportFilenames {
+		allowed[fn] = true
+	}
+	for fn := range files {
+		if !allowed[fn] {
+			return ErrExportMalformed
+		}
+		if _, err = os.Stat(filepath.Join(dataDir, fn)); !os.IsNotExist(err) {
+			return ErrExportExists
+		}
+	}
+
+	var written []string
+	for _, fn := range exportFilenames {
+		data, ok := files[fn]
+		if !ok {
+			continue
+		}
+
+		path := filepath.Join(dataDir, fn)
+		if err = ioutil.WriteFile(path, data, 0o600); err != nil {
+			// Do not leave a partially imported identity behind.
+			for _, p := range written {
+				_ = os.Remove(p)
+			}
+			return fmt.Errorf("identity: failed to write %s: %w", fn, err)
+		}
+		written = append(written, path)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package identity
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -61,3 +62,62 @@ func TestLoadOrGenerate(t *testing.T) {
 	// and different if the wall clock minute changed.
 	require.Equal(t, identity3.GetTLSCertificate().PrivateKey, identity4.GetTLSCertificate().PrivateKey)
 }
+
+func TestExportImport(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-identity-export-test_")
+	require.NoError(err, "create data dir")
+	defer os.RemoveAll(dataDir)
+
+	factory, err := fileSigner.NewFactory(dataDir, signature.SignerNode, signature.SignerP2P, signature.SignerConsensus)
+	require.NoError(err, "NewFactory")
+
+	identity, err := LoadOrGenerate(dataDir, factory, true)
+	require.NoError(err, "LoadOrGenerate")
+
+	passphrase := []byte("correct horse battery staple")
+	var archive bytes.Buffer
+	err = Export(dataDir, &archive, passphrase)
+	require.NoError(err, "Export")
+
+	importDir, err := ioutil.TempDir("", "oasis-identity-import-test_")
+	require.NoError(err, "create import dir")
+	defer os.RemoveAll(importDir)
+
+	// Importing with an incorrect passphrase should fail.
+	err = Import(importDir, bytes.NewReader(archive.Bytes()), []byte("incorrect"))
+	require.ErrorIs(err, ErrExportMalformed, "Import should fail with an incorrect passphrase")
+
+	// Importing with the correct passphrase should succeed.
+	err = Import(importDir, bytes.NewReader(archive.Bytes()), passphrase)
+	require.NoError(err, "Import")
+
+	// Importing into a directory with an existing identity should fail.
+	err = Import(importDir, bytes.NewReader(archive.Bytes()), passphrase)
+	require.ErrorIs(err, ErrExportExists, "Import should not overwrite an existing identity")
+
+	// The file signer should be able to consume the imported keys.
+	importFactory, err := fileSigner.NewFactory(importDir, signature.SignerNode, signature.SignerP2P, signature.SignerConsensus)
+	require.NoError(err, "NewFactory")
+	imported, err := Load(importDir, importFactory)
+	require.NoError(err, "Load")
+	require.EqualValues(identity.NodeSigner, imported.NodeSigner)
+	require.EqualValues(identity.P2PSigner, imported.P2PSigner)
+	require.EqualValues(identity.ConsensusSigner, imported.ConsensusSigner)
+	beaconScalar, err := identity.BeaconScalar.MarshalBinary()
+	require.NoError(err, "BeaconScalar.MarshalBinary")
+	importedBeaconScalar, err := imported.BeaconScalar.MarshalBinary()
+	require.NoError(err, "BeaconScalar.MarshalBinary")
+	require.Equal(beaconScalar, importedBeaconScalar)
+	require.EqualValues(identity.GetTLSSigner(), imported.GetTLSSigner())
+	require.EqualValues(identity.GetTLSCertificate(), imported.GetTLSCertificate())
+	require.EqualValues(identity.TLSSentryClientCertificate, imported.TLSSentryClientCertificate)
+
+	// Exporting a directory without any keys should fail.
+	emptyDir, err := ioutil.TempDir("", "oasis-identity-empty-test_")
+	require.NoError(err, "create empty dir")
+	defer os.RemoveAll(emptyDir)
+	err = Export(emptyDir, &archive, passphrase)
+	require.ErrorIs(err, ErrExportNoKeys, "Export should fail without keys")
+}
@@ -0,0 +1,210 @@
+// Package jsonschema implements a minimal JSON Schema generator for Go types
+// serialized using encoding/json.
+//
+// Only the subset of JSON Schema needed to describe such types is emitted
+// (type, enum, minimum, properties, additionalProperties, items and
+// description). Existing reflection-based generators derive property names
+// and descriptions from their own struct tags, while our types are documented
+// using Go doc comments and rely on custom (text) marshalers for most of their
+// leaf types, so they would need the same overrides and descriptions as here
+// on top of a new dependency. Validation against the generated schemas is left
+// to standard JSON Schema tooling.
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Draft is the JSON Schema draft the generated schemas conform to.
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+const (
+	typeNull    = "null"
+	typeBoolean = "boolean"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeString  = "string"
+	typeArray   = "array"
+	typeObject  = "object"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Schema is a JSON Schema document.
+type Schema map[string]interface{}
+
+// Generator is a JSON Schema generator.
+type Generator struct {
+	overrides    map[reflect.Type]Schema
+	descriptions map[reflect.Type]map[string]string
+}
+
+// Override configures the generator to use the given schema for all values of
+// the given type (e.g., to describe enumerations).
+func (g *Generator) Override(v interface{}, s Schema) {
+	g.overrides[reflect.TypeOf(v)] = s
+}
+
+// Describe configures the generator to annotate the properties of the given
+// struct type with descriptions, keyed by the JSON property name.
+//
+// Go doc comments are not available via reflection, so field documentation
+// needs to be provided explicitly.
+func (g *Generator) Describe(v interface{}, descriptions map[string]string) {
+	g.descriptions[reflect.TypeOf(v)] = descriptions
+}
+
+// Generate generates a JSON Schema for the type of the given value.
+func (g *Generator) Generate(v interface{}) Schema {
+	s := g.schemaFor(reflect.TypeOf(v), make(map[reflect.Type]bool))
+	s["$schema"] = Draft
+	return s
+}
+
+func (g *Generator) schemaFor(t reflect.Type, inProgress map[reflect.Type]bool) Schema {
+	if s, ok := g.overrides[t]; ok {
+		return copySchema(s)
+	}
+
+	if t.Kind() == reflect.Ptr {
+		return nullable(g.schemaFor(t.Elem(), inProgress))
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		// Types with custom JSON serialization can be anything.
+		return Schema{}
+	}
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return Schema{"type": typeString}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": typeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Schema{"type": typeInteger}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Schema{"type": typeInteger, "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": typeNumber}
+	case reflect.String:
+		return Schema{"type": typeString}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !reflect.PtrTo(t.Elem()).Implements(textMarshalerType) {
+			// Byte slices are encoded as Base64 strings.
+			return Schema{"type": []string{typeString, typeNull}}
+		}
+		return Schema{
+			"type":  []string{typeArray, typeNull},
+			"items": g.schemaFor(t.Elem(), inProgress),
+		}
+	case reflect.Array:
+		return Schema{
+			"type":  typeArray,
+			"items": g.schemaFor(t.Elem(), inProgress),
+		}
+	case reflect.Map:
+		return Schema{
+			"type":                 []string{typeObject, typeNull},
+			"additionalProperties": g.schemaFor(t.Elem(), inProgress),
+		}
+	case reflect.Struct:
+		if inProgress[t] {
+			// Recursive type, accept anything.
+			return Schema{}
+		}
+		inProgress[t] = true
+		defer delete(inProgress, t)
+
+		properties := make(map[string]interface{})
+		g.structProperties(t, properties, inProgress)
+		for name, desc := range g.descriptions[t] {
+			if ps, ok := properties[name].(Schema); ok {
+				ps["description"] = desc
+			}
+		}
+		return Schema{
+			"type":                 typeObject,
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	default:
+		// Interfaces and other types can be anything.
+		return Schema{}
+	}
+}
+
+func (g *Generator) structProperties(t reflect.Type, properties map[string]interface{}, inProgress map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			// Skip unexported fields.
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		// Embedded structs without a name are flattened.
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.structProperties(ft, properties, inProgress)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = g.schemaFor(f.Type, inProgress)
+	}
+}
+
+// NewGenerator creates a new JSON Schema generator.
+func NewGenerator() *Generator {
+	return &Generator{
+		overrides:    make(map[reflect.Type]Schema),
+		descriptions: make(map[reflect.Type]map[string]string),
+	}
+}
+
+func copySchema(s Schema) Schema {
+	c := make(Schema, len(s))
+	for k, v := range s {
+		c[k] = v
+	}
+	return c
+}
+
+func nullable(s Schema) Schema {
+	s = copySchema(s)
+	switch t := s["type"].(type) {
+	case string:
+		s["type"] = []string{t, typeNull}
+	case []string:
+		for _, v := range t {
+			if v == typeNull {
+				return s
+			}
+		}
+		s["type"] = append(append([]string{}, t...), typeNull)
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		s["enum"] = append(append([]interface{}{}, enum...), nil)
+	}
+	return s
+}
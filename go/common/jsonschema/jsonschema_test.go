@@ -0,0 +1,92 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testKind uint8
+
+type testText struct{}
+
+func (t testText) MarshalText() ([]byte, error) {
+	return []byte("text"), nil
+}
+
+type testCustom struct{}
+
+func (c *testCustom) MarshalJSON() ([]byte, error) {
+	return []byte("[1,2]"), nil
+}
+
+type testEmbedded struct {
+	V uint16 `json:"v"`
+}
+
+type testStruct struct {
+	testEmbedded
+
+	Kind     testKind            `json:"kind"`
+	Name     string              `json:"name"`
+	Data     []byte              `json:"data,omitempty"`
+	Text     testText            `json:"text"`
+	Items    []uint64            `json:"items"`
+	Map      map[string]bool     `json:"map,omitempty"`
+	Optional *testEmbedded       `json:"optional,omitempty"`
+	Nested   map[string]testText `json:"nested,omitempty"`
+	Custom   []testCustom        `json:"custom,omitempty"`
+	Ignored  string              `json:"-"`
+	Untagged int
+
+	unexported int
+}
+
+func TestGenerate(t *testing.T) {
+	require := require.New(t)
+
+	g := NewGenerator()
+	g.Override(testKind(0), Schema{
+		"type": "integer",
+		"enum": []interface{}{1, 2},
+	})
+	g.Describe(testStruct{}, map[string]string{
+		"name": "Name is the name.",
+	})
+	g.Describe(testEmbedded{}, map[string]string{
+		"v": "V is the version.",
+	})
+	schema := g.Generate(testStruct{})
+	require.Equal(Draft, schema["$schema"], "schema draft")
+	require.Equal(false, schema["additionalProperties"], "unknown fields should not be allowed")
+
+	_, err := json.Marshal(schema)
+	require.NoError(err, "schema should be serializable")
+
+	props := schema["properties"].(map[string]interface{})
+	require.Len(props, 11, "all exported and tagged fields should be present")
+	require.NotContains(props, "Ignored", "ignored fields should be skipped")
+	require.NotContains(props, "unexported", "unexported fields should be skipped")
+	require.Contains(props, "Untagged", "untagged fields should use the field name")
+
+	require.Equal("Name is the name.", props["name"].(Schema)["description"], "property description")
+	require.Equal([]interface{}{1, 2}, props["kind"].(Schema)["enum"], "enum override")
+	require.Equal(Schema{"type": "integer", "minimum": 0}, props["v"], "embedded fields are flattened")
+	require.Equal(Schema{"type": []string{"string", "null"}}, props["data"], "byte slices are Base64 strings")
+	require.Equal(Schema{"type": "string"}, props["text"], "text marshalers are strings")
+	require.Equal(Schema{
+		"type":  []string{"array", "null"},
+		"items": Schema{"type": "integer", "minimum": 0},
+	}, props["items"], "slices are nullable arrays")
+	require.Equal(Schema{
+		"type":                 []string{"object", "null"},
+		"additionalProperties": Schema{"type": "boolean"},
+	}, props["map"], "maps are nullable objects")
+	require.Equal(Schema{}, props["custom"].(Schema)["items"], "custom JSON marshalers can be anything")
+
+	optional := props["optional"].(Schema)
+	require.Equal([]string{"object", "null"}, optional["type"], "pointers are nullable")
+	require.Equal("V is the version.", optional["properties"].(map[string]interface{})["v"].(Schema)["description"],
+		"nested property description")
+}
@@ -38,6 +38,7 @@ var (
 
 	teeHashContext = []byte("oasis-core/node: TEE RAK binding")
 
+	_ prettyprint.PrettyPrinter = (*Node)(nil)
 	_ prettyprint.PrettyPrinter = (*MultiSignedNode)(nil)
 )
 
@@ -109,6 +110,16 @@ const (
 	// RoleReserved are all the bits of the Oasis node roles bitmask
 	// that are reserved and must not be used.
 	RoleReserved RolesMask = ((1 << 32) - 1) & ^((RoleStorageRPC << 1) - 1)
+
+	// ConsensusAddressRequiredRoles are the node roles that require a consensus address.
+	ConsensusAddressRequiredRoles = RoleValidator
+	// TLSAddressRequiredRoles are the node roles that require a TLS address.
+	TLSAddressRequiredRoles = RoleComputeWorker |
+		RoleStorageWorker |
+		RoleKeyManager |
+		RoleConsensusRPC
+	// P2PAddressRequiredRoles are the node roles that require a P2P address.
+	P2PAddressRequiredRoles = RoleComputeWorker
 )
 
 // Roles returns a list of available valid roles.
@@ -181,6 +192,37 @@ func (n *Node) ValidateBasic(strictVersion bool) error {
 	return nil
 }
 
+// ValidateRoles checks that all of the fields required by the node's roles
+// are populated.
+//
+// Note that this only checks for presence, validity of the individual fields
+// (e.g., address routability) must be checked separately.
+func (n *Node) ValidateRoles() error {
+	if n.HasRoles(ConsensusAddressRequiredRoles) && len(n.Consensus.Addresses) == 0 {
+		return fmt.Errorf("node: missing consensus address (required by roles: %s)",
+			n.Roles&ConsensusAddressRequiredRoles,
+		)
+	}
+	if n.HasRoles(TLSAddressRequiredRoles) {
+		if !n.TLS.PubKey.IsValid() {
+			return fmt.Errorf("node: missing TLS certificate (required by roles: %s)",
+				n.Roles&TLSAddressRequiredRoles,
+			)
+		}
+		if len(n.TLS.Addresses) == 0 {
+			return fmt.Errorf("node: missing TLS address (required by roles: %s)",
+				n.Roles&TLSAddressRequiredRoles,
+			)
+		}
+	}
+	if n.HasRoles(P2PAddressRequiredRoles) && len(n.P2P.Addresses) == 0 {
+		return fmt.Errorf("node: missing P2P address (required by roles: %s)",
+			n.Roles&P2PAddressRequiredRoles,
+		)
+	}
+	return nil
+}
+
 // AddRoles adds a new node role to the existing roles mask.
 func (n *Node) AddRoles(r RolesMask) {
 	n.Roles |= r
@@ -437,6 +479,68 @@ func (n *Node) String() string {
 	return "<Node id=" + n.ID.String() + ">"
 }
 
+// PrettyPrint writes a pretty-printed representation of Node to the given
+// writer.
+func (n *Node) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sID:         %s\n", prefix, n.ID)
+	fmt.Fprintf(w, "%sEntity ID:  %s\n", prefix, n.EntityID)
+	fmt.Fprintf(w, "%sExpiration: %d\n", prefix, n.Expiration)
+	fmt.Fprintf(w, "%sRoles:      %s\n", prefix, n.Roles)
+
+	fmt.Fprintf(w, "%sTLS:\n", prefix)
+	fmt.Fprintf(w, "%s  Public Key: %s\n", prefix, n.TLS.PubKey)
+	if n.TLS.NextPubKey.IsValid() {
+		fmt.Fprintf(w, "%s  Next Public Key: %s\n", prefix, n.TLS.NextPubKey)
+	}
+	fmt.Fprintf(w, "%s  Addresses:\n", prefix)
+	if len(n.TLS.Addresses) == 0 {
+		fmt.Fprintf(w, "%s    none\n", prefix)
+	}
+	for _, addr := range n.TLS.Addresses {
+		fmt.Fprintf(w, "%s    %s@%s\n", prefix, addr.PubKey, addr.Address)
+	}
+
+	fmt.Fprintf(w, "%sP2P:\n", prefix)
+	fmt.Fprintf(w, "%s  ID: %s\n", prefix, n.P2P.ID)
+	fmt.Fprintf(w, "%s  Addresses:\n", prefix)
+	if len(n.P2P.Addresses) == 0 {
+		fmt.Fprintf(w, "%s    none\n", prefix)
+	}
+	for _, addr := range n.P2P.Addresses {
+		fmt.Fprintf(w, "%s    %s\n", prefix, addr)
+	}
+
+	fmt.Fprintf(w, "%sConsensus:\n", prefix)
+	fmt.Fprintf(w, "%s  ID: %s\n", prefix, n.Consensus.ID)
+	fmt.Fprintf(w, "%s  Addresses:\n", prefix)
+	if len(n.Consensus.Addresses) == 0 {
+		fmt.Fprintf(w, "%s    none\n", prefix)
+	}
+	for _, addr := range n.Consensus.Addresses {
+		fmt.Fprintf(w, "%s    %s\n", prefix, addr.String())
+	}
+
+	fmt.Fprintf(w, "%sRuntimes:\n", prefix)
+	if len(n.Runtimes) == 0 {
+		fmt.Fprintf(w, "%s  none\n", prefix)
+	}
+	for _, rt := range n.Runtimes {
+		fmt.Fprintf(w, "%s  - ID:      %s\n", prefix, rt.ID)
+		fmt.Fprintf(w, "%s    Version: %s\n", prefix, rt.Version)
+		if tee := rt.Capabilities.TEE; tee != nil {
+			fmt.Fprintf(w, "%s    TEE:     %s (RAK: %s)\n", prefix, tee.Hardware, tee.RAK)
+		} else {
+			fmt.Fprintf(w, "%s    TEE:     none\n", prefix)
+		}
+	}
+}
+
+// PrettyType returns a representation of Node that can be used for pretty
+// printing.
+func (n *Node) PrettyType() (interface{}, error) {
+	return n, nil
+}
+
 // MultiSignedNode is a multi-signed blob containing a CBOR-serialized Node.
 type MultiSignedNode struct {
 	signature.MultiSigned
@@ -465,7 +569,7 @@ func (s MultiSignedNode) PrettyType() (interface{}, error) {
 	if err := cbor.Unmarshal(s.MultiSigned.Blob, &n); err != nil {
 		return nil, fmt.Errorf("malformed signed blob: %w", err)
 	}
-	return signature.NewPrettyMultiSigned(s.MultiSigned, n)
+	return signature.NewPrettyMultiSigned(s.MultiSigned, &n)
 }
 
 // MultiSignNode serializes the Node and multi-signs the result.
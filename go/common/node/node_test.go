@@ -1,12 +1,16 @@
 package node
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 )
 
 func TestNodeDescriptor(t *testing.T) {
@@ -40,3 +44,105 @@ func TestNodeDescriptor(t *testing.T) {
 	require.NoError(err, "deserialize descriptor")
 	require.EqualValues(n, n2, "s11n roundtrip")
 }
+
+func TestNodeValidateRoles(t *testing.T) {
+	require := require.New(t)
+
+	pk := memorySigner.NewTestSigner("node validate roles test").Public()
+	consensusAddrs := []ConsensusAddress{{ID: pk}}
+	tlsAddrs := []TLSAddress{{PubKey: pk}}
+	p2pAddrs := []Address{{}}
+
+	for _, tc := range []struct {
+		msg   string
+		n     Node
+		valid bool
+	}{
+		{"validator without consensus address", Node{Roles: RoleValidator}, false},
+		{"validator with consensus address", Node{Roles: RoleValidator, Consensus: ConsensusInfo{Addresses: consensusAddrs}}, true},
+		{"storage without TLS certificate", Node{Roles: RoleStorageWorker, TLS: TLSInfo{Addresses: tlsAddrs}}, false},
+		{"storage without TLS address", Node{Roles: RoleStorageWorker, TLS: TLSInfo{PubKey: pk}}, false},
+		{"storage with TLS certificate and address", Node{Roles: RoleStorageWorker, TLS: TLSInfo{PubKey: pk, Addresses: tlsAddrs}}, true},
+		{"key manager without TLS address", Node{Roles: RoleKeyManager, TLS: TLSInfo{PubKey: pk}}, false},
+		{"key manager with TLS certificate and address", Node{Roles: RoleKeyManager, TLS: TLSInfo{PubKey: pk, Addresses: tlsAddrs}}, true},
+		{"consensus RPC without TLS address", Node{Roles: RoleConsensusRPC, TLS: TLSInfo{PubKey: pk}}, false},
+		{"consensus RPC with TLS certificate and address", Node{Roles: RoleConsensusRPC, TLS: TLSInfo{PubKey: pk, Addresses: tlsAddrs}}, true},
+		{"compute without P2P address", Node{Roles: RoleComputeWorker, TLS: TLSInfo{PubKey: pk, Addresses: tlsAddrs}}, false},
+		{"compute without TLS address", Node{Roles: RoleComputeWorker, P2P: P2PInfo{Addresses: p2pAddrs}}, false},
+		{"compute with TLS and P2P addresses", Node{Roles: RoleComputeWorker, TLS: TLSInfo{PubKey: pk, Addresses: tlsAddrs}, P2P: P2PInfo{Addresses: p2pAddrs}}, true},
+		{"storage RPC without addresses", Node{Roles: RoleStorageRPC}, true},
+		{
+			"compute and validator without consensus address",
+			Node{Roles: RoleComputeWorker | RoleValidator, TLS: TLSInfo{PubKey: pk, Addresses: tlsAddrs}, P2P: P2PInfo{Addresses: p2pAddrs}},
+			false,
+		},
+		{
+			"compute and validator with all addresses",
+			Node{Roles: RoleComputeWorker | RoleValidator, Consensus: ConsensusInfo{Addresses: consensusAddrs}, TLS: TLSInfo{PubKey: pk, Addresses: tlsAddrs}, P2P: P2PInfo{Addresses: p2pAddrs}},
+			true,
+		},
+	} {
+		err := tc.n.ValidateRoles()
+		switch tc.valid {
+		case true:
+			require.NoError(err, tc.msg)
+		case false:
+			require.Error(err, tc.msg)
+		}
+	}
+}
+
+func TestNodePrettyPrint(t *testing.T) {
+	require := require.New(t)
+
+	pk := memorySigner.NewTestSigner("node pretty print test").Public()
+	rtID := common.NewTestNamespaceFromSeed([]byte("node pretty print test"), 0)
+
+	var addr Address
+	err := addr.UnmarshalText([]byte("192.0.2.1:1234"))
+	require.NoError(err, "UnmarshalText")
+
+	n := Node{
+		ID:         pk,
+		EntityID:   pk,
+		Expiration: 42,
+		TLS: TLSInfo{
+			PubKey:    pk,
+			Addresses: []TLSAddress{{PubKey: pk, Address: addr}},
+		},
+		P2P: P2PInfo{
+			ID:        pk,
+			Addresses: []Address{addr},
+		},
+		Runtimes: []*Runtime{
+			{
+				ID: rtID,
+				Capabilities: Capabilities{
+					TEE: &CapabilityTEE{
+						Hardware: TEEHardwareIntelSGX,
+						RAK:      pk,
+					},
+				},
+			},
+		},
+		Roles: RoleComputeWorker | RoleStorageWorker | RoleValidator,
+	}
+
+	var buf bytes.Buffer
+	n.PrettyPrint(context.Background(), "", &buf)
+	out := buf.String()
+
+	require.Contains(out, pk.String(), "output should contain the node ID")
+	require.Contains(out, "Expiration: 42", "output should contain the expiration")
+	require.Contains(out, "compute,storage,validator", "output should contain role names")
+	require.Contains(out, "192.0.2.1:1234", "output should contain addresses")
+	require.Contains(out, rtID.String(), "output should contain runtime IDs")
+	require.Contains(out, TEEHardwareIntelSGX.String(), "output should contain TEE hardware")
+
+	// Pretty printing a multi-signed node should not fail.
+	signed, err := MultiSignNode([]signature.Signer{memorySigner.NewTestSigner("node pretty print test")}, signature.NewContext("node pretty print test"), &n)
+	require.NoError(err, "MultiSignNode")
+	buf.Reset()
+	signed.PrettyPrint(context.Background(), "", &buf)
+	require.Contains(buf.String(), pk.String(), "output should contain the node ID")
+}
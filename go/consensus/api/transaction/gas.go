@@ -19,6 +19,10 @@ var (
 	// ErrGasPriceTooLow is the error returned when the gas price is too low.
 	ErrGasPriceTooLow = errors.New(moduleName, 3, "transaction: gas price too low")
 
+	// ErrGasLimitTooHigh is the error returned when the gas limit exceeds the
+	// maximum allowed per transaction.
+	ErrGasLimitTooHigh = errors.New(moduleName, 5, "transaction: gas limit too high")
+
 	_ prettyprint.PrettyPrinter = (*Fee)(nil)
 )
 
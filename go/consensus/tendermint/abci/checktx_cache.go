@@ -0,0 +1,79 @@
+package abci
+
+import (
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+// checkTxCache is a bounded cache of failed CheckTx results, used to avoid
+// re-validating identical transactions that are known to be invalid.
+//
+// Only failed results are cached as successfully checking a transaction
+// updates the CheckTx state (e.g., by incrementing the signer's nonce), so
+// checking the same transaction again would yield a different result. For the
+// same reason, the entire cache is invalidated whenever a transaction passes
+// CheckTx or a block is committed, as either may cause a previously invalid
+// transaction to become valid.
+type checkTxCache struct {
+	cache *lru.Cache
+}
+
+type checkTxCacheEntry struct {
+	height int64
+	resp   types.ResponseCheckTx
+}
+
+// check returns the cached result for the given transaction at the given
+// height if one exists, otherwise it calls fn to check the transaction and
+// updates the cache based on the result.
+func (c *checkTxCache) check(txHash hash.Hash, height int64, fn func() types.ResponseCheckTx) types.ResponseCheckTx {
+	if c == nil {
+		return fn()
+	}
+
+	if v, ok := c.cache.Get(txHash); ok {
+		entry := v.(*checkTxCacheEntry)
+		if entry.height == height {
+			return entry.resp
+		}
+	}
+
+	resp := fn()
+	switch resp.IsOK() {
+	case true:
+		// State has changed, so cached results may no longer be valid.
+		c.cache.Clear()
+	case false:
+		_ = c.cache.Put(txHash, &checkTxCacheEntry{
+			height: height,
+			resp:   resp,
+		})
+	}
+	return resp
+}
+
+// invalidate invalidates all cached results.
+func (c *checkTxCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.cache.Clear()
+}
+
+// newCheckTxCache creates a new CheckTx cache with the given capacity. In case
+// the capacity is zero, nil is returned and caching is disabled.
+func newCheckTxCache(capacity uint64) (*checkTxCache, error) {
+	if capacity == 0 {
+		return nil, nil
+	}
+
+	cache, err := lru.New(lru.Capacity(capacity, false))
+	if err != nil {
+		return nil, err
+	}
+	return &checkTxCache{
+		cache: cache,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package abci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+func TestCheckTxCache(t *testing.T) {
+	require := require.New(t)
+
+	cache, err := newCheckTxCache(2)
+	require.NoError(err, "newCheckTxCache")
+
+	var calls int
+	checkFn := func(code uint32) func() types.ResponseCheckTx {
+		return func() types.ResponseCheckTx {
+			calls++
+			return types.ResponseCheckTx{Code: code, Codespace: "test"}
+		}
+	}
+
+	txA := hash.NewFromBytes([]byte("tx a"))
+	txB := hash.NewFromBytes([]byte("tx b"))
+	txC := hash.NewFromBytes([]byte("tx c"))
+	txOK := hash.NewFromBytes([]byte("tx ok"))
+
+	// Checking the same failing transaction twice within a height should only
+	// call CheckTx once.
+	resp := cache.check(txA, 10, checkFn(1))
+	require.EqualValues(1, resp.Code)
+	resp = cache.check(txA, 10, checkFn(1))
+	require.EqualValues(1, resp.Code, "cached result should be returned")
+	require.Equal(1, calls, "CheckTx should only be called once within a height")
+
+	// Cached results should not be used at a different height.
+	_ = cache.check(txA, 11, checkFn(1))
+	require.Equal(2, calls, "CheckTx should be called at a different height")
+
+	// Successful results should not be cached and should invalidate the cache.
+	_ = cache.check(txOK, 11, checkFn(types.CodeTypeOK))
+	_ = cache.check(txOK, 11, checkFn(types.CodeTypeOK))
+	require.Equal(4, calls, "successful results should not be cached")
+	_ = cache.check(txA, 11, checkFn(1))
+	require.Equal(5, calls, "successful CheckTx should invalidate the cache")
+
+	// Invalidation should clear the cache.
+	cache.invalidate()
+	_ = cache.check(txA, 11, checkFn(1))
+	require.Equal(6, calls, "invalidation should clear the cache")
+
+	// The cache should be bounded.
+	_ = cache.check(txB, 11, checkFn(1))
+	_ = cache.check(txC, 11, checkFn(1))
+	require.Equal(8, calls)
+	require.EqualValues(2, cache.cache.Size(), "cache should be bounded")
+	_ = cache.check(txA, 11, checkFn(1))
+	require.Equal(9, calls, "evicted results should be re-checked")
+
+	// A disabled cache should always call CheckTx.
+	cache, err = newCheckTxCache(0)
+	require.NoError(err, "newCheckTxCache")
+	require.Nil(cache, "zero capacity should disable the cache")
+	_ = cache.check(txA, 11, checkFn(1))
+	_ = cache.check(txA, 11, checkFn(1))
+	require.Equal(11, calls, "disabled cache should always call CheckTx")
+	cache.invalidate()
+}
@@ -0,0 +1,95 @@
+package abci
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+// DumpState dumps the latest committed ABCI application state stored in the
+// given data directory as a map of raw keys to values.
+//
+// The dump only depends on the state contents, and any canonical encoding of
+// it (e.g., CBOR, which sorts map keys) is deterministic, so it can be used for
+// golden-state tests of application state migrations.
+func DumpState(ctx context.Context, dataDir string) (map[string][]byte, error) {
+	ldb, ndb, stateRoot, err := InitStateStorage(ctx, &ApplicationConfig{
+		DataDir:         dataDir,
+		StorageBackend:  storageDB.BackendNameBadgerDB,
+		ReadOnlyStorage: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("abci: failed to open state storage: %w", err)
+	}
+	defer ldb.Cleanup()
+
+	tree := mkvs.NewWithRoot(nil, ndb, *stateRoot, mkvs.WithoutWriteLog())
+	defer tree.Close()
+
+	it := tree.NewIterator(ctx)
+	defer it.Close()
+
+	kv := make(map[string][]byte)
+	for it.Rewind(); it.Valid(); it.Next() {
+		kv[string(it.Key())] = append([]byte{}, it.Value()...)
+	}
+	if err = it.Err(); err != nil {
+		return nil, fmt.Errorf("abci: failed to iterate state: %w", err)
+	}
+	return kv, nil
+}
+
+// LoadStateInto loads the given ABCI application state, as returned by
+// DumpState, into the state storage of an empty data directory and commits it
+// at the given version.
+//
+// The resulting state root is returned.
+func LoadStateInto(ctx context.Context, dataDir string, version uint64, kv map[string][]byte) (*storage.Root, error) {
+	ldb, ndb, stateRoot, err := InitStateStorage(ctx, &ApplicationConfig{
+		DataDir:        dataDir,
+		StorageBackend: storageDB.BackendNameBadgerDB,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("abci: failed to open state storage: %w", err)
+	}
+	defer ldb.Cleanup()
+
+	if stateRoot.Version != 0 || !stateRoot.Hash.IsEmpty() {
+		return nil, fmt.Errorf("abci: state storage is not empty (version: %d)", stateRoot.Version)
+	}
+
+	tree := mkvs.New(nil, ndb, storage.RootTypeState, mkvs.WithoutWriteLog())
+	defer tree.Close()
+
+	// Insert keys in sorted order so that loading is deterministic.
+	keys := make([]string, 0, len(kv))
+	for k := range kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err = tree.Insert(ctx, []byte(k), kv[k]); err != nil {
+			return nil, fmt.Errorf("abci: failed to insert state key: %w", err)
+		}
+	}
+
+	_, rootHash, err := tree.Commit(ctx, common.Namespace{}, version)
+	if err != nil {
+		return nil, fmt.Errorf("abci: failed to commit state: %w", err)
+	}
+	root := storage.Root{
+		Namespace: common.Namespace{},
+		Version:   version,
+		Type:      storage.RootTypeState,
+		Hash:      rootHash,
+	}
+	if err = ndb.Finalize(ctx, []storage.Root{root}); err != nil {
+		return nil, fmt.Errorf("abci: failed to finalize state: %w", err)
+	}
+	return &root, nil
+}
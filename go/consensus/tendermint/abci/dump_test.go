@@ -0,0 +1,85 @@
+package abci
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	abciState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
+)
+
+func newTestApplicationState(t *testing.T, dataDir string) *applicationState {
+	s, err := newApplicationState(context.Background(), nil, &ApplicationConfig{
+		DataDir:             dataDir,
+		StorageBackend:      storageDB.BackendNameBadgerDB,
+		Pruning:             PruneConfig{Strategy: PruneNone},
+		DisableCheckpointer: true,
+		InitialHeight:       1,
+	})
+	require.NoError(t, err, "newApplicationState")
+	return s
+}
+
+func TestDumpAndLoadState(t *testing.T) {
+	require := require.New(t)
+
+	srcDir, err := ioutil.TempDir("", "abci-dump.test.src")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(srcDir)
+	dstDir, err := ioutil.TempDir("", "abci-dump.test.dst")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dstDir)
+
+	// Commit some state.
+	now := time.Unix(1580461674, 0)
+	s := newTestApplicationState(t, srcDir)
+	ctx := s.NewContext(api.ContextDeliverTx, now)
+	err = abciState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+		MaxTxSize: 32 * 1024,
+	})
+	require.NoError(err, "SetConsensusParameters")
+	for i := 0; i < 100; i++ {
+		err = ctx.State().Insert(ctx, []byte(fmt.Sprintf("key:%d", i)), []byte(fmt.Sprintf("value:%d", i)))
+		require.NoError(err, "Insert")
+	}
+	ctx.Close()
+	_, err = s.doCommit(now)
+	require.NoError(err, "doCommit")
+	blockHeight := s.BlockHeight()
+	blockHash := s.BlockHash()
+	s.doCleanup()
+
+	// Dump the state.
+	dump, err := DumpState(context.Background(), srcDir)
+	require.NoError(err, "DumpState")
+	require.Len(dump, 101, "dump should contain all keys")
+	require.EqualValues([]byte("value:42"), dump["key:42"], "dumped value should be correct")
+
+	// The dump should be deterministic.
+	dump2, err := DumpState(context.Background(), srcDir)
+	require.NoError(err, "DumpState")
+	require.EqualValues(cbor.Marshal(dump), cbor.Marshal(dump2), "dump should be deterministic")
+
+	// Reload the state into a fresh directory.
+	root, err := LoadStateInto(context.Background(), dstDir, uint64(blockHeight), dump)
+	require.NoError(err, "LoadStateInto")
+	require.EqualValues(blockHash, root.Hash[:], "reloaded state root should match")
+
+	_, err = LoadStateInto(context.Background(), dstDir, uint64(blockHeight), dump)
+	require.Error(err, "LoadStateInto into a non-empty directory should fail")
+
+	s = newTestApplicationState(t, dstDir)
+	defer s.doCleanup()
+	require.EqualValues(blockHeight, s.BlockHeight(), "reloaded block height should match")
+	require.EqualValues(blockHash, s.BlockHash(), "reloaded block hash should match")
+	require.EqualValues(32*1024, s.ConsensusParameters().MaxTxSize, "reloaded consensus parameters should match")
+}
@@ -70,6 +70,10 @@ type ApplicationConfig struct { // nolint: maligned
 	HaltEpochHeight beacon.EpochTime
 	MinGasPrice     uint64
 
+	// MaxTxGas is the maximum gas limit a transaction can declare in order to be accepted into
+	// the local mempool (0 means no limit).
+	MaxTxGas uint64
+
 	DisableCheckpointer       bool
 	CheckpointerCheckInterval time.Duration
 
@@ -82,8 +86,16 @@ type ApplicationConfig struct { // nolint: maligned
 	// ReadOnlyStorage forces read-only access for the state storage.
 	ReadOnlyStorage bool
 
+	// PinnedVersion, if non-zero, is the historical state version that is loaded instead of the
+	// latest one. The loaded state is read-only and any attempt to commit it will fail.
+	PinnedVersion uint64
+
 	// InitialHeight is the height of the initial block.
 	InitialHeight uint64
+
+	// CheckTxCacheSize is the maximum number of cached failed CheckTx results
+	// (0 disables the cache).
+	CheckTxCacheSize uint64
 }
 
 // ApplicationServer implements a tendermint ABCI application + socket server,
@@ -129,12 +141,42 @@ func (a *ApplicationServer) Mux() types.Application {
 //
 // All registration must be done before Start is called.  ABCI operations
 // that act on every single app (InitChain, BeginBlock, EndBlock) will be
-// called in name lexicographic order. Checks that applications named in
-// deps are already registered.
+// called in dependency order, such that an application is always called
+// after all of its dependencies, with ties broken by name lexicographic
+// order. Registering an application that would introduce a dependency cycle
+// fails. Start checks that all dependencies are registered.
 func (a *ApplicationServer) Register(app api.Application) error {
 	return a.mux.doRegister(app)
 }
 
+// RegisteredApps returns the sorted names of all registered applications.
+func (a *ApplicationServer) RegisteredApps() []string {
+	apps := a.RegisteredAppsInfo()
+	names := make([]string, 0, len(apps))
+	for _, app := range apps {
+		names = append(names, app.Name)
+	}
+	return names
+}
+
+// RegisteredAppsInfo returns information about all registered applications,
+// sorted by name.
+func (a *ApplicationServer) RegisteredAppsInfo() []*api.ApplicationInfo {
+	apps := make([]*api.ApplicationInfo, 0, len(a.mux.appsByName))
+	for name, app := range a.mux.appsByName {
+		apps = append(apps, &api.ApplicationInfo{
+			Name:      name,
+			ID:        app.ID(),
+			EventType: api.EventTypeForApp(name),
+			Blessed:   app.Blessed(),
+		})
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Name < apps[j].Name
+	})
+	return apps
+}
+
 // RegisterHaltHook registers a function to be called when the
 // consensus Halt epoch height is reached.
 func (a *ApplicationServer) RegisterHaltHook(hook consensus.HaltHook) {
@@ -212,15 +254,20 @@ type abciMux struct {
 	state  *applicationState
 
 	appsByName     map[string]api.Application
+	appsByID       map[uint8]api.Application
 	appsByMethod   map[transaction.MethodName]api.Application
-	appsByLexOrder []api.Application
+	appsByDepOrder []api.Application
 	appBlessed     api.Application
 
 	lastBeginBlock int64
 	currentTime    time.Time
 
+	maxTxGas transaction.Gas
+
 	haltHooks []consensus.HaltHook
 
+	checkTxCache *checkTxCache
+
 	// invalidatedTxs maps transaction hashes (hash.Hash) to a subscriber
 	// waiting for that transaction to become invalid.
 	invalidatedTxs sync.Map
@@ -320,7 +367,7 @@ func (mux *abciMux) InitChain(req types.RequestInitChain) types.ResponseInitChai
 	ctx := mux.state.NewContext(api.ContextInitChain, mux.currentTime)
 	defer ctx.Close()
 
-	for _, app := range mux.appsByLexOrder {
+	for _, app := range mux.appsByDepOrder {
 		mux.logger.Debug("InitChain: calling InitChain on application",
 			"app", app.Name(),
 		)
@@ -445,18 +492,11 @@ func (mux *abciMux) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginB
 	}
 
 	// Dispatch BeginBlock to all applications.
-	for _, app := range mux.appsByLexOrder {
-		if err := app.BeginBlock(ctx, req); err != nil {
-			mux.logger.Error("BeginBlock: fatal error in application",
-				"err", err,
-				"app", app.Name(),
-			)
-
-			mux.logger.Debug("dispatching halt hooks on begin block failure")
-			mux.dispatchHaltHooks(blockHeight, currentEpoch, err)
+	if err = mux.dispatchBeginBlock(ctx, req); err != nil {
+		mux.logger.Debug("dispatching halt hooks on begin block failure")
+		mux.dispatchHaltHooks(blockHeight, currentEpoch, err)
 
-			panic(fmt.Errorf("mux: BeginBlock: fatal error in application: '%s': %w", app.Name(), err))
-		}
+		panic(err)
 	}
 
 	response := mux.BaseApplication.BeginBlock(req)
@@ -486,6 +526,25 @@ func (mux *abciMux) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginB
 	return response
 }
 
+// dispatchBeginBlock dispatches BeginBlock to all applications in dependency order.
+//
+// Any error returned by an application's BeginBlock is treated as fatal and dispatch stops at
+// the first failing application. Since the dispatch order only depends on the set of registered
+// applications and their dependencies, all validators report the same failure for the same
+// block, so halting on the returned error is deterministic.
+func (mux *abciMux) dispatchBeginBlock(ctx *api.Context, req types.RequestBeginBlock) error {
+	for _, app := range mux.appsByDepOrder {
+		if err := app.BeginBlock(ctx, req); err != nil {
+			mux.logger.Error("BeginBlock: fatal error in application",
+				"err", err,
+				"app", app.Name(),
+			)
+			return fmt.Errorf("mux: BeginBlock: fatal error in application: '%s': %w", app.Name(), err)
+		}
+	}
+	return nil
+}
+
 func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Transaction, *transaction.SignedTransaction, error) {
 	if mux.state.haltMode {
 		ctx.Logger().Debug("executeTx: in halt, rejecting all transactions")
@@ -607,9 +666,34 @@ func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
 		}
 	}
 
+	// If we are in CheckTx mode, make sure that the transaction does not declare more gas than
+	// the configured per-transaction limit. Critical protocol methods and own transactions are
+	// always accepted. The gas price is checked by the staking application.
+	if ctx.IsCheckOnly() && !tx.Method.IsCritical() && !ctx.TxSigner().Equal(mux.state.OwnTxSigner()) {
+		if err = checkTxGas(tx, mux.maxTxGas); err != nil {
+			ctx.Logger().Debug("rejecting transaction due to gas limit",
+				"tx", tx,
+				"tx_signer", ctx.TxSigner(),
+				"err", err,
+			)
+			return err
+		}
+	}
+
 	return mux.processTx(ctx, tx, len(rawTx))
 }
 
+// checkTxGas checks that the transaction does not declare more than maxTxGas gas (if non-zero).
+func checkTxGas(tx *transaction.Transaction, maxTxGas transaction.Gas) error {
+	if tx.Fee == nil || maxTxGas == 0 {
+		return nil
+	}
+	if tx.Fee.Gas > maxTxGas {
+		return fmt.Errorf("%w (limit: %d wanted: %d)", transaction.ErrGasLimitTooHigh, maxTxGas, tx.Fee.Gas)
+	}
+	return nil
+}
+
 func (mux *abciMux) EstimateGas(caller signature.PublicKey, tx *transaction.Transaction) (transaction.Gas, error) {
 	if tx == nil {
 		return 0, consensus.ErrInvalidArgument
@@ -664,6 +748,25 @@ func (mux *abciMux) notifyInvalidatedCheckTx(txHash hash.Hash, err error) {
 }
 
 func (mux *abciMux) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	if req.Type == types.CheckTxType_Recheck {
+		// Always re-check transactions in order to notify about invalidated
+		// transactions.
+		resp := mux.doCheckTx(req)
+		if resp.IsOK() {
+			// State has changed, so cached results may no longer be valid.
+			mux.checkTxCache.invalidate()
+		}
+		return resp
+	}
+
+	// Avoid re-validating identical transactions known to be invalid.
+	txHash := hash.NewFromBytes(req.Tx)
+	return mux.checkTxCache.check(txHash, mux.state.BlockHeight(), func() types.ResponseCheckTx {
+		return mux.doCheckTx(req)
+	})
+}
+
+func (mux *abciMux) doCheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
 	ctx := mux.state.NewContext(api.ContextCheckTx, mux.currentTime)
 	defer ctx.Close()
 
@@ -748,7 +851,7 @@ func (mux *abciMux) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
 
 	// Dispatch EndBlock to all applications.
 	resp := mux.BaseApplication.EndBlock(req)
-	for _, app := range mux.appsByLexOrder {
+	for _, app := range mux.appsByDepOrder {
 		newResp, err := app.EndBlock(ctx, req)
 		if err != nil {
 			mux.logger.Error("EndBlock: fatal error in application",
@@ -804,6 +907,9 @@ func (mux *abciMux) Commit() types.ResponseCommit {
 		panic(err)
 	}
 
+	// State has changed, so cached CheckTx results are no longer valid.
+	mux.checkTxCache.invalidate()
+
 	mux.logger.Debug("Commit",
 		"block_height", mux.state.BlockHeight(),
 		"block_hash", hex.EncodeToString(mux.state.BlockHash()),
@@ -1079,32 +1185,52 @@ func (mux *abciMux) ApplySnapshotChunk(req types.RequestApplySnapshotChunk) type
 func (mux *abciMux) doCleanup() {
 	mux.state.doCleanup()
 
-	for _, v := range mux.appsByLexOrder {
+	for _, v := range mux.appsByDepOrder {
 		v.OnCleanup()
 	}
 }
 
 func (mux *abciMux) doRegister(app api.Application) error {
+	// Make sure that the application doesn't conflict with any of the already
+	// registered applications before altering any state.
 	name := app.Name()
 	if mux.appsByName[name] != nil {
 		return fmt.Errorf("mux: application already registered: '%s'", name)
 	}
-	if app.Blessed() {
-		// Enforce the 1 blessed app limitation.
-		if mux.appBlessed != nil {
-			return fmt.Errorf("mux: blessed application already exists")
-		}
-		mux.appBlessed = app
+	id := app.ID()
+	if other := mux.appsByID[id]; other != nil {
+		return fmt.Errorf("mux: application identifier 0x%02x already registered by '%s'", id, other.Name())
 	}
-
-	mux.appsByName[name] = app
+	// Enforce the 1 blessed app limitation.
+	if app.Blessed() && mux.appBlessed != nil {
+		return fmt.Errorf("mux: blessed application already exists")
+	}
+	methods := make(map[transaction.MethodName]bool)
 	for _, m := range app.Methods() {
-		if _, exists := mux.appsByMethod[m]; exists {
+		if _, exists := mux.appsByMethod[m]; exists || methods[m] {
 			return fmt.Errorf("mux: method already registered: %s", m)
 		}
+		methods[m] = true
+	}
+	appsByName := make(map[string]api.Application, len(mux.appsByName)+1)
+	for n, a := range mux.appsByName {
+		appsByName[n] = a
+	}
+	appsByName[name] = app
+	appsByDepOrder, err := sortAppsByDependencies(appsByName)
+	if err != nil {
+		return err
+	}
+
+	if app.Blessed() {
+		mux.appBlessed = app
+	}
+	mux.appsByName = appsByName
+	mux.appsByID[id] = app
+	for m := range methods {
 		mux.appsByMethod[m] = app
 	}
-	mux.rebuildAppLexOrdering() // Inefficient but not a lot of apps.
+	mux.appsByDepOrder = appsByDepOrder
 
 	app.OnRegister(mux.state, &mux.md)
 	mux.logger.Debug("Registered new application",
@@ -1114,18 +1240,62 @@ func (mux *abciMux) doRegister(app api.Application) error {
 	return nil
 }
 
-func (mux *abciMux) rebuildAppLexOrdering() {
-	numApps := len(mux.appsByName)
-	appOrder := make([]string, 0, numApps)
-	for name := range mux.appsByName {
-		appOrder = append(appOrder, name)
+// sortAppsByDependencies orders the given applications such that each
+// application comes after all of its (registered) dependencies, breaking ties
+// by name lexicographic order. An error is returned in case of a dependency
+// cycle.
+func sortAppsByDependencies(appsByName map[string]api.Application) ([]api.Application, error) {
+	// Count the number of unprocessed registered dependencies of each
+	// application and track the reverse dependencies.
+	pendingDeps := make(map[string]int, len(appsByName))
+	dependents := make(map[string][]string)
+	for name, app := range appsByName {
+		pendingDeps[name] = 0
+		seen := make(map[string]bool)
+		for _, dep := range app.Dependencies() {
+			if _, ok := appsByName[dep]; !ok || seen[dep] {
+				// Missing dependencies are checked on start.
+				continue
+			}
+			seen[dep] = true
+			pendingDeps[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
 	}
-	sort.Strings(appOrder)
 
-	mux.appsByLexOrder = make([]api.Application, 0, numApps)
-	for _, name := range appOrder {
-		mux.appsByLexOrder = append(mux.appsByLexOrder, mux.appsByName[name])
+	var ready []string
+	for name, n := range pendingDeps {
+		if n == 0 {
+			ready = append(ready, name)
+		}
 	}
+
+	ordered := make([]api.Application, 0, len(appsByName))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+
+		ordered = append(ordered, appsByName[name])
+		for _, dependent := range dependents[name] {
+			pendingDeps[dependent]--
+			if pendingDeps[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(ordered) != len(appsByName) {
+		var cycle []string
+		for name, n := range pendingDeps {
+			if n > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("mux: dependency cycle detected (unordered applications: %v)", cycle)
+	}
+	return ordered, nil
 }
 
 func (mux *abciMux) checkDependencies() error {
@@ -1144,7 +1314,18 @@ func (mux *abciMux) checkDependencies() error {
 }
 
 func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationConfig) (*abciMux, error) {
-	state, err := newApplicationState(ctx, upgrader, cfg)
+	checkTxCache, err := newCheckTxCache(cfg.CheckTxCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("mux: failed to create CheckTx cache: %w", err)
+	}
+
+	var state *applicationState
+	switch cfg.PinnedVersion {
+	case 0:
+		state, err = newApplicationState(ctx, upgrader, cfg)
+	default:
+		state, err = newApplicationStateAtVersion(ctx, upgrader, cfg, cfg.PinnedVersion)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1153,8 +1334,11 @@ func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationC
 		logger:         logging.GetLogger("abci-mux"),
 		state:          state,
 		appsByName:     make(map[string]api.Application),
+		appsByID:       make(map[uint8]api.Application),
 		appsByMethod:   make(map[transaction.MethodName]api.Application),
 		lastBeginBlock: blockHeightInvalid,
+		maxTxGas:       transaction.Gas(cfg.MaxTxGas),
+		checkTxCache:   checkTxCache,
 	}
 
 	mux.logger.Debug("ABCI multiplexer initialized",
@@ -0,0 +1,246 @@
+package abci
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	tmabcitypes "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+type testApplication struct {
+	name    string
+	id      uint8
+	methods []transaction.MethodName
+	blessed bool
+	deps    []string
+
+	beginBlockErr error
+
+	registered  bool
+	beganBlocks int
+}
+
+func (app *testApplication) Name() string {
+	return app.name
+}
+
+func (app *testApplication) ID() uint8 {
+	return app.id
+}
+
+func (app *testApplication) Methods() []transaction.MethodName {
+	return app.methods
+}
+
+func (app *testApplication) Blessed() bool {
+	return app.blessed
+}
+
+func (app *testApplication) Dependencies() []string {
+	return app.deps
+}
+
+func (app *testApplication) QueryFactory() interface{} {
+	return nil
+}
+
+func (app *testApplication) OnRegister(state api.ApplicationState, md api.MessageDispatcher) {
+	app.registered = true
+}
+
+func (app *testApplication) OnCleanup() {
+}
+
+func (app *testApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) error {
+	return nil
+}
+
+func (app *testApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
+	return nil
+}
+
+func (app *testApplication) InitChain(ctx *api.Context, req tmabcitypes.RequestInitChain, doc *genesis.Document) error {
+	return nil
+}
+
+func (app *testApplication) BeginBlock(ctx *api.Context, req tmabcitypes.RequestBeginBlock) error {
+	app.beganBlocks++
+	return app.beginBlockErr
+}
+
+func (app *testApplication) EndBlock(ctx *api.Context, req tmabcitypes.RequestEndBlock) (tmabcitypes.ResponseEndBlock, error) {
+	return tmabcitypes.ResponseEndBlock{}, nil
+}
+
+func TestMuxRegister(t *testing.T) {
+	require := require.New(t)
+
+	mux := newTestMux()
+
+	methodA := transaction.MethodName("test_a.MethodA")
+	methodB := transaction.MethodName("test_b.MethodB")
+
+	appA := &testApplication{name: "test_a", id: 0x01, methods: []transaction.MethodName{methodA}, blessed: true}
+	err := mux.doRegister(appA)
+	require.NoError(err, "registering the first application should succeed")
+	require.True(appA.registered, "OnRegister should be called")
+
+	for _, tc := range []struct {
+		name string
+		app  *testApplication
+	}{
+		{"SameName", &testApplication{name: "test_a", id: 0x02}},
+		{"SameID", &testApplication{name: "test_b", id: 0x01, methods: []transaction.MethodName{methodB}}},
+		{"SameMethod", &testApplication{name: "test_b", id: 0x02, methods: []transaction.MethodName{methodB, methodA}}},
+		{"DuplicateMethod", &testApplication{name: "test_b", id: 0x02, methods: []transaction.MethodName{methodB, methodB}}},
+		{"SecondBlessed", &testApplication{name: "test_b", id: 0x02, blessed: true}},
+	} {
+		err = mux.doRegister(tc.app)
+		require.Error(err, "registering a conflicting application should fail (%s)", tc.name)
+		require.False(tc.app.registered, "OnRegister should not be called (%s)", tc.name)
+
+		// A failed registration must not alter any state.
+		require.Len(mux.appsByName, 1, "failed registration should not add the application (%s)", tc.name)
+		require.Len(mux.appsByID, 1, "failed registration should not add the identifier (%s)", tc.name)
+		require.Len(mux.appsByMethod, 1, "failed registration should not add methods (%s)", tc.name)
+		require.Equal(appA, mux.appsByID[0x01], "failed registration should not overwrite identifiers (%s)", tc.name)
+		require.Equal(appA, mux.appsByMethod[methodA], "failed registration should not overwrite methods (%s)", tc.name)
+		require.Equal(appA, mux.appBlessed, "failed registration should not overwrite the blessed application (%s)", tc.name)
+	}
+
+	appB := &testApplication{name: "test_b", id: 0x02, methods: []transaction.MethodName{methodB}}
+	err = mux.doRegister(appB)
+	require.NoError(err, "registering a non-conflicting application should succeed")
+	require.True(appB.registered, "OnRegister should be called")
+	require.Equal(appB, mux.appsByID[0x02])
+	require.Equal(appB, mux.appsByMethod[methodB])
+	require.Equal([]api.Application{appA, appB}, mux.appsByDepOrder)
+}
+
+func newTestMux() *abciMux {
+	return &abciMux{
+		logger:       logging.GetLogger("abci-mux/test"),
+		appsByName:   make(map[string]api.Application),
+		appsByID:     make(map[uint8]api.Application),
+		appsByMethod: make(map[transaction.MethodName]api.Application),
+	}
+}
+
+func appNames(apps []api.Application) []string {
+	names := make([]string, 0, len(apps))
+	for _, app := range apps {
+		names = append(names, app.Name())
+	}
+	return names
+}
+
+func TestMuxDependencyOrder(t *testing.T) {
+	require := require.New(t)
+
+	mux := newTestMux()
+
+	// Register applications out of dependency order.
+	for i, app := range []*testApplication{
+		{name: "a", deps: []string{"d", "c"}},
+		{name: "b"},
+		{name: "c", deps: []string{"d"}},
+		{name: "d"},
+		{name: "e", deps: []string{"a", "missing"}},
+	} {
+		app.id = uint8(i)
+		err := mux.doRegister(app)
+		require.NoError(err, "doRegister(%s)", app.name)
+	}
+	require.Equal([]string{"b", "d", "c", "a", "e"}, appNames(mux.appsByDepOrder), "applications should be ordered by dependencies")
+
+	// Missing dependencies should be reported.
+	err := mux.checkDependencies()
+	require.Error(err, "checkDependencies should fail with missing dependencies")
+
+	// Registering an application introducing a cycle should fail.
+	mux = newTestMux()
+	for i, app := range []*testApplication{
+		{name: "a", deps: []string{"c"}},
+		{name: "b", deps: []string{"a"}},
+	} {
+		app.id = uint8(i)
+		err = mux.doRegister(app)
+		require.NoError(err, "doRegister(%s)", app.name)
+	}
+	order := appNames(mux.appsByDepOrder)
+
+	cyclic := &testApplication{name: "c", id: 2, deps: []string{"b"}}
+	err = mux.doRegister(cyclic)
+	require.Error(err, "doRegister should fail on dependency cycles")
+	require.False(cyclic.registered, "OnRegister should not be called")
+	require.Len(mux.appsByName, 2, "failed registration should not add the application")
+	require.Equal(order, appNames(mux.appsByDepOrder), "failed registration should not alter the order")
+
+	selfCyclic := &testApplication{name: "d", id: 3, deps: []string{"d"}}
+	err = mux.doRegister(selfCyclic)
+	require.Error(err, "doRegister should fail on self dependencies")
+
+	// Without the cycle, the application should be registered.
+	err = mux.doRegister(&testApplication{name: "c", id: 2})
+	require.NoError(err, "doRegister(c)")
+	require.Equal([]string{"c", "a", "b"}, appNames(mux.appsByDepOrder), "applications should be ordered by dependencies")
+	require.NoError(mux.checkDependencies(), "checkDependencies")
+}
+
+func TestRegisteredApps(t *testing.T) {
+	require := require.New(t)
+
+	srv := &ApplicationServer{mux: newTestMux()}
+	require.Empty(srv.RegisteredApps(), "no applications should be registered")
+
+	for _, app := range []*testApplication{
+		{name: "test_c", id: 0x03},
+		{name: "test_a", id: 0x01, blessed: true},
+		{name: "test_b", id: 0x02},
+	} {
+		err := srv.Register(app)
+		require.NoError(err, "Register")
+	}
+
+	require.Equal([]string{"test_a", "test_b", "test_c"}, srv.RegisteredApps(), "registered applications should be enumerated")
+	require.Equal([]*api.ApplicationInfo{
+		{Name: "test_a", ID: 0x01, EventType: api.EventTypeForApp("test_a"), Blessed: true},
+		{Name: "test_b", ID: 0x02, EventType: api.EventTypeForApp("test_b")},
+		{Name: "test_c", ID: 0x03, EventType: api.EventTypeForApp("test_c")},
+	}, srv.RegisteredAppsInfo(), "registered application information should be correct")
+}
+
+func TestCheckTxGas(t *testing.T) {
+	require := require.New(t)
+
+	newTx := func(gas transaction.Gas) *transaction.Transaction {
+		return &transaction.Transaction{Fee: &transaction.Fee{Gas: gas}}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		tx          *transaction.Transaction
+		maxTxGas    transaction.Gas
+		expectedErr error
+	}{
+		{"NoFee", &transaction.Transaction{}, 1000, nil},
+		{"WithinLimit", newTx(1000), 1000, nil},
+		{"Unlimited", newTx(1000000), 0, nil},
+		{"OverLimit", newTx(1001), 1000, transaction.ErrGasLimitTooHigh},
+	} {
+		err := checkTxGas(tc.tx, tc.maxTxGas)
+		switch tc.expectedErr {
+		case nil:
+			require.NoError(err, "checkTxGas should succeed (%s)", tc.name)
+		default:
+			require.ErrorIs(err, tc.expectedErr, "checkTxGas should fail (%s)", tc.name)
+		}
+	}
+}
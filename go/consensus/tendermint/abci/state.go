@@ -3,6 +3,7 @@ package abci
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -34,6 +35,10 @@ var _ api.ApplicationState = (*applicationState)(nil)
 // appStateDir is the subdirectory which contains ABCI state.
 const appStateDir = "abci-state"
 
+// errStatePinned is the error returned when attempting to modify a state that is pinned to a
+// historical version.
+var errStatePinned = errors.New("state: state is pinned to a historical version")
+
 type applicationState struct { // nolint: maligned
 	logger *logging.Logger
 
@@ -66,6 +71,8 @@ type applicationState struct { // nolint: maligned
 	haltMode        bool
 	haltEpochHeight beacon.EpochTime
 
+	pinned bool
+
 	minGasPrice        quantity.Quantity
 	ownTxSigner        signature.PublicKey
 	ownTxSignerAddress staking.Address
@@ -283,6 +290,10 @@ func (s *applicationState) doApplyStateSync(root storage.Root) error {
 	s.blockLock.Lock()
 	defer s.blockLock.Unlock()
 
+	if s.pinned {
+		return errStatePinned
+	}
+
 	s.stateRoot = root
 
 	s.deliverTxTree.Close()
@@ -301,6 +312,10 @@ func (s *applicationState) doCommit(now time.Time) (uint64, error) {
 	s.blockLock.Lock()
 	defer s.blockLock.Unlock()
 
+	if s.pinned {
+		return 0, errStatePinned
+	}
+
 	_, stateRootHash, err := s.deliverTxTree.Commit(s.ctx, s.stateRoot.Namespace, s.stateRoot.Version+1)
 	if err != nil {
 		return 0, fmt.Errorf("failed to commit: %w", err)
@@ -483,18 +498,33 @@ func InitStateStorage(ctx context.Context, cfg *ApplicationConfig) (storage.Loca
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	roots, err := ndb.GetRootsForVersion(ctx, latestVersion)
+	version := latestVersion
+	if cfg.PinnedVersion != 0 {
+		// Use the pinned version instead, if it is available.
+		var earliestVersion uint64
+		earliestVersion, err = ndb.GetEarliestVersion(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if cfg.PinnedVersion < earliestVersion || cfg.PinnedVersion > latestVersion {
+			return nil, nil, nil, fmt.Errorf("state: pinned version %d not available (earliest: %d latest: %d)",
+				cfg.PinnedVersion, earliestVersion, latestVersion,
+			)
+		}
+		version = cfg.PinnedVersion
+	}
+	roots, err := ndb.GetRootsForVersion(ctx, version)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 	stateRoot := &storage.Root{
-		Version: latestVersion,
+		Version: version,
 		Type:    storage.RootTypeState,
 	}
 	switch len(roots) {
 	case 0:
 		// No roots -- empty database.
-		if latestVersion != 0 {
+		if version != 0 {
 			return nil, nil, nil, fmt.Errorf("state: no roots at non-zero height, corrupted database?")
 		}
 		stateRoot.Hash.Empty()
@@ -558,6 +588,7 @@ func newApplicationState(ctx context.Context, upgrader upgrade.Backend, cfg *App
 		ownTxSigner:        cfg.OwnTxSigner,
 		ownTxSignerAddress: staking.NewAddress(cfg.OwnTxSigner),
 		metricsClosedCh:    make(chan struct{}),
+		pinned:             cfg.PinnedVersion != 0,
 	}
 
 	// Refresh consensus parameters when loading state if we are past genesis.
@@ -595,6 +626,28 @@ func newApplicationState(ctx context.Context, upgrader upgrade.Backend, cfg *App
 	return s, nil
 }
 
+// newApplicationStateAtVersion creates a new application state pinned to the given historical
+// version. The state is read-only and is meant for forensic use, e.g., for debugging divergence.
+func newApplicationStateAtVersion(
+	ctx context.Context,
+	upgrader upgrade.Backend,
+	cfg *ApplicationConfig,
+	version uint64,
+) (*applicationState, error) {
+	if version == 0 {
+		return nil, fmt.Errorf("state: pinned version must be non-zero")
+	}
+
+	pinnedCfg := *cfg
+	pinnedCfg.PinnedVersion = version
+	// Make sure nothing modifies the state.
+	pinnedCfg.ReadOnlyStorage = true
+	pinnedCfg.DisableCheckpointer = true
+	pinnedCfg.Pruning = PruneConfig{Strategy: PruneNone}
+
+	return newApplicationState(ctx, upgrader, &pinnedCfg)
+}
+
 func parseGenesisAppState(req types.RequestInitChain) (*genesis.Document, error) {
 	var st genesis.Document
 	if err := json.Unmarshal(req.AppStateBytes, &st); err != nil {
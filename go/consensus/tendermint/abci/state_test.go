@@ -0,0 +1,72 @@
+package abci
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	abciState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci/state"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
+)
+
+func TestApplicationStateAtVersion(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "abci-state.test.pinned")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	// Commit several versions.
+	now := time.Unix(1580461674, 0)
+	key := []byte("key")
+	s := newTestApplicationState(t, dataDir)
+	for i := 1; i <= 5; i++ {
+		ctx := s.NewContext(api.ContextDeliverTx, now)
+		err = abciState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &consensusGenesis.Parameters{
+			MaxTxSize: uint64(i),
+		})
+		require.NoError(err, "SetConsensusParameters")
+		err = ctx.State().Insert(ctx, key, []byte(fmt.Sprintf("value:%d", i)))
+		require.NoError(err, "Insert")
+		ctx.Close()
+
+		_, err = s.doCommit(now)
+		require.NoError(err, "doCommit")
+	}
+	s.doCleanup()
+
+	cfg := &ApplicationConfig{
+		DataDir:        dataDir,
+		StorageBackend: storageDB.BackendNameBadgerDB,
+		InitialHeight:  1,
+	}
+
+	// Versions that do not exist should be rejected.
+	_, err = newApplicationStateAtVersion(context.Background(), nil, cfg, 10)
+	require.Error(err, "loading a non-existent version should fail")
+
+	// Load an earlier version.
+	s, err = newApplicationStateAtVersion(context.Background(), nil, cfg, 3)
+	require.NoError(err, "newApplicationStateAtVersion")
+	defer s.doCleanup()
+
+	require.EqualValues(3, s.BlockHeight(), "block height should reflect the pinned version")
+	require.EqualValues(3, s.ConsensusParameters().MaxTxSize, "consensus parameters should reflect the pinned version")
+
+	ctx := s.NewContext(api.ContextCheckTx, now)
+	defer ctx.Close()
+	value, err := ctx.State().Get(ctx, key)
+	require.NoError(err, "Get")
+	require.EqualValues([]byte("value:3"), value, "queries should reflect the pinned version")
+
+	// The pinned state should be read-only.
+	_, err = s.doCommit(now)
+	require.Equal(errStatePinned, err, "committing a pinned state should fail")
+}
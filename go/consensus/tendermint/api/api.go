@@ -226,6 +226,23 @@ type Backend interface {
 	// GetLastRetainedVersion returns the earliest retained version the ABCI
 	// state.
 	GetLastRetainedVersion(ctx context.Context) (int64, error)
+
+	// GetApplications returns information about the registered ABCI
+	// multiplexer applications.
+	GetApplications(ctx context.Context) ([]*ApplicationInfo, error)
+}
+
+// ApplicationInfo is information about a registered ABCI multiplexer
+// application.
+type ApplicationInfo struct {
+	// Name is the application name.
+	Name string `json:"name"`
+	// ID is the unique application identifier used for transaction routing.
+	ID uint8 `json:"id"`
+	// EventType is the ABCI event type of the application's events.
+	EventType string `json:"event_type"`
+	// Blessed is true iff the application is the blessed application.
+	Blessed bool `json:"blessed,omitempty"`
 }
 
 // TransactionAuthHandler is the interface for ABCI applications that handle
@@ -3,6 +3,7 @@
 package beacon
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 
@@ -145,3 +146,20 @@ func GetBeacon(epoch beacon.EpochTime, entropyCtx, entropy []byte) []byte {
 	_, _ = h.Write(tmp[:])
 	return h.Sum(nil)
 }
+
+// VerifyBeacon verifies that the given beacon was derived from the given
+// epoch and entropy source under the provided consensus parameters.
+//
+// This allows third parties to independently verify the beacon used for
+// committee elections given the entropy that was used to derive it.
+func VerifyBeacon(params *beacon.ConsensusParameters, epoch beacon.EpochTime, value, entropy []byte) error {
+	entropyCtx := prodEntropyCtx
+	if params.DebugDeterministic {
+		entropyCtx = DebugEntropyCtx
+	}
+
+	if !bytes.Equal(GetBeacon(epoch, entropyCtx, entropy), value) {
+		return fmt.Errorf("beacon: beacon for epoch %d does not match entropy", epoch)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
+func TestVerifyBeacon(t *testing.T) {
+	require := require.New(t)
+
+	var params beacon.ConsensusParameters
+	entropy := []byte("test entropy")
+	b := GetBeacon(42, prodEntropyCtx, entropy)
+
+	err := VerifyBeacon(&params, 42, b, entropy)
+	require.NoError(err, "VerifyBeacon should succeed for a correctly derived beacon")
+
+	err = VerifyBeacon(&params, 43, b, entropy)
+	require.Error(err, "VerifyBeacon should fail for a different epoch")
+
+	err = VerifyBeacon(&params, 42, b, []byte("other entropy"))
+	require.Error(err, "VerifyBeacon should fail for different entropy")
+
+	// Deterministic beacons use a different context.
+	params.DebugDeterministic = true
+	err = VerifyBeacon(&params, 42, b, entropy)
+	require.Error(err, "VerifyBeacon should fail for a different context")
+
+	b = GetBeacon(42, DebugEntropyCtx, DebugEntropy)
+	err = VerifyBeacon(&params, 42, b, DebugEntropy)
+	require.NoError(err, "VerifyBeacon should succeed for a deterministic beacon")
+}
@@ -93,7 +93,7 @@ func onPVSSMisbehavior(
 
 	// Slash runtime node entity.
 	entityAddr := staking.NewAddress(node.EntityID)
-	totalSlashed, err := stakeState.SlashEscrow(ctx, entityAddr, &penalty.Amount)
+	totalSlashed, err := stakeState.SlashEscrow(ctx, entityAddr, &penalty.Amount, why)
 	if err != nil {
 		return fmt.Errorf("beacon: error slashing account %s: %w", entityAddr, err)
 	}
@@ -0,0 +1,8 @@
+// Package api defines the governance application API for other applications.
+package api
+
+type messageKind uint8
+
+// MessageUpdateRuntime is the message kind used when dispatching runtime descriptor updates
+// approved by a passed governance proposal. The message is the updated runtime descriptor.
+var MessageUpdateRuntime = messageKind(0)
@@ -10,6 +10,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
 	registryapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
@@ -26,6 +27,7 @@ var _ api.Application = (*governanceApplication)(nil)
 
 type governanceApplication struct {
 	state api.ApplicationState
+	md    api.MessageDispatcher
 }
 
 func (app *governanceApplication) Name() string {
@@ -50,6 +52,7 @@ func (app *governanceApplication) Dependencies() []string {
 
 func (app *governanceApplication) OnRegister(state api.ApplicationState, md api.MessageDispatcher) {
 	app.state = state
+	app.md = md
 
 	// Subscribe to messages emitted by other apps.
 	md.Subscribe(api.MessageStateSyncCompleted, app)
@@ -236,6 +239,30 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 				)
 			}
 		}
+	case proposal.Content.UpdateRuntime != nil:
+		params, err := state.ConsensusParameters(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query consensus parameters: %w", err)
+		}
+		if !params.EnableUpdateRuntimeProposals {
+			return fmt.Errorf("%w: runtime update proposals are disabled", governance.ErrInvalidArgument)
+		}
+
+		// Runtime descriptor updates are executed by the registry application. Gas was
+		// already accounted for when the proposal was submitted.
+		msgCtx := ctx.WithMessageExecution()
+		defer msgCtx.Close()
+		msgCtx.SetGasAccountant(api.NewNopGasAccountant())
+
+		// Make sure that a failed update does not leave any partial state updates behind.
+		cp := msgCtx.StartCheckpoint()
+		defer cp.Close()
+
+		err = app.md.Publish(msgCtx, governanceApi.MessageUpdateRuntime, &proposal.Content.UpdateRuntime.Descriptor)
+		if err != nil {
+			return fmt.Errorf("failed to update runtime: %w", err)
+		}
+		cp.Commit()
 	default:
 		return governance.ErrInvalidArgument
 	}
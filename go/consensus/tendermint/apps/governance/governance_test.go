@@ -19,6 +19,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
@@ -493,6 +494,94 @@ func TestExecuteProposal(t *testing.T) {
 	}
 }
 
+var testMsgDispatcherKey = []byte("test message dispatcher key")
+
+type testMsgDispatcher struct {
+	err       error
+	published []interface{}
+}
+
+// Implements MessageDispatcher.
+func (md *testMsgDispatcher) Subscribe(interface{}, abciAPI.MessageSubscriber) {
+}
+
+// Implements MessageDispatcher.
+func (md *testMsgDispatcher) Publish(ctx *abciAPI.Context, kind, msg interface{}) error {
+	if kind != governanceApi.MessageUpdateRuntime {
+		return abciAPI.ErrNoSubscribers
+	}
+	if ctx.Gas() != abciAPI.NewNopGasAccountant() {
+		panic("runtime updates should not be charged gas")
+	}
+	// Perform a state update so that it can be checked whether it is persisted.
+	if err := ctx.State().Insert(ctx, testMsgDispatcherKey, []byte("updated")); err != nil {
+		return err
+	}
+	if md.err != nil {
+		return md.err
+	}
+	md.published = append(md.published, msg)
+	return nil
+}
+
+func TestExecuteUpdateRuntimeProposal(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	var md testMsgDispatcher
+	state := governanceState.NewMutableState(ctx.State())
+	app := &governanceApplication{
+		state: appState,
+		md:    &md,
+	}
+
+	rt := newTestRuntime("consensus/tendermint/apps/governance: execute update runtime", registry.GovernanceConsensus)
+	proposal := &governance.Proposal{
+		ID: 1,
+		Content: governance.ProposalContent{
+			UpdateRuntime: &governance.UpdateRuntimeProposal{Descriptor: *rt},
+		},
+	}
+
+	// Runtime updates should fail when runtime update proposals are disabled.
+	params := &governance.ConsensusParameters{}
+	err := state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "SetConsensusParameters")
+	err = app.executeProposal(ctx, state, proposal)
+	require.True(errors.Is(err, governance.ErrInvalidArgument), "executing disabled runtime update should fail")
+	require.Equal(governance.StateFailed, proposal.State, "proposal should fail")
+	require.Empty(md.published, "no runtime update should be applied")
+
+	params.EnableUpdateRuntimeProposals = true
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "SetConsensusParameters")
+
+	// Rejected runtime updates should fail the proposal.
+	md.err = registry.ErrRuntimeUpdateNotAllowed
+	err = app.executeProposal(ctx, state, proposal)
+	require.True(errors.Is(err, registry.ErrRuntimeUpdateNotAllowed), "executing rejected runtime update should fail")
+	require.Equal(governance.StateFailed, proposal.State, "proposal should fail")
+	require.Empty(md.published, "no runtime update should be applied")
+	value, err := ctx.State().Get(ctx, testMsgDispatcherKey)
+	require.NoError(err, "Get")
+	require.Nil(value, "rejected runtime update should not leave any state updates behind")
+
+	// Accepted runtime updates should pass the proposal.
+	md.err = nil
+	err = app.executeProposal(ctx, state, proposal)
+	require.NoError(err, "executing runtime update should work")
+	require.Equal(governance.StatePassed, proposal.State, "proposal should pass")
+	require.Len(md.published, 1, "runtime update should be applied")
+	require.EqualValues(rt, md.published[0], "applied runtime descriptor should be correct")
+	value, err = ctx.State().Get(ctx, testMsgDispatcherKey)
+	require.NoError(err, "Get")
+	require.EqualValues([]byte("updated"), value, "accepted runtime update should be persisted")
+}
+
 func TestBeginBlock(t *testing.T) {
 	require := require.New(t)
 	var err error
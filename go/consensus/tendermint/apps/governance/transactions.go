@@ -127,6 +127,35 @@ func (app *governanceApplication) submitProposal(
 		if upgrade.Descriptor.Epoch < params.UpgradeCancelMinEpochDiff+epoch {
 			return governance.ErrUpgradeTooSoon
 		}
+
+	case proposalContent.UpdateRuntime != nil:
+		if !params.EnableUpdateRuntimeProposals {
+			ctx.Logger().Error("governance: runtime update proposals are disabled")
+			return governance.ErrInvalidArgument
+		}
+
+		// Only runtimes using the consensus layer governance model can be updated via
+		// governance proposals.
+		rt := &proposalContent.UpdateRuntime.Descriptor
+		var existingRt *registryAPI.Runtime
+		existingRt, err = registryState.NewMutableState(ctx.State()).AnyRuntime(ctx, rt.ID)
+		if err != nil {
+			ctx.Logger().Error("governance: failed to fetch runtime to update",
+				"runtime_id", rt.ID,
+				"err", err,
+			)
+			return err
+		}
+		if existingRt.GovernanceModel != registryAPI.GovernanceConsensus {
+			ctx.Logger().Error("governance: runtime does not use consensus layer governance",
+				"runtime_id", rt.ID,
+				"governance_model", existingRt.GovernanceModel,
+			)
+			return governance.ErrInvalidArgument
+		}
+		if err = registryAPI.VerifyRuntimeUpdate(ctx.Logger(), existingRt, rt); err != nil {
+			return err
+		}
 	}
 
 	// Deposit proposal funds.
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
@@ -16,9 +18,41 @@ import (
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
+func newTestRuntime(seed string, governanceModel registry.RuntimeGovernanceModel) *registry.Runtime {
+	rt := &registry.Runtime{
+		Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:        common.NewTestNamespaceFromSeed([]byte(seed), 0),
+		Kind:      registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize:    1,
+			RoundTimeout: 5,
+		},
+		TxnScheduler: registry.TxnSchedulerParameters{
+			Algorithm:         registry.TxnSchedulerSimple,
+			BatchFlushTimeout: time.Second,
+			MaxBatchSize:      1,
+			MaxBatchSizeBytes: 1024,
+			ProposerTimeout:   5,
+		},
+		Storage: registry.StorageParameters{
+			GroupSize:               1,
+			MinWriteReplication:     1,
+			MaxApplyWriteLogEntries: 100_000,
+			MaxApplyOps:             2,
+		},
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+		},
+		GovernanceModel: governanceModel,
+	}
+	rt.Genesis.StateRoot.Empty()
+	return rt
+}
+
 func TestSubmitProposal(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -44,6 +78,13 @@ func TestSubmitProposal(t *testing.T) {
 	})
 	require.NoError(err, "SetAccount")
 
+	// Setup registry state.
+	regState := registryState.NewMutableState(ctx.State())
+	entityRt := newTestRuntime("consensus/tendermint/apps/governance: entity governed runtime", registry.GovernanceEntity)
+	consensusRt := newTestRuntime("consensus/tendermint/apps/governance: consensus governed runtime", registry.GovernanceConsensus)
+	updatedConsensusRt := *consensusRt
+	updatedConsensusRt.Executor.GroupSize = 2
+
 	// Setup governance state.
 	state := governanceState.NewMutableState(ctx.State())
 	app := &governanceApplication{
@@ -52,13 +93,14 @@ func TestSubmitProposal(t *testing.T) {
 
 	minProposalDeposit := quantity.NewFromUint64(100)
 	baseConsParams := &governance.ConsensusParameters{
-		GasCosts:                  governance.DefaultGasCosts,
-		MinProposalDeposit:        *minProposalDeposit,
-		Quorum:                    90,
-		Threshold:                 90,
-		UpgradeCancelMinEpochDiff: beacon.EpochTime(100),
-		UpgradeMinEpochDiff:       beacon.EpochTime(100),
-		VotingPeriod:              beacon.EpochTime(50),
+		GasCosts:                     governance.DefaultGasCosts,
+		MinProposalDeposit:           *minProposalDeposit,
+		Quorum:                       90,
+		Threshold:                    90,
+		UpgradeCancelMinEpochDiff:    beacon.EpochTime(100),
+		UpgradeMinEpochDiff:          beacon.EpochTime(100),
+		VotingPeriod:                 beacon.EpochTime(50),
+		EnableUpdateRuntimeProposals: true,
 	}
 
 	for _, tc := range []struct {
@@ -226,6 +268,70 @@ func TestSubmitProposal(t *testing.T) {
 			},
 			governance.ErrUpgradeAlreadyPending,
 		},
+		{
+			"should fail update runtime proposal when runtime update proposals are disabled",
+			func() *governance.ConsensusParameters {
+				params := *baseConsParams
+				params.EnableUpdateRuntimeProposals = false
+				return &params
+			}(),
+			pk1,
+			&governance.ProposalContent{UpdateRuntime: &governance.UpdateRuntimeProposal{
+				Descriptor: *consensusRt,
+			}},
+			func() {},
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should fail update runtime proposal for non-existing runtime",
+			baseConsParams,
+			pk1,
+			&governance.ProposalContent{UpdateRuntime: &governance.UpdateRuntimeProposal{
+				Descriptor: *consensusRt,
+			}},
+			func() {},
+			registry.ErrNoSuchRuntime,
+		},
+		{
+			"should fail update runtime proposal for runtime not using consensus layer governance",
+			baseConsParams,
+			pk1,
+			&governance.ProposalContent{UpdateRuntime: &governance.UpdateRuntimeProposal{
+				Descriptor: *entityRt,
+			}},
+			func() {
+				err = regState.SetRuntime(ctx, entityRt, false)
+				require.NoError(err, "SetRuntime()")
+			},
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should fail update runtime proposal with invalid runtime update",
+			baseConsParams,
+			pk1,
+			&governance.ProposalContent{UpdateRuntime: &governance.UpdateRuntimeProposal{
+				Descriptor: func() registry.Runtime {
+					rt := updatedConsensusRt
+					rt.GovernanceModel = registry.GovernanceEntity
+					return rt
+				}(),
+			}},
+			func() {
+				err = regState.SetRuntime(ctx, consensusRt, false)
+				require.NoError(err, "SetRuntime()")
+			},
+			registry.ErrRuntimeUpdateNotAllowed,
+		},
+		{
+			"should work with valid update runtime proposal",
+			baseConsParams,
+			pk1,
+			&governance.ProposalContent{UpdateRuntime: &governance.UpdateRuntimeProposal{
+				Descriptor: updatedConsensusRt,
+			}},
+			func() {},
+			nil,
+		},
 	} {
 		err = state.SetConsensusParameters(ctx, tc.params)
 		require.NoError(err, "setting governance consensus parameters should not error")
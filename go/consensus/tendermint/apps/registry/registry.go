@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
 	stakingapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
@@ -55,6 +56,7 @@ func (app *registryApplication) OnRegister(state api.ApplicationState, md api.Me
 
 	// Subscribe to messages emitted by other apps.
 	md.Subscribe(roothashApi.RuntimeMessageRegistry, app)
+	md.Subscribe(governanceApi.MessageUpdateRuntime, app)
 }
 
 func (app *registryApplication) OnCleanup() {
@@ -76,10 +78,12 @@ func (app *registryApplication) ExecuteMessage(ctx *api.Context, kind, msg inter
 		m := msg.(*message.RegistryMessage)
 		switch {
 		case m.UpdateRuntime != nil:
-			return app.registerRuntime(ctx, state, m.UpdateRuntime)
+			return app.registerRuntime(ctx, state, m.UpdateRuntime, false)
 		default:
 			return registry.ErrInvalidArgument
 		}
+	case governanceApi.MessageUpdateRuntime:
+		return app.registerRuntime(ctx, state, msg.(*registry.Runtime), true)
 	default:
 		return registry.ErrInvalidArgument
 	}
@@ -117,16 +121,54 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		if err := cbor.Unmarshal(tx.Body, &rt); err != nil {
 			return err
 		}
-		return app.registerRuntime(ctx, state, &rt)
+		return app.registerRuntime(ctx, state, &rt, false)
 	default:
 		return registry.ErrInvalidArgument
 	}
 }
 
 func (app *registryApplication) EndBlock(ctx *api.Context, request types.RequestEndBlock) (types.ResponseEndBlock, error) {
+	// Commit to the node list at the start of the new epoch. This is done at the end of the
+	// block so that it includes any registrations in the epoch transition block.
+	if changed, registryEpoch := app.state.EpochChanged(ctx); changed {
+		if err := app.commitNodeListHash(ctx, registryEpoch); err != nil {
+			return types.ResponseEndBlock{}, err
+		}
+	}
 	return types.ResponseEndBlock{}, nil
 }
 
+func (app *registryApplication) commitNodeListHash(ctx *api.Context, registryEpoch beacon.EpochTime) error {
+	state := registryState.NewMutableState(ctx.State())
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("registry: failed to fetch consensus parameters: %w", err)
+	}
+	if !params.EnableNodeListHash {
+		return nil
+	}
+
+	nodes, err := state.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("registry: failed to get nodes: %w", err)
+	}
+
+	// The node list does not include expired nodes.
+	var nl registry.NodeList
+	for _, n := range nodes {
+		if n.IsExpired(uint64(registryEpoch)) {
+			continue
+		}
+		nl.Nodes = append(nl.Nodes, n)
+	}
+
+	if err = state.SetNodeListHash(ctx, nl.Hash()); err != nil {
+		return fmt.Errorf("registry: failed to set node list hash: %w", err)
+	}
+	return nil
+}
+
 func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registryEpoch beacon.EpochTime) (err error) {
 	state := registryState.NewMutableState(ctx.State())
 	stakeState := stakingState.NewMutableState(ctx.State())
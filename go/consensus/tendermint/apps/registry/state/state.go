@@ -6,6 +6,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/pvss"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
@@ -76,6 +77,11 @@ var (
 	//
 	// Value is binary signature.PublicKey (node ID).
 	beaconPointMapKeyFmt = keyformat.New(0x1a, keyformat.H(&pvss.Point{}))
+	// nodeListHashKeyFmt is the key format used for the hash of the node list
+	// at the start of the current epoch.
+	//
+	// Value is the binary node list hash.
+	nodeListHashKeyFmt = keyformat.New(0x1b)
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -482,6 +488,24 @@ func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*registry.Con
 	return &params, nil
 }
 
+// NodeListHash returns the hash of the node list at the start of the current
+// epoch.
+func (s *ImmutableState) NodeListHash(ctx context.Context) (*hash.Hash, error) {
+	raw, err := s.is.Get(ctx, nodeListHashKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, registry.ErrNoNodeListHash
+	}
+
+	var h hash.Hash
+	if err = h.UnmarshalBinary(raw); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &h, nil
+}
+
 // NodeBySubKey looks up a specific node by its consensus, P2P or TLS key.
 func (s *ImmutableState) NodeBySubKey(ctx context.Context, key signature.PublicKey) (*node.Node, error) {
 	rawID, err := s.is.Get(ctx, keyMapKeyFmt.Encode(&key))
@@ -727,6 +751,13 @@ func (s *MutableState) SetConsensusParameters(ctx context.Context, params *regis
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SetNodeListHash sets the hash of the node list at the start of the current
+// epoch.
+func (s *MutableState) SetNodeListHash(ctx context.Context, h hash.Hash) error {
+	err := s.ms.Insert(ctx, nodeListHashKeyFmt.Encode(), h[:])
+	return abciAPI.UnavailableStateError(err)
+}
+
 // NewMutableState creates a new mutable registry state wrapper.
 func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 	return &MutableState{
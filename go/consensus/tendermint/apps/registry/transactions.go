@@ -573,10 +573,16 @@ func (app *registryApplication) unfreezeNode(
 	return nil
 }
 
+// registerRuntime registers or updates a runtime.
+//
+// If consensusApproved is set, the runtime update has been approved by a consensus layer
+// governance proposal and the transaction signer check is replaced by a check that the
+// existing runtime uses the consensus layer governance model.
 func (app *registryApplication) registerRuntime( // nolint: gocyclo
 	ctx *api.Context,
 	state *registryState.MutableState,
 	rt *registry.Runtime,
+	consensusApproved bool,
 ) error {
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
@@ -639,7 +645,15 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 		}
 	}
 
-	if !ctx.IsInitChain() {
+	switch {
+	case consensusApproved:
+		// Only existing runtimes using the consensus layer governance model can be
+		// updated via governance proposals.
+		if existingRt == nil || existingRt.GovernanceModel != registry.GovernanceConsensus {
+			ctx.Logger().Error("RegisterRuntime: governance proposals can only update runtimes with consensus-layer governance")
+			return registry.ErrForbidden
+		}
+	case !ctx.IsInitChain():
 		// Make sure the signer of the transaction matches the signer of the
 		// entity or runtime that is controlling the runtime.
 		// NOTE: If this is invoked during InitChain then there is no actual transaction
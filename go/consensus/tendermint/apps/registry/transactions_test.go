@@ -485,3 +485,188 @@ func TestRegisterNode(t *testing.T) {
 		})
 	}
 }
+
+func TestUnfreezeNode(t *testing.T) {
+	require := requirePkg.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 42,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	// Add a node that is frozen until a future epoch.
+	entitySigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: unfreeze entity signer")
+	nodeSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: unfreeze node signer")
+	nod := &node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        nodeSigner.Public(),
+		EntityID:  entitySigner.Public(),
+	}
+	sigNode, err := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, nod)
+	require.NoError(err, "MultiSignNode")
+	err = state.SetNode(ctx, nil, nod, sigNode)
+	require.NoError(err, "SetNode")
+	err = state.SetNodeStatus(ctx, nod.ID, &registry.NodeStatus{FreezeEndTime: 43})
+	require.NoError(err, "SetNodeStatus")
+
+	unfreeze := &registry.UnfreezeNode{NodeID: nod.ID}
+
+	// Only the owning entity should be able to unfreeze the node.
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(nodeSigner.Public())
+	err = app.unfreezeNode(txCtx, state, unfreeze)
+	require.ErrorIs(err, registry.ErrBadEntityForNode, "unfreeze by non-owner should fail")
+
+	// Node should not be unfrozen before the freeze period ends.
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(entitySigner.Public())
+	err = app.unfreezeNode(txCtx, state, unfreeze)
+	require.ErrorIs(err, registry.ErrNodeCannotBeUnfrozen, "unfreeze before freeze end should fail")
+	require.False(txCtx.HasEvent(app.Name(), KeyNodeUnfrozen), "no unfrozen event should be emitted")
+
+	status, err := state.NodeStatus(ctx, nod.ID)
+	require.NoError(err, "NodeStatus")
+	require.True(status.IsFrozen(), "node should still be frozen")
+	require.EqualValues(43, status.FreezeEndTime)
+
+	// Node should be unfrozen once the freeze period ends.
+	err = state.SetNodeStatus(ctx, nod.ID, &registry.NodeStatus{FreezeEndTime: 42})
+	require.NoError(err, "SetNodeStatus")
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(entitySigner.Public())
+	err = app.unfreezeNode(txCtx, state, unfreeze)
+	require.NoError(err, "unfreeze at freeze end should succeed")
+	require.True(txCtx.HasEvent(app.Name(), KeyNodeUnfrozen), "unfrozen event should be emitted")
+
+	status, err = state.NodeStatus(ctx, nod.ID)
+	require.NoError(err, "NodeStatus")
+	require.False(status.IsFrozen(), "node should be unfrozen")
+}
+
+func TestRegisterRuntime(t *testing.T) {
+	require := requirePkg.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		DebugAllowTestRuntimes: true,
+		DebugBypassStake:       true,
+		EnableRuntimeGovernanceModels: map[registry.RuntimeGovernanceModel]bool{
+			registry.GovernanceEntity:    true,
+			registry.GovernanceConsensus: true,
+		},
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: register runtime entity signer")
+	otherSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: register runtime other signer")
+
+	newRuntime := func(seed string) *registry.Runtime {
+		rt := &registry.Runtime{
+			Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+			ID:        common.NewTestNamespaceFromSeed([]byte(seed), 0),
+			EntityID:  entitySigner.Public(),
+			Kind:      registry.KindCompute,
+			Executor: registry.ExecutorParameters{
+				GroupSize:    1,
+				RoundTimeout: 5,
+			},
+			TxnScheduler: registry.TxnSchedulerParameters{
+				Algorithm:         registry.TxnSchedulerSimple,
+				BatchFlushTimeout: time.Second,
+				MaxBatchSize:      1,
+				MaxBatchSizeBytes: 1024,
+				ProposerTimeout:   5,
+			},
+			Storage: registry.StorageParameters{
+				GroupSize:               1,
+				MinWriteReplication:     1,
+				MaxApplyWriteLogEntries: 100_000,
+				MaxApplyOps:             2,
+			},
+			AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+				AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+			},
+			GovernanceModel: registry.GovernanceEntity,
+		}
+		rt.Genesis.StateRoot.Empty()
+		return rt
+	}
+
+	// A runtime registration signed by a key other than the owning entity
+	// should be rejected.
+	rt := newRuntime("consensus/tendermint/apps/registry: register runtime: other signer")
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(otherSigner.Public())
+	err = app.registerRuntime(txCtx, state, rt, false)
+	require.ErrorIs(err, registry.ErrIncorrectTxSigner, "runtime registration not signed by the owning entity should fail")
+	_, err = state.Runtime(ctx, rt.ID)
+	require.Equal(registry.ErrNoSuchRuntime, err, "runtime should not be registered")
+
+	// A runtime registration signed by the owning entity should succeed.
+	rt = newRuntime("consensus/tendermint/apps/registry: register runtime: entity signer")
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(entitySigner.Public())
+	err = app.registerRuntime(txCtx, state, rt, false)
+	require.NoError(err, "runtime registration signed by the owning entity should succeed")
+	regRt, err := state.Runtime(ctx, rt.ID)
+	require.NoError(err, "runtime should be registered")
+	require.EqualValues(rt, regRt, "registered runtime descriptor should be correct")
+
+	// Updates signed by a key other than the owning entity should be rejected,
+	// even if the update claims a different owner.
+	updatedRt := *rt
+	updatedRt.EntityID = otherSigner.Public()
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(otherSigner.Public())
+	err = app.registerRuntime(txCtx, state, &updatedRt, false)
+	require.Error(err, "runtime update not signed by the owning entity should fail")
+	regRt, err = state.Runtime(ctx, rt.ID)
+	require.NoError(err, "runtime should still be registered")
+	require.EqualValues(entitySigner.Public(), regRt.EntityID, "runtime owner should not change")
+
+	// Runtimes using consensus layer governance can only be updated by governance proposals.
+	consensusRt := newRuntime("consensus/tendermint/apps/registry: register runtime: consensus governance")
+	consensusRt.GovernanceModel = registry.GovernanceConsensus
+	err = state.SetRuntime(ctx, consensusRt, false)
+	require.NoError(err, "SetRuntime")
+
+	updatedRt = *consensusRt
+	updatedRt.Executor.GroupSize = 2
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(entitySigner.Public())
+	err = app.registerRuntime(txCtx, state, &updatedRt, false)
+	require.ErrorIs(err, registry.ErrForbidden, "runtime update not approved by governance should fail")
+	err = app.registerRuntime(txCtx, state, &updatedRt, true)
+	require.NoError(err, "runtime update approved by governance should succeed")
+	regRt, err = state.Runtime(ctx, consensusRt.ID)
+	require.NoError(err, "runtime should still be registered")
+	require.EqualValues(2, regRt.Executor.GroupSize, "runtime should be updated")
+
+	// Governance approved updates of runtimes not using consensus layer governance should fail.
+	err = app.registerRuntime(txCtx, state, rt, true)
+	require.ErrorIs(err, registry.ErrForbidden, "governance approved update of an entity governed runtime should fail")
+}
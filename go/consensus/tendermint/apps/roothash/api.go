@@ -47,6 +47,9 @@ var (
 	// KeyFinalized is an ABCI event attribute key for finalized blocks
 	// (value is a CBOR serialized ValueFinalized).
 	KeyFinalized = []byte("finalized")
+	// KeyRoundFailed is an ABCI event attribute key for round failure
+	// events (value is a CBOR serialized ValueRoundFailed).
+	KeyRoundFailed = []byte("round-failed")
 	// KeyMessage is an ABCI event attribute key for message result events
 	// (value is a CBOR serialized ValueMessage).
 	KeyMessage = []byte("message")
@@ -83,6 +86,12 @@ type ValueExecutionDiscrepancyDetected struct {
 	Event roothash.ExecutionDiscrepancyDetectedEvent `json:"event"`
 }
 
+// ValueRoundFailed is the value component of a KeyRoundFailed.
+type ValueRoundFailed struct {
+	ID    common.Namespace          `json:"id"`
+	Event roothash.RoundFailedEvent `json:"event"`
+}
+
 // ValueMessage is the value component of a KeyMessage.
 type ValueMessage struct {
 	ID    common.Namespace      `json:"id"`
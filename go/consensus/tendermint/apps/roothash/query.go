@@ -23,6 +23,11 @@ type QueryFactory struct {
 	state abciAPI.ApplicationQueryState
 }
 
+// LatestHeight returns the height of the latest committed state.
+func (sf *QueryFactory) LatestHeight() int64 {
+	return sf.state.BlockHeight()
+}
+
 // QueryAt returns the roothash query interface for a specific height.
 func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error) {
 	state, err := roothashState.NewImmutableState(ctx, sf.state, height)
@@ -190,7 +190,9 @@ func (app *rootHashApplication) onCommitteeChanged(ctx *tmapi.Context, state *ro
 			}
 		}
 
-		// If the committee has actually changed, force a new round.
+		// Force a new round on every epoch transition, even if the committee membership did not
+		// change. Any in-flight commitments are based on the previous block and can therefore not
+		// be carried over once the epoch transition block has been emitted.
 		if !rtState.Suspended {
 			ctx.Logger().Debug("updating committee for runtime",
 				"runtime_id", rt.ID,
@@ -315,6 +317,34 @@ func (app *rootHashApplication) emitEmptyBlock(ctx *tmapi.Context, runtime *root
 	return nil
 }
 
+// failRound emits an empty round failed block together with an event describing the reason why
+// the round has failed.
+func (app *rootHashApplication) failRound(
+	ctx *tmapi.Context,
+	rtState *roothash.RuntimeState,
+	reason roothash.RoundFailReason,
+	detail string,
+) error {
+	if err := app.emitEmptyBlock(ctx, rtState, block.RoundFailed); err != nil {
+		return fmt.Errorf("failed to emit empty block: %w", err)
+	}
+
+	tagV := ValueRoundFailed{
+		ID: rtState.Runtime.ID,
+		Event: roothash.RoundFailedEvent{
+			Round:  rtState.CurrentBlock.Header.Round,
+			Reason: reason,
+			Detail: detail,
+		},
+	}
+	ctx.EmitEvent(
+		tmapi.NewEventBuilder(app.Name()).
+			Attribute(KeyRoundFailed, cbor.Marshal(tagV)).
+			Attribute(KeyRuntimeID, ValueRuntimeID(rtState.Runtime.ID)),
+	)
+	return nil
+}
+
 func (app *rootHashApplication) ExecuteMessage(ctx *tmapi.Context, kind, msg interface{}) error {
 	switch kind {
 	case registryApi.MessageNewRuntimeRegistered:
@@ -552,8 +582,10 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 
 	commit, err := pool.TryFinalize(ctx.BlockHeight(), runtime.Executor.RoundTimeout, forced, true)
 	if err == commitment.ErrDiscrepancyDetected {
+		kind := pool.ClassifyDiscrepancy()
 		ctx.Logger().Warn("executor discrepancy detected",
 			"round", round,
+			"kind", kind,
 			logging.LogEvent, roothash.LogEventExecutionDiscrepancyDetected,
 		)
 
@@ -561,6 +593,7 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 			ID: runtime.ID,
 			Event: roothash.ExecutionDiscrepancyDetectedEvent{
 				Timeout: forced,
+				Kind:    kind,
 			},
 		}
 		ctx.EmitEvent(
@@ -684,11 +717,7 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 		logging.LogEvent, roothash.LogEventRoundFailed,
 	)
 
-	if err := app.emitEmptyBlock(ctx, rtState, block.RoundFailed); err != nil {
-		return fmt.Errorf("failed to emit empty block: %w", err)
-	}
-
-	return nil
+	return app.failRound(ctx, rtState, roothash.RoundFailReasonFromError(err), err.Error())
 }
 
 func (app *rootHashApplication) tryFinalizeBlock(
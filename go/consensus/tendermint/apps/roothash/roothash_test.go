@@ -0,0 +1,48 @@
+package roothash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
+	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+func TestOnNewRuntimeGenesisBlock(t *testing.T) {
+	require := require.New(t)
+
+	genesisTestHelpers.SetTestChainContext()
+
+	runtime := &registry.Runtime{
+		ID:   common.NewTestNamespaceFromSeed([]byte("roothash genesis block test"), 0),
+		Kind: registry.KindCompute,
+	}
+
+	// The genesis block should only depend on the consensus time, so that all
+	// nodes produce identical genesis blocks.
+	now := time.Unix(1580461674, 0)
+	var genesisBlocks [][]byte
+	for i := 0; i < 2; i++ {
+		appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+		ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+		defer ctx.Close()
+
+		app := rootHashApplication{appState, &abciAPI.NoopMessageDispatcher{}}
+		err := app.onNewRuntime(ctx, runtime, nil, false)
+		require.NoError(err, "onNewRuntime")
+
+		state := roothashState.NewMutableState(ctx.State())
+		rtState, err := state.RuntimeState(ctx, runtime.ID)
+		require.NoError(err, "RuntimeState")
+		require.EqualValues(now.Unix(), rtState.GenesisBlock.Header.Timestamp, "genesis block timestamp should be the consensus time")
+
+		genesisBlocks = append(genesisBlocks, cbor.Marshal(rtState.GenesisBlock))
+	}
+	require.Equal(genesisBlocks[0], genesisBlocks[1], "genesis blocks should be identical")
+}
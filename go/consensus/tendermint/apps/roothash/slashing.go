@@ -36,7 +36,7 @@ func onEvidenceRuntimeEquivocation(
 
 	// Slash runtime node entity.
 	entityAddr := staking.NewAddress(node.EntityID)
-	totalSlashed, err := stakeState.SlashEscrow(ctx, entityAddr, penaltyAmount)
+	totalSlashed, err := stakeState.SlashEscrow(ctx, entityAddr, penaltyAmount, staking.SlashRuntimeEquivocation)
 	if err != nil {
 		return fmt.Errorf("tendermint/roothash: error slashing account %s: %w", entityAddr, err)
 	}
@@ -98,7 +98,7 @@ func onRuntimeIncorrectResults(
 		entityAddr := staking.NewAddress(node.EntityID)
 
 		// Slash entity.
-		slashed, err := stakeState.SlashEscrow(ctx, entityAddr, penaltyAmount)
+		slashed, err := stakeState.SlashEscrow(ctx, entityAddr, penaltyAmount, staking.SlashRuntimeIncorrectResults)
 		if err != nil {
 			return fmt.Errorf("tendermint/roothash: error slashing account %s: %w", entityAddr, err)
 		}
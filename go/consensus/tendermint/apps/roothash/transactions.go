@@ -13,7 +13,6 @@ import (
 	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
-	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
@@ -174,8 +173,8 @@ func (app *rootHashApplication) executorProposerTimeout(
 		"err", err,
 		logging.LogEvent, roothash.LogEventRoundFailed,
 	)
-	if err = app.emitEmptyBlock(ctx, rtState, block.RoundFailed); err != nil {
-		return fmt.Errorf("failed to emit empty block: %w", err)
+	if err = app.failRound(ctx, rtState, roothash.RoundFailReasonProposerTimeout, "proposer timeout requested"); err != nil {
+		return err
 	}
 
 	// Update runtime state.
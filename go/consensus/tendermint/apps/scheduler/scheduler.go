@@ -195,6 +195,7 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.Requ
 				runtimes,
 				committeeNodes,
 				kind,
+				params,
 			); err != nil {
 				return fmt.Errorf("tendermint/scheduler: couldn't elect %s committees: %w", kind, err)
 			}
@@ -377,6 +378,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 	rt *registry.Runtime,
 	nodes []*node.Node,
 	kind scheduler.CommitteeKind,
+	params *scheduler.ConsensusParameters,
 ) error {
 	// Only generic compute runtimes need to elect all the committees.
 	if !rt.IsCompute() && kind != scheduler.KindComputeExecutor {
@@ -436,6 +438,11 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		if !isSuitableFn(ctx, n, rt) {
 			continue
 		}
+		// Check the runtime's admission policy, as it may have changed since
+		// the node has registered.
+		if params.EnforceAdmissionPolicy && rt.AdmissionPolicy.VerifyNode(n) != nil {
+			continue
+		}
 
 		// Check pre-election scheduling constraints.
 		var eligible bool
@@ -586,9 +593,10 @@ func (app *schedulerApplication) electAllCommittees(
 	runtimes []*registry.Runtime,
 	nodes []*node.Node,
 	kind scheduler.CommitteeKind,
+	params *scheduler.ConsensusParameters,
 ) error {
 	for _, runtime := range runtimes {
-		if err := app.electCommittee(ctx, epoch, beacon, stakeAcc, entitiesEligibleForReward, validatorEntities, runtime, nodes, kind); err != nil {
+		if err := app.electCommittee(ctx, epoch, beacon, stakeAcc, entitiesEligibleForReward, validatorEntities, runtime, nodes, kind, params); err != nil {
 			return err
 		}
 	}
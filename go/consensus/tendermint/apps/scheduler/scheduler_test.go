@@ -548,7 +548,7 @@ func TestElectCommittee(t *testing.T) {
 			true,
 		},
 	} {
-		err := app.electCommittee(ctx, 1, mockBeacon, nil, nil, tc.validatorEntities, &tc.rt, tc.nodes, tc.kind)
+		err := app.electCommittee(ctx, 1, mockBeacon, nil, nil, tc.validatorEntities, &tc.rt, tc.nodes, tc.kind, &scheduler.ConsensusParameters{})
 		require.NoError(err, "committee election should not fail")
 
 		c, err := schedState.Committee(ctx, tc.kind, tc.rt.ID)
@@ -560,4 +560,64 @@ func TestElectCommittee(t *testing.T) {
 
 		require.NotNil(c, "Committee should have been elected (%s)", tc.msg)
 	}
+
+	// Only nodes of whitelisted entities should be elected.
+	whitelistRt := registry.Runtime{
+		ID:   rtID1,
+		Kind: registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize:       1,
+			GroupBackupSize: 1,
+		},
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			EntityWhitelist: &registry.EntityWhitelistRuntimeAdmissionPolicy{
+				Entities: map[signature.PublicKey]registry.EntityWhitelistConfig{
+					entityID1: {},
+				},
+			},
+		},
+	}
+	whitelistNodes := []*node.Node{
+		{
+			ID:       nodeID1,
+			EntityID: entityID1,
+			Runtimes: []*node.Runtime{{ID: rtID1}},
+			Roles:    node.RoleComputeWorker,
+		},
+		{
+			ID:       nodeID3,
+			EntityID: entityID2,
+			Runtimes: []*node.Runtime{{ID: rtID1}},
+			Roles:    node.RoleComputeWorker,
+		},
+	}
+	require.Equal(registry.ErrNodeNotAdmitted, whitelistRt.AdmissionPolicy.VerifyNode(whitelistNodes[1]), "non-whitelisted node should not be admitted")
+
+	enforceParams := &scheduler.ConsensusParameters{EnforceAdmissionPolicy: true}
+	err := app.electCommittee(ctx, 1, mockBeacon, nil, nil, nil, &whitelistRt, whitelistNodes, scheduler.KindComputeExecutor, enforceParams)
+	require.NoError(err, "committee election should not fail")
+	c, err := schedState.Committee(ctx, scheduler.KindComputeExecutor, whitelistRt.ID)
+	require.NoError(err, "Committee")
+	require.NotNil(c, "Committee should have been elected")
+	require.NotEmpty(c.Members, "Committee should have members")
+	for _, m := range c.Members {
+		require.EqualValues(nodeID1, m.PublicKey, "only whitelisted nodes should be elected")
+	}
+
+	// Not enough nodes of whitelisted entities.
+	whitelistRt.Executor.GroupSize = 2
+	whitelistRt.Executor.GroupBackupSize = 0
+	err = app.electCommittee(ctx, 1, mockBeacon, nil, nil, nil, &whitelistRt, whitelistNodes, scheduler.KindComputeExecutor, enforceParams)
+	require.NoError(err, "committee election should not fail")
+	c, err = schedState.Committee(ctx, scheduler.KindComputeExecutor, whitelistRt.ID)
+	require.NoError(err, "Committee")
+	require.Nil(c, "Committee should not have been elected without enough whitelisted nodes")
+
+	// The admission policy should not be enforced unless enabled.
+	err = app.electCommittee(ctx, 1, mockBeacon, nil, nil, nil, &whitelistRt, whitelistNodes, scheduler.KindComputeExecutor, &scheduler.ConsensusParameters{})
+	require.NoError(err, "committee election should not fail")
+	c, err = schedState.Committee(ctx, scheduler.KindComputeExecutor, whitelistRt.ID)
+	require.NoError(err, "Committee")
+	require.NotNil(c, "Committee should have been elected when the admission policy is not enforced")
+	require.Len(c.Members, 2, "Committee should include non-whitelisted nodes when the admission policy is not enforced")
 }
@@ -138,42 +138,12 @@ func (app *stakingApplication) disburseFeesVQ(
 		return fmt.Errorf("ConsensusParameters: %w", err)
 	}
 
-	// Compute the portion associated with each eligible validator's share of the fees, and within that, how much goes
-	// to the voter and how much goes to the next proposer.
-	perValidator := lastBlockFees.Clone()
-	var nEVQ quantity.Quantity
-	if err = nEVQ.FromInt64(int64(numEligibleValidators)); err != nil {
-		return fmt.Errorf("import numEligibleValidators %d: %w", numEligibleValidators, err)
-	}
-	if err = perValidator.Quo(&nEVQ); err != nil {
-		return fmt.Errorf("divide perValidator: %w", err)
-	}
-	denom := consensusParameters.FeeSplitWeightVote.Clone()
-	if err = denom.Add(&consensusParameters.FeeSplitWeightNextPropose); err != nil {
-		return fmt.Errorf("add FeeSplitWeightNextPropose: %w", err)
-	}
-	shareNextProposer := perValidator.Clone()
-	if err = shareNextProposer.Mul(&consensusParameters.FeeSplitWeightNextPropose); err != nil {
-		return fmt.Errorf("multiply shareNextProposer: %w", err)
-	}
-	if err = shareNextProposer.Quo(denom); err != nil {
-		return fmt.Errorf("divide shareNextProposer: %w", err)
-	}
-	shareVote := perValidator.Clone()
-	if err = shareVote.Sub(shareNextProposer); err != nil {
-		return fmt.Errorf("subtract shareVote: %w", err)
-	}
-
-	// Multiply to get the next proposer's total payment.
-	numVotingEntities := len(votingEntities)
-	var nVEQ quantity.Quantity
-	if err = nVEQ.FromInt64(int64(numVotingEntities)); err != nil {
-		return fmt.Errorf("import numVotingEntities %d: %w", numVotingEntities, err)
-	}
-	nextProposerTotal := shareNextProposer.Clone()
-	if err = nextProposerTotal.Mul(&nVEQ); err != nil {
-		return fmt.Errorf("multiply nextProposerTotal: %w", err)
+	disbursement, err := consensusParameters.ComputeVoteFeeDisbursement(lastBlockFees, numEligibleValidators, len(votingEntities))
+	if err != nil {
+		return fmt.Errorf("ComputeVoteFeeDisbursement: %w", err)
 	}
+	shareVote := &disbursement.PerVoter
+	nextProposerTotal := &disbursement.NextProposer
 
 	// Pay the next proposer.
 	if !nextProposerTotal.IsZero() && proposerEntity != nil {
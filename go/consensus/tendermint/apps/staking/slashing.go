@@ -88,7 +88,7 @@ func onEvidenceByzantineConsensus(
 
 	// Slash validator.
 	entityAddr := staking.NewAddress(node.EntityID)
-	_, err = stakeState.SlashEscrow(ctx, entityAddr, &penalty.Amount)
+	_, err = stakeState.SlashEscrow(ctx, entityAddr, &penalty.Amount, reason)
 	if err != nil {
 		ctx.Logger().Error("failed to slash validator entity",
 			"err", err,
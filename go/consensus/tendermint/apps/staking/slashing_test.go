@@ -133,6 +133,27 @@ func TestOnEvidenceByzantineConsensus(t *testing.T) {
 	_ = balance.Sub(&slashAmount)
 	require.EqualValues(balance, acct.Escrow.Active.Balance, "entity stake should be slashed")
 
+	// Slash event should be emitted.
+	var slashEvents []*staking.SlashEvent
+	for _, ev := range ctx.GetEvents() {
+		if ev.GetType() != EventType {
+			continue
+		}
+		for _, pair := range ev.GetAttributes() {
+			if !abciAPI.IsAttributeKind(pair.GetKey(), &staking.SlashEvent{}) {
+				continue
+			}
+			var e staking.SlashEvent
+			err = cbor.Unmarshal(pair.GetValue(), &e)
+			require.NoError(err, "cbor.Unmarshal(SlashEvent)")
+			slashEvents = append(slashEvents, &e)
+		}
+	}
+	require.Len(slashEvents, 1, "exactly one slash event should be emitted")
+	require.Equal(addr, slashEvents[0].Owner, "slash event owner")
+	require.Equal(staking.SlashConsensusEquivocation, slashEvents[0].Reason, "slash event reason")
+	require.Equal(slashAmount, slashEvents[0].Amount, "slash event amount")
+
 	// Node should be frozen.
 	status, err = regState.NodeStatus(ctx, nod.ID)
 	require.NoError(err, "NodeStatus")
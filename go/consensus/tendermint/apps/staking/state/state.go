@@ -716,7 +716,7 @@ func slashPool(dst *quantity.Quantity, p *staking.SharePool, amount, total *quan
 
 // SlashEscrow slashes the escrow balance and the escrow-but-undergoing-debonding
 // balance of the account, transferring it to the global common pool, returning
-// the amount actually slashed.
+// the amount actually slashed. The reason is reported in the emitted slash event.
 //
 // WARNING: This is an internal routine to be used to implement staking policy,
 // and MUST NOT be exposed outside of backend implementations.
@@ -724,6 +724,7 @@ func (s *MutableState) SlashEscrow(
 	ctx *abciAPI.Context,
 	fromAddr staking.Address,
 	amount *quantity.Quantity,
+	reason staking.SlashReason,
 ) (*quantity.Quantity, error) {
 	var slashed quantity.Quantity
 
@@ -772,6 +773,11 @@ func (s *MutableState) SlashEscrow(
 			Owner:  fromAddr,
 			Amount: *totalSlashed,
 		}))
+		ctx.EmitEvent(api.NewEventBuilder(AppName).TypedAttribute(&staking.SlashEvent{
+			Owner:  fromAddr,
+			Reason: reason,
+			Amount: *totalSlashed,
+		}))
 	}
 
 	return totalSlashed, nil
@@ -1049,13 +1055,7 @@ func (s *MutableState) AddRewards(
 	if err != nil {
 		return err
 	}
-	var activeStep *staking.RewardStep
-	for i, step := range steps {
-		if time < step.Until {
-			activeStep = &steps[i]
-			break
-		}
-	}
+	activeStep := staking.RewardStepAt(steps, time)
 	if activeStep == nil {
 		// We're past the end of the schedule.
 		return nil
@@ -1177,13 +1177,7 @@ func (s *MutableState) AddRewardSingleAttenuated(
 	if err != nil {
 		return fmt.Errorf("failed to query reward schedule: %w", err)
 	}
-	var activeStep *staking.RewardStep
-	for i, step := range steps {
-		if time < step.Until {
-			activeStep = &steps[i]
-			break
-		}
-	}
+	activeStep := staking.RewardStepAt(steps, time)
 	if activeStep == nil {
 		// We're past the end of the schedule.
 		return nil
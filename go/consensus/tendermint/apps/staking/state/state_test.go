@@ -124,6 +124,70 @@ func TestDelegationQueries(t *testing.T) {
 	require.EqualValues(expectedDebDelegations, debDelegations, "DebondingDelegations should match expected")
 }
 
+func TestDelegationsForMultipleEscrows(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+	s := NewMutableState(ctx.State())
+
+	fac := memorySigner.NewFactory()
+	delegatorSigner, err := fac.Generate(signature.SignerEntity, rand.Reader)
+	require.NoError(err, "generating delegator signer")
+	delegatorAddr := staking.NewAddress(delegatorSigner.Public())
+
+	var delegatorAccount staking.Account
+	err = delegatorAccount.General.Balance.FromBigInt(big.NewInt(1000))
+	require.NoError(err, "initialize delegator account general balance")
+
+	// Escrow into two accounts, the second of which already has some stake and has been rewarded
+	// so that its shares are worth more than one base unit each.
+	escrowAmounts := []int64{100, 300}
+	var escrowAddrs []staking.Address
+	for i, amount := range escrowAmounts {
+		escrowSigner, serr := fac.Generate(signature.SignerEntity, rand.Reader)
+		require.NoError(serr, "generating escrow signer")
+		escrowAddr := staking.NewAddress(escrowSigner.Public())
+		escrowAddrs = append(escrowAddrs, escrowAddr)
+
+		var escrowAccount staking.Account
+		if i > 0 {
+			escrowAccount.Escrow.Active.Balance = mustInitQuantity(t, 200)
+			escrowAccount.Escrow.Active.TotalShares = mustInitQuantity(t, 100)
+		}
+
+		var del staking.Delegation
+		_, err = escrowAccount.Escrow.Active.Deposit(&del.Shares, &delegatorAccount.General.Balance, mustInitQuantityP(t, amount))
+		require.NoError(err, "active escrow deposit")
+
+		err = s.SetAccount(ctx, escrowAddr, &escrowAccount)
+		require.NoError(err, "SetAccount")
+		err = s.SetDelegation(ctx, delegatorAddr, escrowAddr, &del)
+		require.NoError(err, "SetDelegation")
+	}
+	err = s.SetAccount(ctx, delegatorAddr, &delegatorAccount)
+	require.NoError(err, "SetAccount")
+
+	delegations, err := s.DelegationsFor(ctx, delegatorAddr)
+	require.NoError(err, "DelegationsFor")
+	require.Len(delegations, len(escrowAddrs), "delegations to all escrow accounts should be returned")
+
+	expectedShares := []int64{100, 150}
+	for i, escrowAddr := range escrowAddrs {
+		require.Contains(delegations, escrowAddr)
+		del := delegations[escrowAddr]
+		require.Equal(mustInitQuantity(t, expectedShares[i]), del.Shares, "delegation shares")
+
+		escrowAccount, aerr := s.Account(ctx, escrowAddr)
+		require.NoError(aerr, "Account")
+		stake, serr := escrowAccount.Escrow.Active.StakeForShares(&del.Shares)
+		require.NoError(serr, "StakeForShares")
+		require.Equal(mustInitQuantityP(t, escrowAmounts[i]), stake, "delegation stake value")
+	}
+}
+
 func TestDebondingDelegation(t *testing.T) {
 	require := require.New(t)
 
@@ -341,7 +405,7 @@ func TestRewardAndSlash(t *testing.T) {
 	require.NoError(err, "Account")
 	require.Equal(mustInitQuantity(t, 300), escrowAccount.Escrow.Active.Balance, "reward late epoch - escrow active escrow")
 
-	slashed, err := s.SlashEscrow(ctx, escrowAddr, mustInitQuantityP(t, 40))
+	slashed, err := s.SlashEscrow(ctx, escrowAddr, mustInitQuantityP(t, 40), staking.SlashConsensusEquivocation)
 	require.NoError(err, "slash escrow")
 	require.False(slashed.IsZero(), "slashed nonzero")
 
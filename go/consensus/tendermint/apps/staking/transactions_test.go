@@ -582,6 +582,18 @@ func TestAddEscrow(t *testing.T) {
 			},
 			staking.ErrUnderMinDelegationAmount,
 		},
+		{
+			"should succeed when exactly min delegation amount",
+			&staking.ConsensusParameters{
+				MinDelegationAmount: *quantity.NewFromUint64(1000),
+			},
+			pk2,
+			&staking.Escrow{
+				Account: addr1,
+				Amount:  *quantity.NewFromUint64(1000),
+			},
+			nil,
+		},
 		{
 			"should succeed when over min delegation amount",
 			&staking.ConsensusParameters{
@@ -29,10 +29,14 @@ var testSigner = memorySigner.NewTestSigner("oasis-core epochtime mock key seed"
 
 // ServiceClient is the beacon service client interface.
 type ServiceClient interface {
-	beaconAPI.Backend
+	beaconAPI.HookableBackend
 	tmAPI.ServiceClient
 }
 
+type epochChangeHook struct {
+	fn beaconAPI.EpochChangeHook
+}
+
 type serviceClient struct {
 	sync.RWMutex
 	tmAPI.BaseServiceClient
@@ -44,6 +48,8 @@ type serviceClient struct {
 	ctx     context.Context
 
 	epochNotifier     *pubsub.Broker
+	epochHooksLock    sync.Mutex
+	epochHooks        []*epochChangeHook
 	epochLastNotified beaconAPI.EpochTime
 	epoch             beaconAPI.EpochTime
 	epochCurrentBlock int64
@@ -184,6 +190,31 @@ func (sc *serviceClient) WatchLatestEpoch(ctx context.Context) (<-chan beaconAPI
 	return typedCh, sub, nil
 }
 
+func (sc *serviceClient) RegisterOnEpochChange(hook beaconAPI.EpochChangeHook) func() {
+	h := &epochChangeHook{fn: hook}
+
+	sc.epochHooksLock.Lock()
+	defer sc.epochHooksLock.Unlock()
+	sc.epochHooks = append(sc.epochHooks, h)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			sc.epochHooksLock.Lock()
+			defer sc.epochHooksLock.Unlock()
+
+			for i, v := range sc.epochHooks {
+				if v != h {
+					continue
+				}
+				// Make sure to copy the hooks as notifyEpoch may be iterating over them.
+				sc.epochHooks = append(sc.epochHooks[:i:i], sc.epochHooks[i+1:]...)
+				break
+			}
+		})
+	}
+}
+
 func (sc *serviceClient) GetBeacon(ctx context.Context, height int64) ([]byte, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -193,6 +224,16 @@ func (sc *serviceClient) GetBeacon(ctx context.Context, height int64) ([]byte, e
 	return q.Beacon(ctx)
 }
 
+func (sc *serviceClient) GetEpochBeacon(ctx context.Context, epoch beaconAPI.EpochTime) ([]byte, error) {
+	// The beacon for an epoch is generated in the block that transitions to the epoch.
+	height, err := sc.GetEpochBlock(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return sc.GetBeacon(ctx, height)
+}
+
 func (sc *serviceClient) GetPVSSState(ctx context.Context, height int64) (*beaconAPI.PVSSState, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -260,7 +301,7 @@ func (sc *serviceClient) DeliverBlock(ctx context.Context, height int64) error {
 	}
 
 	if sc.updateCachedEpoch(height, epoch) {
-		sc.epochNotifier.Broadcast(epoch)
+		sc.notifyEpoch(epoch)
 	}
 
 	var pvssState *beaconAPI.PVSSState
@@ -293,7 +334,7 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx tmty
 			}
 
 			if sc.updateCachedEpoch(height, epoch) {
-				sc.epochNotifier.Broadcast(epoch)
+				sc.notifyEpoch(epoch)
 			}
 		}
 		if tmAPI.IsAttributeKind(pair.GetKey(), &beaconAPI.PVSSEvent{}) {
@@ -331,6 +372,24 @@ func (sc *serviceClient) updateCachedEpoch(height int64, epoch beaconAPI.EpochTi
 	return false
 }
 
+func (sc *serviceClient) notifyEpoch(epoch beaconAPI.EpochTime) {
+	sc.epochHooksLock.Lock()
+	hooks := sc.epochHooks
+	sc.epochHooksLock.Unlock()
+
+	// Invoke hooks before notifying any watchers.
+	for _, h := range hooks {
+		if err := h.fn(epoch); err != nil {
+			sc.logger.Error("epoch change hook failed",
+				"err", err,
+				"epoch", epoch,
+			)
+		}
+	}
+
+	sc.epochNotifier.Broadcast(epoch)
+}
+
 func (sc *serviceClient) updateCachedPVSSEvent(event *beaconAPI.PVSSEvent) bool {
 	sc.Lock()
 	defer sc.Unlock()
@@ -3,6 +3,7 @@ package badger
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,6 +23,9 @@ const (
 	// BackendName is the name of this implementation.
 	BackendName = "badger"
 
+	// DefaultMaxCacheSize is the default maximum block cache size in bytes.
+	DefaultMaxCacheSize = 64 * 1024 * 1024
+
 	dbVersion = 1
 	dbSuffix  = ".badger.db"
 )
@@ -30,16 +34,37 @@ var (
 	baseLogger = logging.GetLogger("tendermint/db/badger")
 
 	// DBProvider is a DBProvider to be used when initializing
-	// a tendermint node.
-	DBProvider node.DBProvider = badgerDBProvider
+	// a tendermint node, using the default configuration.
+	DBProvider = NewDBProvider(nil)
+
+	// ErrCorrupted is the error returned when database verification on open
+	// detects corruption.
+	ErrCorrupted = errors.New("tendermint/db/badger: database corrupted")
 
 	dbVersionStart = []byte{dbVersion}
 	dbVersionEnd   = []byte{dbVersion + 1}
 )
 
-func badgerDBProvider(ctx *node.DBContext) (dbm.DB, error) {
-	// BadgerDB can handle dealing with the directory for us.
-	return New(filepath.Join(ctx.Config.DBDir(), ctx.ID), false)
+// Config is the tendermint Badger DB configuration.
+type Config struct {
+	// MaxCacheSize is the maximum size of the block cache in bytes, where
+	// 0 means DefaultMaxCacheSize. A larger cache results in fewer table
+	// block reads and decompressions at the cost of memory usage.
+	MaxCacheSize int64
+
+	// VerifyOnOpen enables verifying the integrity of the database when it
+	// is opened. This requires reading the entire database, so it can
+	// significantly slow down opening large databases.
+	VerifyOnOpen bool
+}
+
+// NewDBProvider returns a DBProvider to be used when initializing a
+// tendermint node, with the given configuration (nil means the default).
+func NewDBProvider(cfg *Config) node.DBProvider {
+	return func(ctx *node.DBContext) (dbm.DB, error) {
+		// BadgerDB can handle dealing with the directory for us.
+		return New(filepath.Join(ctx.Config.DBDir(), ctx.ID), false, cfg)
+	}
 }
 
 type badgerDBImpl struct {
@@ -54,12 +79,24 @@ type badgerDBImpl struct {
 // New constructs a new tendermint DB, backed by a Badger database at
 // the provided path.
 //
+// If cfg is nil, the default configuration is used.
+//
 // Note: This should only be used by tendermint, all other places
 // that need a K/V store should favor using BadgerDB directly.
-func New(fn string, noSuffix bool) (dbm.DB, error) {
+func New(fn string, noSuffix bool, cfg *Config) (dbm.DB, error) {
 	if !noSuffix && !strings.HasSuffix(fn, dbSuffix) {
 		fn = fn + dbSuffix
 	}
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	maxCacheSize := cfg.MaxCacheSize
+	if maxCacheSize < 0 {
+		return nil, fmt.Errorf("tendermint/db/badger: invalid cache size: %d", maxCacheSize)
+	}
+	if maxCacheSize == 0 {
+		maxCacheSize = DefaultMaxCacheSize
+	}
 
 	logger := baseLogger.With("path", fn)
 
@@ -67,13 +104,24 @@ func New(fn string, noSuffix bool) (dbm.DB, error) {
 	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
 	opts = opts.WithSyncWrites(false)
 	opts = opts.WithCompression(options.Snappy)
-	opts = opts.WithBlockCacheSize(64 * 1024 * 1024)
+	opts = opts.WithBlockCacheSize(maxCacheSize)
 
 	db, err := cmnBadger.Open(opts)
 	if err != nil {
 		return nil, fmt.Errorf("tendermint/db/badger: failed to open database: %w", err)
 	}
 
+	if cfg.VerifyOnOpen {
+		logger.Info("verifying database integrity")
+		if err = verify(db); err != nil {
+			logger.Error("database integrity verification failed",
+				"err", err,
+			)
+			db.Close()
+			return nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+		}
+	}
+
 	impl := &badgerDBImpl{
 		logger: logger,
 		db:     db,
@@ -83,6 +131,34 @@ func New(fn string, noSuffix bool) (dbm.DB, error) {
 	return impl, nil
 }
 
+// verify verifies the integrity of the database by checking the checksums of
+// all tables and reading all values.
+func verify(db *badger.DB) error {
+	if err := db.VerifyChecksum(); err != nil {
+		return fmt.Errorf("table checksum verification failed: %w", err)
+	}
+
+	return db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.AllVersions = true
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			if item.IsDeletedOrExpired() {
+				continue
+			}
+			if err := item.Value(func([]byte) error { return nil }); err != nil {
+				return fmt.Errorf("failed to read value for key %X: %w", item.Key(), err)
+			}
+		}
+		return nil
+	})
+}
+
 func (d *badgerDBImpl) Get(key []byte) ([]byte, error) {
 	k := toDBKey(key)
 
@@ -261,16 +337,10 @@ func (d *badgerDBImpl) newIterator(start, end []byte, isForward bool) dbm.Iterat
 	// CPU being spent in `nodeDB.getPreviousVersion` under profiling.
 	opts.PrefetchValues = false
 
-	// TODO/perf:
-	//  * opts.Prefix is likely worth setting, but maybe the tendermint
-	//    semantics can't be implemented.
-	opts.Prefix = dbVersionStart
-
 	tx := d.db.NewTransaction(false)
 	it := &badgerDBIterator{
 		db:        d,
 		tx:        tx,
-		iter:      tx.NewIterator(opts),
 		start:     start,
 		end:       end,
 		isForward: isForward,
@@ -287,6 +357,14 @@ func (d *badgerDBImpl) newIterator(start, end []byte, isForward bool) dbm.Iterat
 		it.dbEnd = toDBKey(end)
 	}
 
+	// All keys in the iterator's domain share the longest common prefix of
+	// the bounds, setting it allows Badger to skip irrelevant tables.
+	opts.Prefix = dbVersionStart
+	if start != nil && end != nil {
+		opts.Prefix = commonPrefix(it.dbStart, it.dbEnd)
+	}
+	it.iter = tx.NewIterator(opts)
+
 	// Seek to the first applicable key/value pair.
 	switch isForward {
 	case true:
@@ -510,6 +588,20 @@ func toDBKey(key []byte) []byte {
 	return ret
 }
 
+// commonPrefix returns the longest common prefix of a and b.
+func commonPrefix(a, b []byte) []byte {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	var i int
+	for i < n && a[i] == b[i] {
+		i++
+	}
+	return a[:i]
+}
+
 func fromDBKeyNoCopy(key []byte) []byte {
 	if len(key) < 1 {
 		panic("BUG: zero-length key in Badger database")
@@ -1,12 +1,15 @@
 package badger
 
 import (
+	"bytes"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/db/tests"
 )
@@ -18,9 +21,214 @@ func TestBadgerTendermintDB(t *testing.T) {
 	defer os.RemoveAll(tmpDir)
 
 	// Create the database.
-	db, err := New(filepath.Join(tmpDir, "test"), false)
+	db, err := New(filepath.Join(tmpDir, "test"), false, nil)
 	require.NoError(t, err, "New")
 	defer db.Close()
 
 	tests.TestTendermintDB(t, db)
 }
+
+func TestBadgerTendermintDBCacheSize(t *testing.T) {
+	require := require.New(t)
+
+	// Create a temporary directory to store the test database.
+	tmpDir, err := ioutil.TempDir("", "oasis-go-tendermint-db-test")
+	require.NoError(err, "Failed to create temporary directory.")
+	defer os.RemoveAll(tmpDir)
+
+	_, err = New(filepath.Join(tmpDir, "invalid"), false, &Config{MaxCacheSize: -1})
+	require.Error(err, "New should fail with a negative cache size")
+
+	// Create the database with a custom cache size.
+	db, err := New(filepath.Join(tmpDir, "test"), false, &Config{MaxCacheSize: 8 * 1024 * 1024})
+	require.NoError(err, "New")
+	defer db.Close()
+
+	err = db.SetSync([]byte("key"), []byte("value"))
+	require.NoError(err, "SetSync")
+	value, err := db.Get([]byte("key"))
+	require.NoError(err, "Get")
+	require.Equal([]byte("value"), value, "Get should return the stored value")
+}
+
+func TestBadgerTendermintDBVerifyOnOpen(t *testing.T) {
+	require := require.New(t)
+
+	// Create a temporary directory to store the test database.
+	tmpDir, err := ioutil.TempDir("", "oasis-go-tendermint-db-test")
+	require.NoError(err, "Failed to create temporary directory.")
+	defer os.RemoveAll(tmpDir)
+
+	fn := filepath.Join(tmpDir, "test")
+	cfg := &Config{VerifyOnOpen: true}
+
+	// Populate the database and close it to flush everything to tables.
+	db, err := New(fn, false, cfg)
+	require.NoError(err, "New")
+	batch := db.NewBatch()
+	for i := 0; i < 1000; i++ {
+		key := []byte(fmt.Sprintf("key%06d", i))
+		err = batch.Set(key, bytes.Repeat(key, 10))
+		require.NoError(err, "batch.Set")
+	}
+	require.NoError(batch.WriteSync(), "batch.WriteSync")
+	require.NoError(batch.Close(), "batch.Close")
+	require.NoError(db.Close(), "Close")
+
+	// An intact database should pass verification.
+	db, err = New(fn, false, cfg)
+	require.NoError(err, "New should succeed for an intact database")
+	require.NoError(db.Close(), "Close")
+
+	// Corrupt the data blocks of all tables.
+	tables, err := filepath.Glob(filepath.Join(fn+dbSuffix, "*.sst"))
+	require.NoError(err, "Glob")
+	require.NotEmpty(tables, "database should contain tables")
+	for _, table := range tables {
+		var f *os.File
+		f, err = os.OpenFile(table, os.O_RDWR, 0)
+		require.NoError(err, "OpenFile")
+		_, err = f.WriteAt(bytes.Repeat([]byte{0xa5}, 64), 16)
+		require.NoError(err, "WriteAt")
+		require.NoError(f.Close(), "Close")
+	}
+
+	_, err = New(fn, false, cfg)
+	require.ErrorIs(err, ErrCorrupted, "New should detect the corruption")
+}
+
+func populatePrefixTestDB(t testing.TB, numPrefixes, numKeysPerPrefix int) (dbm.DB, [][]byte, func()) {
+	tmpDir, err := ioutil.TempDir("", "oasis-go-tendermint-db-test")
+	require.NoError(t, err, "Failed to create temporary directory.")
+
+	db, err := New(filepath.Join(tmpDir, "test"), false, nil)
+	require.NoError(t, err, "New")
+
+	// Keys are generated in sorted order.
+	var keys [][]byte
+	batch := db.NewBatch()
+	for p := 0; p < numPrefixes; p++ {
+		for i := 0; i < numKeysPerPrefix; i++ {
+			key := []byte(fmt.Sprintf("prefix%03d/key%06d", p, i))
+			err = batch.Set(key, key)
+			require.NoError(t, err, "batch.Set")
+			keys = append(keys, key)
+		}
+	}
+	require.NoError(t, batch.WriteSync(), "batch.WriteSync")
+	require.NoError(t, batch.Close(), "batch.Close")
+
+	return db, keys, func() {
+		db.Close()
+		os.RemoveAll(tmpDir)
+	}
+}
+
+func collectIterator(t *testing.T, it dbm.Iterator) [][]byte {
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		require.Equal(t, it.Key(), it.Value(), "value should match key")
+		keys = append(keys, it.Key())
+	}
+	require.NoError(t, it.Close(), "Close")
+	return keys
+}
+
+func TestCommonPrefix(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		a, b     string
+		expected string
+	}{
+		{"", "", ""},
+		{"abc", "", ""},
+		{"abc", "abd", "ab"},
+		{"abc", "abcdef", "abc"},
+		{"abc", "xyz", ""},
+		{"abc", "abc", "abc"},
+	} {
+		require.Equal(tc.expected, string(commonPrefix([]byte(tc.a), []byte(tc.b))), "commonPrefix(%s, %s)", tc.a, tc.b)
+	}
+}
+
+func TestBadgerPrefixIterator(t *testing.T) {
+	require := require.New(t)
+
+	db, allKeys, cleanup := populatePrefixTestDB(t, 10, 50)
+	defer cleanup()
+
+	for _, tc := range []struct {
+		name       string
+		start, end []byte
+	}{
+		{"Unbounded", nil, nil},
+		{"NoStart", nil, []byte("prefix003/key000010")},
+		{"NoEnd", []byte("prefix007/key000010"), nil},
+		{"SamePrefix", []byte("prefix005/"), []byte("prefix005/\xff")},
+		{"SamePrefixPartial", []byte("prefix005/key000010"), []byte("prefix005/key000020")},
+		{"SamePrefixExactKeys", []byte("prefix005/key000010"), []byte("prefix005/key000011")},
+		{"AcrossPrefixes", []byte("prefix002/key000040"), []byte("prefix004/key000010")},
+		{"StartIsPrefixOfEnd", []byte("prefix005"), []byte("prefix005/key000005")},
+		{"NoCommonPrefix", []byte("a"), []byte("z")},
+		{"Empty", []byte("prefix005/key000020"), []byte("prefix005/key000010")},
+		{"Missing", []byte("prefix100/"), []byte("prefix100/\xff")},
+	} {
+		var expected [][]byte
+		for _, key := range allKeys {
+			if tc.start != nil && bytes.Compare(key, tc.start) < 0 {
+				continue
+			}
+			if tc.end != nil && bytes.Compare(key, tc.end) >= 0 {
+				continue
+			}
+			expected = append(expected, key)
+		}
+
+		it, err := db.Iterator(tc.start, tc.end)
+		require.NoError(err, "Iterator (%s)", tc.name)
+		require.Equal(expected, collectIterator(t, it), "forward iterator results should match (%s)", tc.name)
+
+		for i, j := 0, len(expected)-1; i < j; i, j = i+1, j-1 {
+			expected[i], expected[j] = expected[j], expected[i]
+		}
+		it, err = db.ReverseIterator(tc.start, tc.end)
+		require.NoError(err, "ReverseIterator (%s)", tc.name)
+		require.Equal(expected, collectIterator(t, it), "reverse iterator results should match (%s)", tc.name)
+	}
+}
+
+func BenchmarkBadgerPrefixIterator(b *testing.B) {
+	db, _, cleanup := populatePrefixTestDB(b, 100, 1_000)
+	defer cleanup()
+
+	// Both ranges contain 100 keys, but only the bounds of the second one
+	// share a prefix that excludes keys of the other prefixes.
+	for _, bc := range []struct {
+		name       string
+		start, end []byte
+	}{
+		{"ShortPrefix", []byte("prefix049/key000950"), []byte("prefix050/key000050")},
+		{"LongPrefix", []byte("prefix050/key000100"), []byte("prefix050/key000200")},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				it, err := db.Iterator(bc.start, bc.end)
+				if err != nil {
+					b.Fatalf("failed to create iterator: %s", err)
+				}
+				var n int
+				for ; it.Valid(); it.Next() {
+					_ = it.Key()
+					n++
+				}
+				it.Close()
+
+				if n != 100 {
+					b.Fatalf("unexpected number of keys: %d", n)
+				}
+			}
+		})
+	}
+}
@@ -14,7 +14,16 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/db/badger"
 )
 
-const cfgBackend = "tendermint.db.backend"
+const (
+	cfgBackend = "tendermint.db.backend"
+
+	// CfgBadgerCacheSize configures the maximum size of the Badger block
+	// cache in bytes (0 means the default).
+	CfgBadgerCacheSize = "tendermint.db.badger.cache_size"
+	// CfgBadgerVerifyOnOpen enables verifying the integrity of Badger
+	// databases when they are opened.
+	CfgBadgerVerifyOnOpen = "tendermint.db.badger.verify_on_open"
+)
 
 // Flags has the configuration flags.
 var Flags = flag.NewFlagSet("", flag.ContinueOnError)
@@ -30,7 +39,7 @@ func GetProvider() (node.DBProvider, error) {
 
 	switch strings.ToLower(backend) {
 	case badger.BackendName:
-		return badger.DBProvider, nil
+		return badger.NewDBProvider(badgerConfig()), nil
 	default:
 		return nil, fmt.Errorf("tendermint/db: unsupported backend: '%v'", backend)
 	}
@@ -42,14 +51,23 @@ func New(fn string, noSuffix bool) (dbm.DB, error) {
 
 	switch strings.ToLower(backend) {
 	case badger.BackendName:
-		return badger.New(fn, noSuffix)
+		return badger.New(fn, noSuffix, badgerConfig())
 	default:
 		return nil, fmt.Errorf("tendermint/db: unsupported backend: '%v'", backend)
 	}
 }
 
+func badgerConfig() *badger.Config {
+	return &badger.Config{
+		MaxCacheSize: viper.GetInt64(CfgBadgerCacheSize),
+		VerifyOnOpen: viper.GetBool(CfgBadgerVerifyOnOpen),
+	}
+}
+
 func init() {
 	Flags.String(cfgBackend, badger.BackendName, "tendermint db backend")
+	Flags.Int64(CfgBadgerCacheSize, badger.DefaultMaxCacheSize, "tendermint badger db block cache size in bytes (larger trades memory for fewer table reads)")
+	Flags.Bool(CfgBadgerVerifyOnOpen, false, "verify tendermint badger db integrity on open (slow for large databases)")
 
 	_ = viper.BindPFlags(Flags)
 }
@@ -82,6 +82,9 @@ const (
 	// CfgABCIPruneNumKept configures the amount of kept heights if pruning is enabled.
 	CfgABCIPruneNumKept = "consensus.tendermint.abci.prune.num_kept"
 
+	// CfgABCICheckTxCacheSize configures the maximum number of cached failed CheckTx results.
+	CfgABCICheckTxCacheSize = "consensus.tendermint.abci.check_tx_cache_size"
+
 	// CfgCheckpointerDisabled disables the ABCI state checkpointer.
 	CfgCheckpointerDisabled = "consensus.tendermint.checkpointer.disabled"
 	// CfgCheckpointerCheckInterval configures the ABCI state checkpointing check interval.
@@ -102,9 +105,14 @@ const (
 	// CfgDebugUnsafeReplayRecoverCorruptedWAL enables the debug and unsafe
 	// automatic corrupted WAL recovery during replay.
 	CfgDebugUnsafeReplayRecoverCorruptedWAL = "consensus.tendermint.debug.unsafe_replay_recover_corrupted_wal"
+	// CfgDebugUnsafePinnedStateVersion pins the ABCI application state to the given historical
+	// version in read-only mode for forensic purposes.
+	CfgDebugUnsafePinnedStateVersion = "consensus.tendermint.debug.unsafe_pinned_state_version"
 
 	// CfgMinGasPrice configures the minimum gas price for this validator.
 	CfgMinGasPrice = "consensus.tendermint.min_gas_price"
+	// CfgMaxTxGas configures the maximum gas limit of transactions accepted by this validator.
+	CfgMaxTxGas = "consensus.tendermint.max_tx_gas"
 
 	// CfgSupplementarySanityEnabled is the supplementary sanity enabled flag.
 	CfgSupplementarySanityEnabled = "consensus.tendermint.supplementarysanity.enabled"
@@ -123,6 +131,10 @@ const (
 	// CfgConsensusStateSyncTrustHash is the known trusted block header hash for the light client.
 	CfgConsensusStateSyncTrustHash = "consensus.tendermint.state_sync.trust_hash"
 
+	// CfgRoothashGenesisBlockCacheSize configures the maximum number of cached runtime genesis
+	// blocks (0 means unlimited).
+	CfgRoothashGenesisBlockCacheSize = "consensus.tendermint.roothash.genesis_block_cache_size"
+
 	// CfgUpgradeStopDelay is the average amount of time to delay shutting down the node on upgrade.
 	CfgUpgradeStopDelay = "consensus.tendermint.upgrade.stop_delay"
 )
@@ -146,6 +158,10 @@ var (
 
 	labelTendermint = prometheus.Labels{"backend": "tendermint"}
 
+	// errStatePinned is the error returned when an operation requires the Tendermint node while
+	// the ABCI state is pinned to a historical version and Tendermint is not running.
+	errStatePinned = fmt.Errorf("tendermint: not available while the state is pinned")
+
 	// Flags has the configuration flags.
 	Flags = flag.NewFlagSet("", flag.ContinueOnError)
 )
@@ -183,6 +199,7 @@ type fullService struct { // nolint: maligned
 	identity                 *identity.Identity
 	dataDir                  string
 	isInitialized, isStarted bool
+	pinnedStateVersion       uint64
 	startedCh                chan struct{}
 	syncedCh                 chan struct{}
 	quitCh                   chan struct{}
@@ -213,8 +230,18 @@ func (t *fullService) Start() error {
 		return fmt.Errorf("tendermint: service already started")
 	}
 
-	switch t.initialized() {
-	case true:
+	switch {
+	case t.initialized() && t.pinnedStateVersion != 0:
+		// When the state is pinned to a historical version, Tendermint is not started at all as
+		// that would trigger block replay. The state is only available for queries.
+		if err := t.mux.Start(); err != nil {
+			return err
+		}
+
+		t.Logger.Warn("ABCI state is pinned, not starting Tendermint",
+			"version", t.pinnedStateVersion,
+		)
+	case t.initialized():
 		if err := t.mux.Start(); err != nil {
 			return err
 		}
@@ -251,7 +278,7 @@ func (t *fullService) Start() error {
 		if cmmetrics.Enabled() {
 			go t.metrics()
 		}
-	case false:
+	default:
 		close(t.syncedCh)
 	}
 
@@ -282,6 +309,13 @@ func (t *fullService) Stop() {
 	}
 
 	t.stopOnce.Do(func() {
+		if t.pinnedStateVersion != 0 {
+			t.svcMgr.Stop()
+			t.mux.Stop()
+			close(t.quitCh)
+			return
+		}
+
 		t.failMonitor.markCleanShutdown()
 		if err := t.node.Stop(); err != nil {
 			t.Logger.Error("Error on stopping node", err)
@@ -532,6 +566,9 @@ func (t *fullService) SubmitEvidence(ctx context.Context, evidence *consensusAPI
 		return fmt.Errorf("tendermint: malformed evidence while converting: %w", err)
 	}
 
+	if err := t.ensureNodeStarted(ctx); err != nil {
+		return err
+	}
 	if _, err := t.client.BroadcastEvidence(ctx, ev); err != nil {
 		return fmt.Errorf("tendermint: broadcast evidence failed: %w", err)
 	}
@@ -549,6 +586,10 @@ func (t *fullService) subscribe(subscriber string, query tmpubsub.Query) (tmtype
 	// force-unsubscribe the channel if processing takes too long.
 
 	subFn := func() (tmtypes.Subscription, error) {
+		if t.pinnedStateVersion != 0 {
+			return nil, errStatePinned
+		}
+
 		sub, err := t.node.EventBus().SubscribeUnbuffered(t.ctx, subscriber, query)
 		if err != nil {
 			return nil, err
@@ -584,7 +625,7 @@ func (t *fullService) subscribe(subscriber string, query tmpubsub.Query) (tmtype
 }
 
 func (t *fullService) unsubscribe(subscriber string, query tmpubsub.Query) error {
-	if t.started() {
+	if t.started() && t.pinnedStateVersion == 0 {
 		return t.node.EventBus().Unsubscribe(t.ctx, subscriber, query)
 	}
 
@@ -753,6 +794,10 @@ func (t *fullService) GetTransactionsWithResults(ctx context.Context, height int
 }
 
 func (t *fullService) GetUnconfirmedTransactions(ctx context.Context) ([][]byte, error) {
+	if t.pinnedStateVersion != 0 {
+		return nil, errStatePinned
+	}
+
 	mempoolTxs := t.node.Mempool().ReapMaxTxs(-1)
 	txs := make([][]byte, 0, len(mempoolTxs))
 	for _, v := range mempoolTxs {
@@ -770,7 +815,18 @@ func (t *fullService) GetStatus(ctx context.Context) (*consensusAPI.Status, erro
 
 	status.ChainContext = t.genesis.ChainContext()
 	status.GenesisHeight = t.genesis.Height
-	if t.started() {
+	switch {
+	case t.started() && t.pinnedStateVersion != 0:
+		// Blocks are not available when the state is pinned, so only report the pinned height.
+		status.LatestHeight = t.mux.State().BlockHeight()
+		if status.LatestHeight > 0 {
+			epoch, err := t.beacon.GetEpoch(ctx, status.LatestHeight)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch epoch: %w", err)
+			}
+			status.LatestEpoch = epoch
+		}
+	case t.started():
 		// Only attempt to fetch blocks in case the consensus service has started as otherwise
 		// requests will block.
 		genBlk, err := t.GetBlock(ctx, t.genesis.Height)
@@ -884,6 +940,19 @@ func (t *fullService) ensureStarted(ctx context.Context) error {
 	return nil
 }
 
+func (t *fullService) ensureNodeStarted(ctx context.Context) error {
+	if err := t.ensureStarted(ctx); err != nil {
+		return err
+	}
+
+	// The Tendermint node is never started when the state is pinned.
+	if t.pinnedStateVersion != 0 {
+		return errStatePinned
+	}
+
+	return nil
+}
+
 func (t *fullService) initialize() error {
 	t.Lock()
 	defer t.Unlock()
@@ -973,7 +1042,7 @@ func (t *fullService) initialize() error {
 	t.svcMgr.RegisterCleanupOnly(t.scheduler, "scheduler backend")
 
 	var scRootHash tmroothash.ServiceClient
-	if scRootHash, err = tmroothash.New(t.ctx, t.dataDir, t); err != nil {
+	if scRootHash, err = tmroothash.New(t.ctx, t.dataDir, t, viper.GetUint64(CfgRoothashGenesisBlockCacheSize)); err != nil {
 		t.Logger.Error("roothash: failed to initialize roothash backend",
 			"err", err,
 		)
@@ -1006,12 +1075,19 @@ func (t *fullService) initialize() error {
 }
 
 func (t *fullService) GetLastRetainedVersion(ctx context.Context) (int64, error) {
-	if err := t.ensureStarted(ctx); err != nil {
+	if err := t.ensureNodeStarted(ctx); err != nil {
 		return -1, err
 	}
 	return t.node.BlockStore().Base(), nil
 }
 
+func (t *fullService) GetApplications(ctx context.Context) ([]*api.ApplicationInfo, error) {
+	if err := t.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+	return t.mux.RegisteredAppsInfo(), nil
+}
+
 func (t *fullService) heightToTendermintHeight(height int64) (int64, error) {
 	var tmHeight int64
 	if height == consensusAPI.HeightLatest {
@@ -1031,7 +1107,7 @@ func (t *fullService) heightToTendermintHeight(height int64) (int64, error) {
 }
 
 func (t *fullService) GetTendermintBlock(ctx context.Context, height int64) (*tmtypes.Block, error) {
-	if err := t.ensureStarted(ctx); err != nil {
+	if err := t.ensureNodeStarted(ctx); err != nil {
 		return nil, err
 	}
 
@@ -1053,12 +1129,12 @@ func (t *fullService) GetTendermintBlock(ctx context.Context, height int64) (*tm
 }
 
 func (t *fullService) GetBlockResults(ctx context.Context, height int64) (*tmrpctypes.ResultBlockResults, error) {
+	if err := t.ensureNodeStarted(ctx); err != nil {
+		return nil, err
+	}
 	if t.client == nil {
 		panic("client not available yet")
 	}
-	if err := t.ensureStarted(ctx); err != nil {
-		return nil, err
-	}
 
 	tmHeight, err := t.heightToTendermintHeight(height)
 	if err != nil {
@@ -1105,11 +1181,17 @@ func (t *fullService) lazyInit() error {
 		Pruning:                   pruneCfg,
 		HaltEpochHeight:           t.genesis.HaltEpoch,
 		MinGasPrice:               viper.GetUint64(CfgMinGasPrice),
+		MaxTxGas:                  viper.GetUint64(CfgMaxTxGas),
 		OwnTxSigner:               t.identity.NodeSigner.Public(),
 		DisableCheckpointer:       viper.GetBool(CfgCheckpointerDisabled),
 		CheckpointerCheckInterval: viper.GetDuration(CfgCheckpointerCheckInterval),
 		InitialHeight:             uint64(t.genesis.Height),
+		CheckTxCacheSize:          viper.GetUint64(CfgABCICheckTxCacheSize),
+	}
+	if cmflags.DebugDontBlameOasis() {
+		appConfig.PinnedVersion = viper.GetUint64(CfgDebugUnsafePinnedStateVersion)
 	}
+	t.pinnedStateVersion = appConfig.PinnedVersion
 	t.mux, err = abci.NewApplicationServer(t.ctx, t.upgrader, appConfig)
 	if err != nil {
 		return err
@@ -1524,6 +1606,7 @@ func New(
 func init() {
 	Flags.String(CfgABCIPruneStrategy, abci.PruneDefault, "ABCI state pruning strategy")
 	Flags.Uint64(CfgABCIPruneNumKept, 3600, "ABCI state versions kept (when applicable)")
+	Flags.Uint64(CfgABCICheckTxCacheSize, 1024, "maximum number of cached failed CheckTx results (0 disables the cache)")
 	Flags.Bool(CfgCheckpointerDisabled, false, "Disable the ABCI state checkpointer")
 	Flags.Duration(CfgCheckpointerCheckInterval, 1*time.Minute, "ABCI state checkpointer check interval")
 	Flags.StringSlice(CfgSentryUpstreamAddress, []string{}, "Tendermint nodes for which we act as sentry of the form ID@ip:port")
@@ -1532,7 +1615,9 @@ func init() {
 	Flags.Bool(CfgP2PDisablePeerExchange, false, "Disable Tendermint's peer-exchange reactor")
 	Flags.Duration(CfgP2PPersistenPeersMaxDialPeriod, 0*time.Second, "Tendermint max timeout when redialing a persistent peer (default: unlimited)")
 	Flags.Uint64(CfgMinGasPrice, 0, "minimum gas price")
+	Flags.Uint64(CfgMaxTxGas, 0, "maximum transaction gas limit (0 = unlimited)")
 	Flags.Bool(CfgDebugUnsafeReplayRecoverCorruptedWAL, false, "Enable automatic recovery from corrupted WAL during replay (UNSAFE).")
+	Flags.Uint64(CfgDebugUnsafePinnedStateVersion, 0, "Load the ABCI state at the given version in read-only mode without starting Tendermint, only queries are served (UNSAFE).")
 
 	Flags.Bool(CfgSupplementarySanityEnabled, false, "enable supplementary sanity checks (slows down consensus)")
 	Flags.Uint64(CfgSupplementarySanityInterval, 10, "supplementary sanity check interval (in blocks)")
@@ -1544,9 +1629,12 @@ func init() {
 	Flags.Uint64(CfgConsensusStateSyncTrustHeight, 0, "state sync: light client trusted height")
 	Flags.String(CfgConsensusStateSyncTrustHash, "", "state sync: light client trusted consensus header hash")
 
+	Flags.Uint64(CfgRoothashGenesisBlockCacheSize, 1024, "maximum number of cached runtime genesis blocks (0 means unlimited)")
+
 	Flags.Duration(CfgUpgradeStopDelay, 60*time.Second, "average amount of time to delay shutting down the node on upgrade")
 
 	_ = Flags.MarkHidden(CfgDebugUnsafeReplayRecoverCorruptedWAL)
+	_ = Flags.MarkHidden(CfgDebugUnsafePinnedStateVersion)
 
 	_ = Flags.MarkHidden(CfgSupplementarySanityEnabled)
 	_ = Flags.MarkHidden(CfgSupplementarySanityInterval)
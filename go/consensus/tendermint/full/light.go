@@ -15,7 +15,7 @@ import (
 
 // Implements LightClientBackend.
 func (t *fullService) GetLightBlock(ctx context.Context, height int64) (*consensusAPI.LightBlock, error) {
-	if err := t.ensureStarted(ctx); err != nil {
+	if err := t.ensureNodeStarted(ctx); err != nil {
 		return nil, err
 	}
 
@@ -59,7 +59,7 @@ func (t *fullService) GetLightBlock(ctx context.Context, height int64) (*consens
 
 // Implements LightClientBackend.
 func (t *fullService) GetParameters(ctx context.Context, height int64) (*consensusAPI.Parameters, error) {
-	if err := t.ensureStarted(ctx); err != nil {
+	if err := t.ensureNodeStarted(ctx); err != nil {
 		return nil, err
 	}
 
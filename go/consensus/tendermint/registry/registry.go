@@ -13,6 +13,7 @@ import (
 	tmrpctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -23,6 +24,7 @@ import (
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
@@ -108,6 +110,42 @@ func (sc *serviceClient) GetNodes(ctx context.Context, height int64) ([]*node.No
 	return q.Nodes(ctx)
 }
 
+func (sc *serviceClient) GetNodesAtEpoch(ctx context.Context, epoch beacon.EpochTime) ([]*node.Node, error) {
+	height, err := sc.backend.Beacon().GetEpochBlock(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("registry: failed to query epoch block: %w", err)
+	}
+	if cmdFlags.DebugDontBlameOasis() {
+		var lastRetained int64
+		if lastRetained, err = sc.backend.GetLastRetainedVersion(ctx); err != nil {
+			return nil, fmt.Errorf("registry: failed to query last retained height: %w", err)
+		}
+		if err = beacon.VerifyEpochBlock(ctx, sc.backend.Beacon(), epoch, height, lastRetained); err != nil {
+			return nil, fmt.Errorf("registry: inconsistent epoch block: %w", err)
+		}
+	}
+
+	nl, err := sc.getNodeList(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return nl.Nodes, nil
+}
+
+func (sc *serviceClient) GetNodeListHash(ctx context.Context, epoch beacon.EpochTime) (*hash.Hash, error) {
+	height, err := sc.backend.Beacon().GetEpochBlock(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("registry: failed to query epoch block: %w", err)
+	}
+
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.NodeListHash(ctx)
+}
+
 func (sc *serviceClient) GetNodeByConsensusAddress(ctx context.Context, query *api.ConsensusAddressQuery) (*node.Node, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -133,6 +171,97 @@ func (sc *serviceClient) WatchNodeList(ctx context.Context) (<-chan *api.NodeLis
 	return typedCh, sub, nil
 }
 
+func (sc *serviceClient) WatchNodeListSince(ctx context.Context, since beacon.EpochTime) (<-chan *api.NodeList, pubsub.ClosableSubscription, error) {
+	// Subscribe first so that no node lists are missed while replaying. Upon
+	// subscription the live stream starts with the latest node list (which
+	// stands in for the current epoch), so only the node lists of the past
+	// epochs need to be replayed.
+	//
+	// In case an epoch transition happens between subscribing and querying the
+	// current epoch, the node list of the previous epoch may be delivered twice.
+	liveCh := make(chan *api.NodeList)
+	sub := sc.nodeListNotifier.Subscribe()
+	sub.Unwrap(liveCh)
+
+	fail := func(err error) (<-chan *api.NodeList, pubsub.ClosableSubscription, error) {
+		sub.Close()
+		// Do not report cancellation as a failure to serve the request.
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		return nil, nil, err
+	}
+
+	epoch, err := sc.backend.Beacon().GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fail(fmt.Errorf("registry: failed to query current epoch: %w", err))
+	}
+	if since > epoch {
+		return fail(fmt.Errorf("registry: requested epoch %d is in the future (current: %d)", since, epoch))
+	}
+
+	if since < epoch {
+		// The node list of an epoch is retained iff its epoch block is retained.
+		lastRetained, rerr := sc.backend.GetLastRetainedVersion(ctx)
+		if rerr != nil {
+			return fail(fmt.Errorf("registry: failed to query last retained height: %w", rerr))
+		}
+		retainedEpoch, rerr := sc.backend.Beacon().GetEpoch(ctx, lastRetained)
+		if rerr != nil {
+			return fail(fmt.Errorf("registry: failed to query epoch at height %d: %w", lastRetained, rerr))
+		}
+		retainedEpochBlock, rerr := sc.backend.Beacon().GetEpochBlock(ctx, retainedEpoch)
+		if rerr != nil {
+			return fail(fmt.Errorf("registry: failed to query epoch block: %w", rerr))
+		}
+		if retainedEpochBlock < lastRetained {
+			retainedEpoch++
+		}
+		if since < retainedEpoch {
+			return fail(fmt.Errorf("%w: epoch %d (earliest retained: %d)", api.ErrNodeListNotRetained, since, retainedEpoch))
+		}
+	}
+
+	var replay []*api.NodeList
+	for e := since; e < epoch; e++ { // Current epoch is delivered by the live stream.
+		nodes, nerr := sc.GetNodesAtEpoch(ctx, e)
+		if nerr != nil {
+			return fail(fmt.Errorf("registry: failed to query node list at epoch %d: %w", e, nerr))
+		}
+		replay = append(replay, &api.NodeList{Nodes: nodes})
+	}
+
+	typedCh := make(chan *api.NodeList)
+	go func() {
+		defer close(typedCh)
+
+		for _, nl := range replay {
+			select {
+			case typedCh <- nl:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for {
+			select {
+			case nl, ok := <-liveCh:
+				if !ok {
+					return
+				}
+				select {
+				case typedCh <- nl:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return typedCh, sub, nil
+}
+
 func (sc *serviceClient) GetRuntime(ctx context.Context, query *api.NamespaceQuery) (*api.Runtime, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
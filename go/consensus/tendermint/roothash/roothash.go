@@ -4,24 +4,28 @@ package roothash
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"math"
+	"sort"
 	"sync"
 
 	"github.com/eapache/channels"
 	"github.com/hashicorp/go-multierror"
+	"github.com/prometheus/client_golang/prometheus"
 	tmabcitypes "github.com/tendermint/tendermint/abci/types"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmrpctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -30,7 +34,25 @@ import (
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 )
 
-const crashPointBlockBeforeIndex = "roothash.before_index"
+const (
+	crashPointBlockBeforeIndex     = "roothash.before_index"
+	crashPointBlockAfterIndex      = "roothash.after_index"
+	crashPointBlockBeforeBroadcast = "roothash.before_broadcast"
+)
+
+var (
+	genesisBlockCacheEntries = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "oasis_roothash_genesis_block_cache_size",
+			Help: "Number of runtime genesis blocks in the roothash genesis block cache.",
+		},
+	)
+	roothashCollectors = []prometheus.Collector{
+		genesisBlockCacheEntries,
+	}
+
+	metricsOnce sync.Once
+)
 
 // ServiceClient is the roothash service client interface.
 type ServiceClient interface {
@@ -46,6 +68,10 @@ type runtimeBrokers struct {
 
 	lastBlockHeight int64
 	lastBlock       *block.Block
+
+	// lastDiscrepancy is the last execution discrepancy event that has not
+	// yet been resolved by the runtime's round being finalized.
+	lastDiscrepancy *api.Event
 }
 
 type trackedRuntime struct {
@@ -73,7 +99,7 @@ type serviceClient struct {
 
 	allBlockNotifier *pubsub.Broker
 	runtimeNotifiers map[common.Namespace]*runtimeBrokers
-	genesisBlocks    map[common.Namespace]*block.Block
+	genesisBlocks    *lru.Cache
 
 	queryCh        chan tmpubsub.Query
 	cmdCh          chan interface{}
@@ -84,12 +110,9 @@ type serviceClient struct {
 func (sc *serviceClient) GetGenesisBlock(ctx context.Context, request *api.RuntimeRequest) (*block.Block, error) {
 	// First check if we have the genesis blocks cached. They are immutable so easy
 	// to cache to avoid repeated requests to the Tendermint app.
-	sc.RLock()
-	if blk := sc.genesisBlocks[request.RuntimeID]; blk != nil {
-		sc.RUnlock()
-		return blk, nil
+	if blk, ok := sc.genesisBlocks.Get(request.RuntimeID); ok {
+		return blk.(*block.Block), nil
 	}
-	sc.RUnlock()
 
 	q, err := sc.querier.QueryAt(ctx, request.Height)
 	if err != nil {
@@ -102,18 +125,56 @@ func (sc *serviceClient) GetGenesisBlock(ctx context.Context, request *api.Runti
 	}
 
 	// Update the genesis block cache.
-	sc.Lock()
-	sc.genesisBlocks[request.RuntimeID] = blk
-	sc.Unlock()
+	if err = sc.cacheGenesisBlock(request.RuntimeID, blk); err != nil {
+		return nil, err
+	}
 
 	return blk, nil
 }
 
+func (sc *serviceClient) cacheGenesisBlock(runtimeID common.Namespace, blk *block.Block) error {
+	// In case the entry gets evicted, the genesis block will be re-fetched,
+	// which is fine as it is immutable.
+	if err := sc.genesisBlocks.Put(runtimeID, blk); err != nil {
+		return err
+	}
+	genesisBlockCacheEntries.Set(float64(sc.genesisBlocks.Size()))
+
+	return nil
+}
+
 // Implements api.Backend.
 func (sc *serviceClient) GetLatestBlock(ctx context.Context, request *api.RuntimeRequest) (*block.Block, error) {
+	// Use the latest block emitted by the worker if it is still current, to
+	// avoid querying the state in the common case of polling for the latest
+	// block.
+	if request.Height == consensus.HeightLatest {
+		if blk := sc.getCachedLatestBlock(request.RuntimeID); blk != nil {
+			return blk, nil
+		}
+	}
 	return sc.getLatestBlockAt(ctx, request.RuntimeID, request.Height)
 }
 
+func (sc *serviceClient) getCachedLatestBlock(runtimeID common.Namespace) *block.Block {
+	sc.RLock()
+	notifiers := sc.runtimeNotifiers[runtimeID]
+	sc.RUnlock()
+	if notifiers == nil {
+		return nil
+	}
+
+	notifiers.Lock()
+	defer notifiers.Unlock()
+
+	// The emitted block is only known to be the latest block at the height it
+	// was finalized at, as the worker may lag behind the committed state.
+	if notifiers.lastBlock == nil || notifiers.lastBlockHeight != sc.querier.LatestHeight() {
+		return nil
+	}
+	return notifiers.lastBlock
+}
+
 func (sc *serviceClient) getLatestBlockAt(ctx context.Context, runtimeID common.Namespace, height int64) (*block.Block, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -154,8 +215,7 @@ func (sc *serviceClient) WatchBlocks(ctx context.Context, id common.Namespace) (
 	// Make sure that we only ever emit monotonically increasing blocks. Without
 	// special handling this can happen for the first received block due to
 	// replaying the latest block (see above).
-	invalidRound := uint64(math.MaxUint64)
-	lastRound := invalidRound
+	filter := block.NewMonotonicFilter(false)
 	monotonicCh := make(chan *api.AnnotatedBlock)
 	go func() {
 		defer close(monotonicCh)
@@ -165,10 +225,9 @@ func (sc *serviceClient) WatchBlocks(ctx context.Context, id common.Namespace) (
 			if !ok {
 				return
 			}
-			if lastRound != invalidRound && blk.Block.Header.Round <= lastRound {
+			if !filter.Accept(blk.Block.Header.Round) {
 				continue
 			}
-			lastRound = blk.Block.Header.Round
 			monotonicCh <- blk
 		}
 	}()
@@ -190,10 +249,167 @@ func (sc *serviceClient) WatchAllBlocks() (<-chan *block.Block, *pubsub.Subscrip
 	return ch, sub
 }
 
+// WatchAllBlocksFrom replays the blocks of all tracked runtimes from their history keepers and
+// then streams blocks as they are finalized.
+func (sc *serviceClient) WatchAllBlocksFrom(ctx context.Context, height int64) (<-chan *block.Block, pubsub.ClosableSubscription, error) {
+	// Subscribe first so that no blocks are missed between the replay and the
+	// live stream.
+	sub := sc.allBlockNotifier.Subscribe()
+	liveCh := make(chan *block.Block)
+	sub.Unwrap(liveCh)
+
+	replay, err := sc.getFinalizedBlocksSince(ctx, height)
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+
+	// Track the last replayed round of each runtime so that blocks which are
+	// both replayed and received live are only emitted once.
+	lastRounds := make(map[common.Namespace]uint64)
+	for _, blk := range replay {
+		lastRounds[blk.Header.Namespace] = blk.Header.Round
+	}
+
+	ctx, csub := pubsub.NewContextSubscription(ctx)
+	ch := make(chan *block.Block)
+	go func() {
+		defer close(ch)
+		defer sub.Close()
+
+		for _, blk := range replay {
+			select {
+			case ch <- blk:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for {
+			var blk *block.Block
+			select {
+			case b, ok := <-liveCh:
+				if !ok {
+					return
+				}
+				blk = b
+			case <-ctx.Done():
+				return
+			}
+
+			if lastRound, ok := lastRounds[blk.Header.Namespace]; ok && blk.Header.Round <= lastRound {
+				continue
+			}
+
+			select {
+			case ch <- blk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, csub, nil
+}
+
+// getFinalizedBlocksSince returns the blocks of all runtimes tracked with a block history that
+// were finalized at or after the given consensus height, ordered by height.
+//
+// The blocks are read from the history keepers by walking back from the latest committed round,
+// so only the replayed blocks need to be looked up.
+func (sc *serviceClient) getFinalizedBlocksSince(ctx context.Context, height int64) ([]*block.Block, error) {
+	lastRetainedHeight, err := sc.backend.GetLastRetainedVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("roothash: failed to get last retained height: %w", err)
+	}
+	if height < lastRetainedHeight {
+		return nil, fmt.Errorf("%w: height %d is below the last retained height %d",
+			consensus.ErrVersionNotFound,
+			height,
+			lastRetainedHeight,
+		)
+	}
+
+	sc.RLock()
+	var histories []api.BlockHistory
+	for _, tr := range sc.trackedRuntime {
+		if tr.blockHistory != nil {
+			histories = append(histories, tr.blockHistory)
+		}
+	}
+	sc.RUnlock()
+
+	var annBlks []*api.AnnotatedBlock
+	for _, bh := range histories {
+		var rtBlks []*api.AnnotatedBlock
+		if rtBlks, err = getHistoryBlocksSince(ctx, bh, height); err != nil {
+			return nil, fmt.Errorf("roothash: failed to replay blocks of runtime %s: %w", bh.RuntimeID(), err)
+		}
+		annBlks = append(annBlks, rtBlks...)
+	}
+	// Blocks of each runtime are already ordered by round, so a stable sort keeps them in order.
+	sort.SliceStable(annBlks, func(i, j int) bool {
+		return annBlks[i].Height < annBlks[j].Height
+	})
+
+	blocks := make([]*block.Block, 0, len(annBlks))
+	for _, annBlk := range annBlks {
+		blocks = append(blocks, annBlk.Block)
+	}
+	return blocks, nil
+}
+
+// getHistoryBlocksSince returns the blocks from the given block history that were finalized at or
+// after the given consensus height, ordered by round.
+func getHistoryBlocksSince(ctx context.Context, bh api.BlockHistory, height int64) ([]*api.AnnotatedBlock, error) {
+	latest, err := bh.GetLatestBlock(ctx)
+	switch {
+	case err == nil:
+	case errors.Is(err, api.ErrNotFound):
+		// Nothing has been committed yet.
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	var annBlks []*api.AnnotatedBlock
+	for round := latest.Header.Round; ; round-- {
+		annBlk, err := bh.GetAnnotatedBlock(ctx, round)
+		if errors.Is(err, api.ErrNotFound) {
+			// Earlier rounds have been pruned.
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if annBlk.Height < height {
+			break
+		}
+		annBlks = append(annBlks, annBlk)
+
+		if round == 0 {
+			break
+		}
+	}
+
+	// Reverse the blocks so they are ordered by round.
+	for i, j := 0, len(annBlks)-1; i < j; i, j = i+1, j-1 {
+		annBlks[i], annBlks[j] = annBlks[j], annBlks[i]
+	}
+	return annBlks, nil
+}
+
 // Implements api.Backend.
 func (sc *serviceClient) WatchEvents(ctx context.Context, id common.Namespace) (<-chan *api.Event, pubsub.ClosableSubscription, error) {
 	notifiers := sc.getRuntimeNotifiers(id)
-	sub := notifiers.eventNotifier.Subscribe()
+	sub := notifiers.eventNotifier.SubscribeEx(-1, func(ch channels.Channel) {
+		// Replay the outstanding discrepancy event if it exists.
+		notifiers.Lock()
+		defer notifiers.Unlock()
+		if notifiers.lastDiscrepancy != nil {
+			ch.In() <- notifiers.lastDiscrepancy
+		}
+	})
 	ch := make(chan *api.Event)
 	sub.Unwrap(ch)
 
@@ -407,8 +623,9 @@ func (sc *serviceClient) reindexBlocks(currentHeight int64, bh api.BlockHistory)
 		)
 		return lastRound, fmt.Errorf("failed to get last indexed height: %w", err)
 	}
-	// +1 since we want the last non-seen height.
-	lastHeight++
+	// Start at the last seen height instead of the next one, so that the last committed block is
+	// committed again. This is a no-op in the history keeper, but makes sure that recovery after a
+	// crash between committing a block and broadcasting it goes through the regular commit path.
 
 	// Take prune strategy into account.
 	lastRetainedHeight, err := sc.backend.GetLastRetainedVersion(sc.ctx)
@@ -532,7 +749,9 @@ func (sc *serviceClient) DeliverCommand(ctx context.Context, height int64, cmd i
 			runtimeID:    c.runtimeID,
 			blockHistory: c.blockHistory,
 		}
+		sc.Lock()
 		sc.trackedRuntime[c.runtimeID] = tr
+		sc.Unlock()
 		// Request subscription to events for this runtime.
 		sc.queryCh <- app.QueryForRuntime(tr.runtimeID)
 
@@ -560,13 +779,24 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx tmty
 	}
 
 	for _, ev := range events {
+		notifiers := sc.getRuntimeNotifiers(ev.RuntimeID)
+
 		// Notify non-finalized events.
 		if ev.Finalized == nil {
-			notifiers := sc.getRuntimeNotifiers(ev.RuntimeID)
+			if ev.ExecutionDiscrepancyDetected != nil {
+				notifiers.Lock()
+				notifiers.lastDiscrepancy = ev
+				notifiers.Unlock()
+			}
 			notifiers.eventNotifier.Broadcast(ev)
 			continue
 		}
 
+		// Finalizing the round resolves any outstanding discrepancy.
+		notifiers.Lock()
+		notifiers.lastDiscrepancy = nil
+		notifiers.Unlock()
+
 		// Only process finalized events for tracked runtimes.
 		if sc.trackedRuntime[ev.RuntimeID] == nil {
 			continue
@@ -679,6 +909,8 @@ func (sc *serviceClient) processFinalizedEvent(
 				)
 				return fmt.Errorf("failed to commit block to history keeper: %w", err)
 			}
+
+			crash.Here(crashPointBlockAfterIndex)
 		}
 	}
 
@@ -687,6 +919,8 @@ func (sc *serviceClient) processFinalizedEvent(
 		return nil
 	}
 
+	crash.Here(crashPointBlockBeforeBroadcast)
+
 	notifiers := sc.getRuntimeNotifiers(runtimeID)
 	// Ensure latest block is set.
 	notifiers.Lock()
@@ -748,6 +982,16 @@ func EventsFromTendermint(
 
 				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, ExecutionDiscrepancyDetected: &value.Event}
 				events = append(events, ev)
+			case bytes.Equal(key, app.KeyRoundFailed):
+				// A round has failed.
+				var value app.ValueRoundFailed
+				if err := cbor.Unmarshal(val, &value); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("roothash: corrupt RoundFailed event: %w", err))
+					continue
+				}
+
+				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, RoundFailed: &value.Event}
+				events = append(events, ev)
 			case bytes.Equal(key, app.KeyExecutorCommitted):
 				// An executor commit has been processed.
 				var value app.ValueExecutorCommitted
@@ -778,18 +1022,35 @@ func EventsFromTendermint(
 	return events, errs
 }
 
+func newGenesisBlockCache(size uint64) (*lru.Cache, error) {
+	return lru.New(lru.Capacity(size, false))
+}
+
 // New constructs a new tendermint-based root hash backend.
+//
+// The genesis block cache is bounded to genesisBlockCacheSize entries, where
+// zero means that the cache is unbounded.
 func New(
 	ctx context.Context,
 	dataDir string,
 	backend tmapi.Backend,
+	genesisBlockCacheSize uint64,
 ) (ServiceClient, error) {
+	genesisBlocks, err := newGenesisBlockCache(genesisBlockCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("roothash: failed to create genesis block cache: %w", err)
+	}
+
 	// Initialize and register the tendermint service component.
 	a := app.New()
-	if err := backend.RegisterApplication(a); err != nil {
+	if err = backend.RegisterApplication(a); err != nil {
 		return nil, err
 	}
 
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(roothashCollectors...)
+	})
+
 	return &serviceClient{
 		ctx:              ctx,
 		logger:           logging.GetLogger("roothash/tendermint"),
@@ -797,7 +1058,7 @@ func New(
 		querier:          a.QueryFactory().(*app.QueryFactory),
 		allBlockNotifier: pubsub.NewBroker(false),
 		runtimeNotifiers: make(map[common.Namespace]*runtimeBrokers),
-		genesisBlocks:    make(map[common.Namespace]*block.Block),
+		genesisBlocks:    genesisBlocks,
 		queryCh:          make(chan tmpubsub.Query, runtimeRegistry.MaxRuntimeCount),
 		cmdCh:            make(chan interface{}, runtimeRegistry.MaxRuntimeCount),
 		trackedRuntime:   make(map[common.Namespace]*trackedRuntime),
@@ -807,5 +1068,7 @@ func New(
 func init() {
 	crash.RegisterCrashPoints(
 		crashPointBlockBeforeIndex,
+		crashPointBlockAfterIndex,
+		crashPointBlockBeforeBroadcast,
 	)
 }
@@ -0,0 +1,198 @@
+package roothash
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	app "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+const recvTimeout = 100 * time.Millisecond
+
+func newTestServiceClient(t *testing.T, genesisBlockCacheSize uint64) *serviceClient {
+	genesisBlocks, err := newGenesisBlockCache(genesisBlockCacheSize)
+	require.NoError(t, err, "newGenesisBlockCache")
+
+	// The mock state has no committed blocks, so any query will fail.
+	appState := tmapi.NewMockApplicationState(&tmapi.MockApplicationStateConfig{})
+	return &serviceClient{
+		ctx:              context.Background(),
+		logger:           logging.GetLogger("roothash/tendermint/test"),
+		querier:          app.NewQueryFactory(appState),
+		runtimeNotifiers: make(map[common.Namespace]*runtimeBrokers),
+		genesisBlocks:    genesisBlocks,
+		cmdCh:            make(chan interface{}, 16),
+		trackedRuntime:   make(map[common.Namespace]*trackedRuntime),
+	}
+}
+
+func TestGenesisBlockCache(t *testing.T) {
+	require := require.New(t)
+
+	const cacheSize = 8
+	sc := newTestServiceClient(t, cacheSize)
+	ctx := context.Background()
+
+	runtimeIDs := make([]common.Namespace, 4*cacheSize)
+	for i := range runtimeIDs {
+		runtimeIDs[i] = common.NewTestNamespaceFromSeed([]byte(fmt.Sprintf("roothash/tendermint: genesis cache runtime %d", i)), 0)
+		err := sc.cacheGenesisBlock(runtimeIDs[i], block.NewGenesisBlock(runtimeIDs[i], uint64(i)))
+		require.NoError(err, "cacheGenesisBlock")
+		require.LessOrEqual(sc.genesisBlocks.Size(), uint64(cacheSize), "genesis block cache should respect the bound")
+	}
+	require.EqualValues(cacheSize, sc.genesisBlocks.Size(), "genesis block cache should be full")
+
+	for i, runtimeID := range runtimeIDs {
+		blk, err := sc.GetGenesisBlock(ctx, &api.RuntimeRequest{
+			RuntimeID: runtimeID,
+			Height:    consensus.HeightLatest,
+		})
+		if i < len(runtimeIDs)-cacheSize {
+			// Evicted entries should be re-fetched from the state.
+			require.ErrorIs(err, consensus.ErrNoCommittedBlocks, "GetGenesisBlock should query the state for evicted entries")
+			continue
+		}
+		require.NoError(err, "GetGenesisBlock should use the cached block")
+		require.Equal(block.NewGenesisBlock(runtimeID, uint64(i)), blk, "GetGenesisBlock should return the cached block")
+	}
+
+	// An unbounded cache should keep all entries.
+	sc = newTestServiceClient(t, 0)
+	for i, runtimeID := range runtimeIDs {
+		err := sc.cacheGenesisBlock(runtimeID, block.NewGenesisBlock(runtimeID, uint64(i)))
+		require.NoError(err, "cacheGenesisBlock")
+	}
+	require.EqualValues(len(runtimeIDs), sc.genesisBlocks.Size(), "unbounded genesis block cache should keep all entries")
+}
+
+func TestGetLatestBlockCache(t *testing.T) {
+	require := require.New(t)
+
+	// Note that the mock state does not support queries, so any query will fail once there are
+	// committed blocks. Keep the latest height at zero when the state should be queried so that
+	// queries fail cleanly.
+	stateCfg := &tmapi.MockApplicationStateConfig{}
+	sc := newTestServiceClient(t, 0)
+	sc.querier = app.NewQueryFactory(tmapi.NewMockApplicationState(stateCfg))
+	ctx := context.Background()
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("roothash/tendermint: cache test runtime"), 0)
+	latestReq := &api.RuntimeRequest{
+		RuntimeID: runtimeID,
+		Height:    consensus.HeightLatest,
+	}
+
+	// Without a cached block, the state should be queried.
+	_, err := sc.GetLatestBlock(ctx, latestReq)
+	require.ErrorIs(err, consensus.ErrNoCommittedBlocks, "GetLatestBlock should query the state without a cached block")
+
+	// Simulate the worker emitting a new block.
+	blk := block.NewGenesisBlock(runtimeID, 0)
+	notifiers := sc.getRuntimeNotifiers(runtimeID)
+	notifiers.Lock()
+	notifiers.lastBlock = blk
+	notifiers.lastBlockHeight = 42
+	notifiers.Unlock()
+
+	// The cached block should be used when it is current.
+	stateCfg.BlockHeight = 42
+	latestBlk, err := sc.GetLatestBlock(ctx, latestReq)
+	require.NoError(err, "GetLatestBlock should use the cached block")
+	require.Equal(blk, latestBlk, "GetLatestBlock should return the cached block")
+
+	// The cache should be bypassed when it is not current.
+	stateCfg.BlockHeight = 0
+	_, err = sc.GetLatestBlock(ctx, latestReq)
+	require.ErrorIs(err, consensus.ErrNoCommittedBlocks, "GetLatestBlock should query the state when the cache is stale")
+
+	// The cache should be bypassed for historical heights.
+	_, err = sc.GetLatestBlock(ctx, &api.RuntimeRequest{
+		RuntimeID: runtimeID,
+		Height:    42,
+	})
+	require.ErrorIs(err, consensus.ErrNoCommittedBlocks, "GetLatestBlock should query the state for historical heights")
+
+	// The cache should be updated when new blocks arrive.
+	nextBlk := block.NewEmptyBlock(blk, 1, block.Normal)
+	notifiers.Lock()
+	notifiers.lastBlock = nextBlk
+	notifiers.lastBlockHeight = 43
+	notifiers.Unlock()
+
+	stateCfg.BlockHeight = 43
+	latestBlk, err = sc.GetLatestBlock(ctx, latestReq)
+	require.NoError(err, "GetLatestBlock should use the cached block")
+	require.Equal(nextBlk, latestBlk, "GetLatestBlock should return the updated cached block")
+}
+
+func TestWatchEventsReplay(t *testing.T) {
+	require := require.New(t)
+
+	sc := newTestServiceClient(t, 0)
+	ctx := context.Background()
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("roothash/tendermint: event replay test runtime"), 0)
+
+	deliverEvent := func(height int64, key []byte, value interface{}) {
+		tmEv := tmapi.NewEventBuilder(app.AppName).Attribute(key, cbor.Marshal(value)).Event()
+		err := sc.DeliverEvent(ctx, height, nil, &tmEv)
+		require.NoError(err, "DeliverEvent")
+	}
+	recvEvent := func(ch <-chan *api.Event) *api.Event {
+		select {
+		case ev := <-ch:
+			return ev
+		case <-time.After(recvTimeout):
+			return nil
+		}
+	}
+
+	// Without any discrepancies, nothing should be replayed.
+	ch, sub, err := sc.WatchEvents(ctx, runtimeID)
+	require.NoError(err, "WatchEvents")
+	require.Nil(recvEvent(ch), "no event should be replayed without a discrepancy")
+	sub.Close()
+
+	// Trigger a discrepancy.
+	deliverEvent(10, app.KeyExecutionDiscrepancyDetected, &app.ValueExecutionDiscrepancyDetected{
+		ID:    runtimeID,
+		Event: api.ExecutionDiscrepancyDetectedEvent{Timeout: true},
+	})
+
+	// The outstanding discrepancy should be replayed on subscribe.
+	ch, sub, err = sc.WatchEvents(ctx, runtimeID)
+	require.NoError(err, "WatchEvents")
+	ev := recvEvent(ch)
+	require.NotNil(ev, "outstanding discrepancy should be replayed")
+	require.Equal(runtimeID, ev.RuntimeID)
+	require.EqualValues(10, ev.Height)
+	require.NotNil(ev.ExecutionDiscrepancyDetected, "replayed event should be a discrepancy event")
+	require.True(ev.ExecutionDiscrepancyDetected.Timeout)
+	sub.Close()
+
+	// Discrepancies of other runtimes should not be replayed.
+	otherRuntimeID := common.NewTestNamespaceFromSeed([]byte("roothash/tendermint: event replay other runtime"), 0)
+	ch, sub, err = sc.WatchEvents(ctx, otherRuntimeID)
+	require.NoError(err, "WatchEvents")
+	require.Nil(recvEvent(ch), "discrepancies of other runtimes should not be replayed")
+	sub.Close()
+
+	// Once the round is finalized, the discrepancy should no longer be replayed.
+	deliverEvent(11, app.KeyFinalized, &app.ValueFinalized{
+		ID:    runtimeID,
+		Event: api.FinalizedEvent{Round: 1},
+	})
+	ch, sub, err = sc.WatchEvents(ctx, runtimeID)
+	require.NoError(err, "WatchEvents")
+	require.Nil(recvEvent(ch), "resolved discrepancy should not be replayed")
+	sub.Close()
+}
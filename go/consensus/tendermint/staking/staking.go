@@ -11,6 +11,7 @@ import (
 	tmrpctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -186,6 +187,20 @@ func (sc *serviceClient) Allowance(ctx context.Context, query *api.AllowanceQuer
 	return &allowance, nil
 }
 
+func (sc *serviceClient) ActiveRewardStep(ctx context.Context, query *api.RewardStepQuery) (*api.RewardStep, error) {
+	params, err := sc.ConsensusParameters(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	step := api.RewardStepAt(params.RewardSchedule, query.Epoch)
+	if step == nil {
+		// We're past the end of the schedule, no rewards are given.
+		return &api.RewardStep{Until: beacon.EpochInvalid}, nil
+	}
+	return step, nil
+}
+
 func (sc *serviceClient) StateToGenesis(ctx context.Context, height int64) (*api.Genesis, error) {
 	// Query the staking genesis state.
 	q, err := sc.querier.QueryAt(ctx, height)
@@ -398,6 +413,16 @@ func EventsFromTendermint(
 
 				evt := &api.Event{Height: height, TxHash: txHash, AllowanceChange: &e}
 				events = append(events, evt)
+			case tmapi.IsAttributeKind(key, &api.SlashEvent{}):
+				// Slash event.
+				var e api.SlashEvent
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt Slash event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, Slash: &e}
+				events = append(events, evt)
 			default:
 				errs = multierror.Append(errs, fmt.Errorf("staking: unknown event type: key: %s, val: %s", key, val))
 			}
@@ -63,6 +63,7 @@ func NewTestNodeGenesisProvider(identity *identity.Identity) (genesis.Provider,
 					registry.GovernanceEntity:  true,
 					registry.GovernanceRuntime: true,
 				},
+				EnableNodeListHash: true,
 			},
 		},
 		Scheduler: scheduler.Genesis{
@@ -72,16 +73,18 @@ func NewTestNodeGenesisProvider(identity *identity.Identity) (genesis.Provider,
 				MaxValidatorsPerEntity: 100,
 				DebugBypassStake:       true,
 				DebugStaticValidators:  true,
+				EnforceAdmissionPolicy: true,
 			},
 		},
 		Governance: governance.Genesis{
 			Parameters: governance.ConsensusParameters{
-				Quorum:                    90,
-				Threshold:                 90,
-				UpgradeCancelMinEpochDiff: 20,
-				UpgradeMinEpochDiff:       20,
-				VotingPeriod:              10,
-				MinProposalDeposit:        *quantity.NewFromUint64(100),
+				Quorum:                       90,
+				Threshold:                    90,
+				UpgradeCancelMinEpochDiff:    20,
+				UpgradeMinEpochDiff:          20,
+				VotingPeriod:                 10,
+				MinProposalDeposit:           *quantity.NewFromUint64(100),
+				EnableUpdateRuntimeProposals: true,
 			},
 		},
 		RootHash: roothash.Genesis{
@@ -14,9 +14,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/localstorage"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
+	executorWorker "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 	storageWorker "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
 )
 
@@ -50,6 +52,24 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// SetTagIndexerEnabled enables or disables the tag indexer for the given runtime.
+	//
+	// When the tag indexer is enabled after being disabled, all blocks available in the runtime
+	// history are reindexed in the background.
+	SetTagIndexerEnabled(ctx context.Context, request *SetTagIndexerEnabledRequest) error
+
+	// CompactLocalStorage compacts the local storage database of the given runtime and reclaims
+	// unused space.
+	CompactLocalStorage(ctx context.Context, runtimeID common.Namespace) error
+}
+
+// SetTagIndexerEnabledRequest is a SetTagIndexerEnabled request.
+type SetTagIndexerEnabledRequest struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Enabled specifies whether the tag indexer should be enabled.
+	Enabled bool `json:"enabled"`
 }
 
 // Status is the current status overview.
@@ -125,8 +145,12 @@ type RuntimeStatus struct {
 	// Committee contains the runtime worker status in case this node is a (candidate) member of a
 	// runtime committee (e.g., compute or storage).
 	Committee *commonWorker.Status `json:"committee"`
+	// Executor contains the executor worker status in case this node is an executor node.
+	Executor *executorWorker.Status `json:"executor,omitempty"`
 	// Storage contains the storage worker status in case this node is a storage node.
 	Storage *storageWorker.Status `json:"storage"`
+	// LocalStorage contains the runtime local storage statistics.
+	LocalStorage *localstorage.Stats `json:"local_storage,omitempty"`
 }
 
 // ControlledNode is an internal interface that the controlled oasis-node must provide.
@@ -148,6 +172,12 @@ type ControlledNode interface {
 
 	// GetPendingUpgrade returns the node's pending upgrades.
 	GetPendingUpgrades(ctx context.Context) ([]*upgrade.PendingUpgrade, error)
+
+	// SetTagIndexerEnabled enables or disables the tag indexer for the given runtime.
+	SetTagIndexerEnabled(runtimeID common.Namespace, enabled bool) error
+
+	// CompactLocalStorage compacts the local storage database of the given runtime.
+	CompactLocalStorage(runtimeID common.Namespace) error
 }
 
 // DebugModuleName is the module name for the debug controller service.
@@ -165,6 +195,32 @@ type DebugController interface {
 	//       return an error.
 	SetEpoch(ctx context.Context, epoch beacon.EpochTime) error
 
+	// ForceEpochTransition manually advances the current epoch by one.
+	//
+	// NOTE: This only works with a mock beacon backend and will otherwise
+	//       return an error.
+	ForceEpochTransition(ctx context.Context) error
+
 	// WaitNodesRegistered waits for the given number of nodes to register.
 	WaitNodesRegistered(ctx context.Context, count int) error
+
+	// GetConsensusApplications returns the applications registered with the
+	// consensus backend.
+	//
+	// NOTE: This only works with the Tendermint consensus backend and will
+	//       otherwise return an error.
+	GetConsensusApplications(ctx context.Context) ([]*ConsensusApplication, error)
+}
+
+// ConsensusApplication is information about an application registered with
+// the consensus backend.
+type ConsensusApplication struct {
+	// Name is the application name.
+	Name string `json:"name"`
+	// ID is the unique application identifier used for transaction routing.
+	ID uint8 `json:"id"`
+	// EventType is the type of the events emitted by the application.
+	EventType string `json:"event_type"`
+	// Blessed is true iff the application is the blessed application.
+	Blessed bool `json:"blessed,omitempty"`
 }
@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	upgradeApi "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
@@ -29,6 +30,10 @@ var (
 	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodSetTagIndexerEnabled is the SetTagIndexerEnabled method.
+	methodSetTagIndexerEnabled = serviceName.NewMethod("SetTagIndexerEnabled", SetTagIndexerEnabledRequest{})
+	// methodCompactLocalStorage is the CompactLocalStorage method.
+	methodCompactLocalStorage = serviceName.NewMethod("CompactLocalStorage", common.Namespace{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -67,6 +72,14 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodSetTagIndexerEnabled.ShortName(),
+				Handler:    handlerSetTagIndexerEnabled,
+			},
+			{
+				MethodName: methodCompactLocalStorage.ShortName(),
+				Handler:    handlerCompactLocalStorage,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -236,6 +249,52 @@ func handlerGetStatus( // nolint: golint
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerSetTagIndexerEnabled( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req SetTagIndexerEnabledRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).SetTagIndexerEnabled(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSetTagIndexerEnabled.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).SetTagIndexerEnabled(ctx, req.(*SetTagIndexerEnabledRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerCompactLocalStorage( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).CompactLocalStorage(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodCompactLocalStorage.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).CompactLocalStorage(ctx, *req.(*common.Namespace))
+	}
+	return interceptor(ctx, &runtimeID, info, handler)
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -289,6 +348,14 @@ func (c *nodeControllerClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *nodeControllerClient) SetTagIndexerEnabled(ctx context.Context, request *SetTagIndexerEnabledRequest) error {
+	return c.conn.Invoke(ctx, methodSetTagIndexerEnabled.FullName(), request, nil)
+}
+
+func (c *nodeControllerClient) CompactLocalStorage(ctx context.Context, runtimeID common.Namespace) error {
+	return c.conn.Invoke(ctx, methodCompactLocalStorage.FullName(), runtimeID, nil)
+}
+
 // NewNodeControllerClient creates a new gRPC node controller client service.
 func NewNodeControllerClient(c *grpc.ClientConn) NodeController {
 	return &nodeControllerClient{c}
@@ -15,8 +15,12 @@ var (
 
 	// methodSetEpoch is the SetEpoch method.
 	methodSetEpoch = debugServiceName.NewMethod("SetEpoch", beacon.EpochTime(0))
+	// methodForceEpochTransition is the ForceEpochTransition method.
+	methodForceEpochTransition = debugServiceName.NewMethod("ForceEpochTransition", nil)
 	// methodWaitNodesRegistered is the WaitNodesRegistered method.
 	methodWaitNodesRegistered = debugServiceName.NewMethod("WaitNodesRegistered", int(0))
+	// methodGetConsensusApplications is the GetConsensusApplications method.
+	methodGetConsensusApplications = debugServiceName.NewMethod("GetConsensusApplications", nil)
 
 	// debugServiceDesc is the gRPC service descriptor.
 	debugServiceDesc = grpc.ServiceDesc{
@@ -27,10 +31,18 @@ var (
 				MethodName: methodSetEpoch.ShortName(),
 				Handler:    handlerSetEpoch,
 			},
+			{
+				MethodName: methodForceEpochTransition.ShortName(),
+				Handler:    handlerForceEpochTransition,
+			},
 			{
 				MethodName: methodWaitNodesRegistered.ShortName(),
 				Handler:    handlerWaitNodesRegistered,
 			},
+			{
+				MethodName: methodGetConsensusApplications.ShortName(),
+				Handler:    handlerGetConsensusApplications,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -59,6 +71,25 @@ func handlerSetEpoch( // nolint: golint
 	return interceptor(ctx, epoch, info, handler)
 }
 
+func handlerForceEpochTransition( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return nil, srv.(DebugController).ForceEpochTransition(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodForceEpochTransition.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(DebugController).ForceEpochTransition(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
 func handlerWaitNodesRegistered( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -82,6 +113,25 @@ func handlerWaitNodesRegistered( // nolint: golint
 	return interceptor(ctx, count, info, handler)
 }
 
+func handlerGetConsensusApplications( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(DebugController).GetConsensusApplications(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetConsensusApplications.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugController).GetConsensusApplications(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
 // RegisterDebugService registers a new debug controller service with the given gRPC server.
 func RegisterDebugService(server *grpc.Server, service DebugController) {
 	server.RegisterService(&debugServiceDesc, service)
@@ -95,10 +145,22 @@ func (c *debugControllerClient) SetEpoch(ctx context.Context, epoch beacon.Epoch
 	return c.conn.Invoke(ctx, methodSetEpoch.FullName(), epoch, nil)
 }
 
+func (c *debugControllerClient) ForceEpochTransition(ctx context.Context) error {
+	return c.conn.Invoke(ctx, methodForceEpochTransition.FullName(), nil, nil)
+}
+
 func (c *debugControllerClient) WaitNodesRegistered(ctx context.Context, count int) error {
 	return c.conn.Invoke(ctx, methodWaitNodesRegistered.FullName(), count, nil)
 }
 
+func (c *debugControllerClient) GetConsensusApplications(ctx context.Context) ([]*ConsensusApplication, error) {
+	var rsp []*ConsensusApplication
+	if err := c.conn.Invoke(ctx, methodGetConsensusApplications.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 // NewDebugControllerClient creates a new gRPC debug controller client service.
 func NewDebugControllerClient(c *grpc.ClientConn) DebugController {
 	return &debugControllerClient{c}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
@@ -119,6 +120,14 @@ func (c *nodeController) GetStatus(ctx context.Context) (*control.Status, error)
 	}, nil
 }
 
+func (c *nodeController) SetTagIndexerEnabled(ctx context.Context, request *control.SetTagIndexerEnabledRequest) error {
+	return c.node.SetTagIndexerEnabled(request.RuntimeID, request.Enabled)
+}
+
+func (c *nodeController) CompactLocalStorage(ctx context.Context, runtimeID common.Namespace) error {
+	return c.node.CompactLocalStorage(runtimeID)
+}
+
 // New creates a new oasis-node controller.
 func New(node control.ControlledNode, consensus consensus.Backend, upgrader upgrade.Backend) control.NodeController {
 	return &nodeController{
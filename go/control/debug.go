@@ -5,11 +5,13 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	tmAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	"github.com/oasisprotocol/oasis-core/go/control/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
 type debugController struct {
+	consensus  consensus.Backend
 	timeSource beacon.Backend
 	registry   registry.Backend
 }
@@ -23,6 +25,19 @@ func (c *debugController) SetEpoch(ctx context.Context, epoch beacon.EpochTime)
 	return mockTS.SetEpoch(ctx, epoch)
 }
 
+func (c *debugController) ForceEpochTransition(ctx context.Context) error {
+	mockTS, ok := c.timeSource.(beacon.SetableBackend)
+	if !ok {
+		return api.ErrIncompatibleBackend
+	}
+
+	epoch, err := mockTS.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return err
+	}
+	return mockTS.SetEpoch(ctx, epoch+1)
+}
+
 func (c *debugController) WaitNodesRegistered(ctx context.Context, count int) error {
 	ch, sub, err := c.registry.WatchNodes(ctx)
 	if err != nil {
@@ -62,9 +77,32 @@ Loop:
 	return nil
 }
 
+func (c *debugController) GetConsensusApplications(ctx context.Context) ([]*api.ConsensusApplication, error) {
+	tmBackend, ok := c.consensus.(tmAPI.Backend)
+	if !ok {
+		return nil, api.ErrIncompatibleBackend
+	}
+
+	apps, err := tmBackend.GetApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*api.ConsensusApplication, 0, len(apps))
+	for _, app := range apps {
+		result = append(result, &api.ConsensusApplication{
+			Name:      app.Name,
+			ID:        app.ID,
+			EventType: app.EventType,
+			Blessed:   app.Blessed,
+		})
+	}
+	return result, nil
+}
+
 // New creates a new oasis-node debug controller.
 func NewDebug(consensus consensus.Backend) api.DebugController {
 	return &debugController{
+		consensus:  consensus,
 		timeSource: consensus.Beacon(),
 		registry:   consensus.Registry(),
 	}
@@ -0,0 +1,96 @@
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	tmAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	"github.com/oasisprotocol/oasis-core/go/control/api"
+)
+
+type testBeacon struct {
+	beacon.Backend
+
+	epoch beacon.EpochTime
+}
+
+func (b *testBeacon) GetEpoch(ctx context.Context, height int64) (beacon.EpochTime, error) {
+	return b.epoch, nil
+}
+
+type testSetableBeacon struct {
+	testBeacon
+}
+
+func (b *testSetableBeacon) SetEpoch(ctx context.Context, epoch beacon.EpochTime) error {
+	b.epoch = epoch
+	return nil
+}
+
+func TestForceEpochTransition(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+
+	// A mock beacon backend should be advanced by one epoch.
+	mockTS := &testSetableBeacon{testBeacon{epoch: 41}}
+	ctrl := &debugController{timeSource: mockTS}
+	err := ctrl.ForceEpochTransition(ctx)
+	require.NoError(err, "ForceEpochTransition")
+	require.EqualValues(42, mockTS.epoch, "epoch should be advanced by one")
+
+	err = ctrl.ForceEpochTransition(ctx)
+	require.NoError(err, "ForceEpochTransition")
+	require.EqualValues(43, mockTS.epoch, "epoch should be advanced by one")
+
+	// Other beacon backends should not support forcing epoch transitions.
+	ts := &testBeacon{epoch: 41}
+	ctrl = &debugController{timeSource: ts}
+	err = ctrl.ForceEpochTransition(ctx)
+	require.ErrorIs(err, api.ErrIncompatibleBackend, "ForceEpochTransition should fail without a mock beacon")
+	require.EqualValues(41, ts.epoch, "epoch should not change")
+}
+
+type testTendermintBackend struct {
+	tmAPI.Backend
+
+	apps []*tmAPI.ApplicationInfo
+}
+
+func (b *testTendermintBackend) GetApplications(ctx context.Context) ([]*tmAPI.ApplicationInfo, error) {
+	return b.apps, nil
+}
+
+type testConsensusBackend struct {
+	consensus.Backend
+}
+
+func TestGetConsensusApplications(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+
+	// The Tendermint backend should return the registered applications.
+	tmBackend := &testTendermintBackend{
+		apps: []*tmAPI.ApplicationInfo{
+			{Name: "100_staking", ID: 0x05, EventType: "oasis-event-100_staking", Blessed: false},
+			{Name: "999_supplementarysanity", ID: 0xff, EventType: "oasis-event-999_supplementarysanity", Blessed: true},
+		},
+	}
+	ctrl := &debugController{consensus: tmBackend}
+	apps, err := ctrl.GetConsensusApplications(ctx)
+	require.NoError(err, "GetConsensusApplications")
+	require.Equal([]*api.ConsensusApplication{
+		{Name: "100_staking", ID: 0x05, EventType: "oasis-event-100_staking", Blessed: false},
+		{Name: "999_supplementarysanity", ID: 0xff, EventType: "oasis-event-999_supplementarysanity", Blessed: true},
+	}, apps, "consensus applications should be correct")
+
+	// Other consensus backends should not support listing applications.
+	ctrl = &debugController{consensus: &testConsensusBackend{}}
+	_, err = ctrl.GetConsensusApplications(ctx)
+	require.ErrorIs(err, api.ErrIncompatibleBackend, "GetConsensusApplications should fail without a Tendermint backend")
+}
@@ -2,11 +2,19 @@ package api
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 )
 
+const (
+	// DefaultMaxExtraDataEntrySize is the default maximum size of a single extra data entry.
+	DefaultMaxExtraDataEntrySize = 64 * 1024
+	// DefaultMaxExtraDataSize is the default maximum total size of all extra data entries.
+	DefaultMaxExtraDataSize = 1024 * 1024
+)
+
 // SanityCheck does basic sanity checking on the contents of the genesis document.
 func (d *Document) SanityCheck() error {
 	if d.Height < 1 {
@@ -55,3 +63,31 @@ func (d *Document) SanityCheck() error {
 
 	return nil
 }
+
+// SanityCheckExtraData checks that the size of each extra data entry (including its key) is
+// within maxEntrySize and that the total size of all entries is within maxTotalSize.
+//
+// This is not part of SanityCheck as the limits are configurable when creating new genesis
+// documents. Whenever extra data is loaded or carried over into a new document, it is checked
+// against DefaultMaxExtraDataEntrySize and DefaultMaxExtraDataSize.
+func (d *Document) SanityCheckExtraData(maxEntrySize, maxTotalSize uint64) error {
+	keys := make([]string, 0, len(d.ExtraData))
+	for k := range d.ExtraData {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var total uint64
+	for _, k := range keys {
+		size := uint64(len(k) + len(d.ExtraData[k]))
+		if size > maxEntrySize {
+			return fmt.Errorf("genesis: sanity check failed: extra data entry '%s' too large (%d > %d bytes)", k, size, maxEntrySize)
+		}
+		total += size
+		if total > maxTotalSize {
+			return fmt.Errorf("genesis: sanity check failed: extra data too large (exceeded %d bytes at entry '%s')", maxTotalSize, k)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+// DocumentSignatureContext is the context used for signing genesis documents.
+//
+// Note that chain domain separation is not used as the chain context is itself derived from the
+// genesis document.
+var DocumentSignatureContext = signature.NewContext("oasis-core/genesis: document")
+
+// SignedDocument is a detached signature over a genesis document.
+type SignedDocument struct {
+	// Hash is the hash of the signed genesis document.
+	Hash hash.Hash `json:"hash"`
+	// Signature is the signature over the genesis document hash.
+	Signature signature.Signature `json:"signature"`
+}
+
+// SignDocument produces a detached signature over the given genesis document.
+func SignDocument(signer signature.Signer, doc *Document) (*SignedDocument, error) {
+	h := doc.Hash()
+	sig, err := signature.Sign(signer, DocumentSignatureContext, h[:])
+	if err != nil {
+		return nil, fmt.Errorf("genesis: failed to sign document: %w", err)
+	}
+	return &SignedDocument{
+		Hash:      h,
+		Signature: *sig,
+	}, nil
+}
+
+// VerifyDocument verifies that the detached signature is a valid signature over the given genesis
+// document made by the expected signer.
+func VerifyDocument(signed *SignedDocument, doc *Document, expectedSigner signature.PublicKey) error {
+	if !signed.Signature.PublicKey.Equal(expectedSigner) {
+		return fmt.Errorf("genesis: document signed by unexpected signer %s", signed.Signature.PublicKey)
+	}
+	if h := doc.Hash(); !h.Equal(&signed.Hash) {
+		return fmt.Errorf("genesis: document hash mismatch (expected: %s actual: %s)", signed.Hash, h)
+	}
+	if !signed.Signature.Verify(DocumentSignatureContext, signed.Hash[:]) {
+		return fmt.Errorf("genesis: invalid document signature")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+func TestSignDocument(t *testing.T) {
+	require := require.New(t)
+
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	otherSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	doc := &Document{
+		Height:  1,
+		ChainID: "genesis signature test",
+	}
+
+	signed, err := SignDocument(signer, doc)
+	require.NoError(err, "SignDocument")
+	require.Equal(doc.Hash(), signed.Hash, "signed document hash should match")
+
+	err = VerifyDocument(signed, doc, signer.Public())
+	require.NoError(err, "VerifyDocument")
+
+	// Verification should fail for an unexpected signer.
+	err = VerifyDocument(signed, doc, otherSigner.Public())
+	require.Error(err, "VerifyDocument should fail for an unexpected signer")
+
+	// Verification should fail for a tampered document.
+	tampered := *doc
+	tampered.ChainID = "tampered"
+	err = VerifyDocument(signed, &tampered, signer.Public())
+	require.Error(err, "VerifyDocument should fail for a tampered document")
+
+	// Verification should fail for a tampered signature.
+	tamperedSigned := *signed
+	tamperedSigned.Hash = tampered.Hash()
+	err = VerifyDocument(&tamperedSigned, &tampered, signer.Public())
+	require.Error(err, "VerifyDocument should fail for a tampered signature")
+}
@@ -48,6 +48,9 @@ func NewFileProvider(filename string) (api.Provider, error) {
 	if err = doc.SanityCheck(); err != nil {
 		return nil, fmt.Errorf("genesis: bad genesis file: %w", err)
 	}
+	if err = doc.SanityCheckExtraData(api.DefaultMaxExtraDataEntrySize, api.DefaultMaxExtraDataSize); err != nil {
+		return nil, fmt.Errorf("genesis: bad genesis file: %w", err)
+	}
 
 	return &fileProvider{document: &doc}, nil
 }
@@ -3,7 +3,10 @@ package genesis
 import (
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -24,6 +27,7 @@ import (
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	tendermint "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
@@ -143,6 +147,34 @@ func TestGenesisChainContext(t *testing.T) {
 	require.Equal(t, "4f50ffb995638282d3b3637315bb22be1d3ff4162e0df206991ebc637cc34729", stableDoc.ChainContext())
 }
 
+func TestGenesisFileProviderExtraData(t *testing.T) {
+	viper.Set(cmdFlags.CfgDebugDontBlameOasis, true)
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-genesis-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	writeDoc := func(d *genesis.Document) string {
+		raw, cerr := d.CanonicalJSON()
+		require.NoError(cerr, "CanonicalJSON")
+		fn := filepath.Join(dataDir, "genesis.json")
+		require.NoError(ioutil.WriteFile(fn, raw, 0o600), "WriteFile")
+		return fn
+	}
+
+	d := testDoc()
+	d.ExtraData = map[string][]byte{
+		"quote": []byte("Verily, no cyclone or whirlwind is Zarathustra"),
+	}
+	_, err = genesisFile.NewFileProvider(writeDoc(&d))
+	require.NoError(err, "genesis file with small extra data should load")
+
+	d.ExtraData["oversized"] = make([]byte, genesis.DefaultMaxExtraDataEntrySize)
+	_, err = genesisFile.NewFileProvider(writeDoc(&d))
+	require.Error(err, "genesis file with oversized extra data should not load")
+}
+
 func TestGenesisSanityCheck(t *testing.T) {
 	viper.Set(cmdFlags.CfgDebugDontBlameOasis, true)
 	require := require.New(t)
@@ -306,6 +338,30 @@ func TestGenesisSanityCheck(t *testing.T) {
 	d.HaltEpoch = 5
 	require.Error(d.SanityCheck(), "halt epoch in the past should be invalid")
 
+	d = testDoc()
+	d.ExtraData = map[string][]byte{
+		"quote": []byte("Verily, no cyclone or whirlwind is Zarathustra"),
+	}
+	require.NoError(d.SanityCheck(), "small extra data should be valid")
+	require.NoError(d.SanityCheckExtraData(genesis.DefaultMaxExtraDataEntrySize, genesis.DefaultMaxExtraDataSize), "small extra data should be within bounds")
+
+	d.ExtraData["oversized"] = make([]byte, genesis.DefaultMaxExtraDataEntrySize)
+	require.NoError(d.SanityCheck(), "extra data bounds should not be enforced by the sanity check")
+	err := d.SanityCheckExtraData(genesis.DefaultMaxExtraDataEntrySize, genesis.DefaultMaxExtraDataSize)
+	require.Error(err, "oversized extra data entry should be invalid")
+	require.Contains(err.Error(), "oversized", "error should name the offending entry")
+
+	d = testDoc()
+	d.ExtraData = make(map[string][]byte)
+	for i := 0; i < genesis.DefaultMaxExtraDataSize/genesis.DefaultMaxExtraDataEntrySize+1; i++ {
+		d.ExtraData[fmt.Sprintf("entry%d", i)] = make([]byte, genesis.DefaultMaxExtraDataEntrySize/2)
+	}
+	require.NoError(d.SanityCheckExtraData(genesis.DefaultMaxExtraDataEntrySize, 2*genesis.DefaultMaxExtraDataSize), "extra data within custom bounds should be valid")
+	for i := 0; i < genesis.DefaultMaxExtraDataSize/genesis.DefaultMaxExtraDataEntrySize+1; i++ {
+		d.ExtraData[fmt.Sprintf("entry%d", i)] = make([]byte, genesis.DefaultMaxExtraDataEntrySize-16)
+	}
+	require.Error(d.SanityCheckExtraData(genesis.DefaultMaxExtraDataEntrySize, genesis.DefaultMaxExtraDataSize), "oversized extra data should be invalid")
+
 	// Test consensus genesis checks.
 	d = testDoc()
 	d.Consensus.Parameters.TimeoutCommit = 0
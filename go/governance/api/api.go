@@ -2,17 +2,20 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
@@ -54,6 +57,7 @@ var (
 	_ prettyprint.PrettyPrinter = (*ProposalContent)(nil)
 	_ prettyprint.PrettyPrinter = (*UpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*CancelUpgradeProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*UpdateRuntimeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ProposalVote)(nil)
 )
 
@@ -61,18 +65,35 @@ var (
 type ProposalContent struct {
 	Upgrade       *UpgradeProposal       `json:"upgrade,omitempty"`
 	CancelUpgrade *CancelUpgradeProposal `json:"cancel_upgrade,omitempty"`
+	UpdateRuntime *UpdateRuntimeProposal `json:"update_runtime,omitempty"`
+}
+
+func (p *ProposalContent) numFieldsSet() int {
+	var n int
+	if p.Upgrade != nil {
+		n++
+	}
+	if p.CancelUpgrade != nil {
+		n++
+	}
+	if p.UpdateRuntime != nil {
+		n++
+	}
+	return n
 }
 
 // ValidateBasic performs basic proposal content validity checks.
 func (p *ProposalContent) ValidateBasic() error {
 	switch {
-	case p.Upgrade != nil && p.CancelUpgrade != nil:
+	case p.numFieldsSet() > 1:
 		return fmt.Errorf("proposal content has multiple fields set")
 	case p.Upgrade != nil:
 		return p.Upgrade.ValidateBasic()
 	case p.CancelUpgrade != nil:
 		// No validation at this time.
 		return nil
+	case p.UpdateRuntime != nil:
+		return p.UpdateRuntime.Descriptor.ValidateBasic(true)
 	default:
 		return fmt.Errorf("proposal content has no fields set")
 	}
@@ -88,6 +109,8 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 		return p.CancelUpgrade.ProposalID == other.CancelUpgrade.ProposalID
 	case p.Upgrade != nil && other.Upgrade != nil:
 		return p.Upgrade.Descriptor.Equals(&other.Upgrade.Descriptor)
+	case p.UpdateRuntime != nil && other.UpdateRuntime != nil:
+		return bytes.Equal(cbor.Marshal(p.UpdateRuntime.Descriptor), cbor.Marshal(other.UpdateRuntime.Descriptor))
 	default:
 		return false
 	}
@@ -97,12 +120,17 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 // given writer.
 func (p ProposalContent) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
 	switch {
-	case p.Upgrade != nil && p.CancelUpgrade == nil:
+	case p.numFieldsSet() > 1:
+		fmt.Fprintf(w, "%s%s\n", prefix, ProposalContentInvalidText)
+	case p.Upgrade != nil:
 		fmt.Fprintf(w, "%sUpgrade:\n", prefix)
 		p.Upgrade.PrettyPrint(ctx, prefix+"  ", w)
-	case p.CancelUpgrade != nil && p.Upgrade == nil:
+	case p.CancelUpgrade != nil:
 		fmt.Fprintf(w, "%sCancel Upgrade:\n", prefix)
 		p.CancelUpgrade.PrettyPrint(ctx, prefix+"  ", w)
+	case p.UpdateRuntime != nil:
+		fmt.Fprintf(w, "%sUpdate Runtime:\n", prefix)
+		p.UpdateRuntime.PrettyPrint(ctx, prefix+"  ", w)
 	default:
 		fmt.Fprintf(w, "%s%s\n", prefix, ProposalContentInvalidText)
 	}
@@ -149,6 +177,27 @@ func (cu CancelUpgradeProposal) PrettyType() (interface{}, error) {
 	return cu, nil
 }
 
+// UpdateRuntimeProposal is a proposal to update the descriptor of a runtime
+// that uses the consensus layer governance model.
+type UpdateRuntimeProposal struct {
+	// Descriptor is the updated runtime descriptor.
+	Descriptor registry.Runtime `json:"descriptor"`
+}
+
+// PrettyPrint writes a pretty-printed representation of UpdateRuntimeProposal
+// to the given writer.
+func (ur UpdateRuntimeProposal) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sRuntime ID: %s\n", prefix, ur.Descriptor.ID)
+	fmt.Fprintf(w, "%sKind:       %s\n", prefix, ur.Descriptor.Kind)
+	fmt.Fprintf(w, "%sVersion:    %s\n", prefix, ur.Descriptor.Version.Version)
+}
+
+// PrettyType returns a representation of UpdateRuntimeProposal that can be
+// used for pretty printing.
+func (ur UpdateRuntimeProposal) PrettyType() (interface{}, error) {
+	return ur, nil
+}
+
 // ProposalVote is a vote for a proposal.
 type ProposalVote struct {
 	// ID is the unique identifier of a proposal.
@@ -259,6 +308,9 @@ type ConsensusParameters struct {
 	// UpgradeCancelMinEpochDiff is the minimum number of epochs between the current
 	// epoch and the proposed upgrade epoch for the upgrade cancellation proposal to be valid.
 	UpgradeCancelMinEpochDiff beacon.EpochTime `json:"upgrade_cancel_min_epoch_diff,omitempty"`
+
+	// EnableUpdateRuntimeProposals is true iff runtime update proposals are allowed.
+	EnableUpdateRuntimeProposals bool `json:"enable_update_runtime_proposals,omitempty"`
 }
 
 // Event signifies a governance event, returned via GetEvents.
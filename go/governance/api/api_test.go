@@ -7,8 +7,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
@@ -60,6 +62,21 @@ func TestValidateBasic(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			msg: "only one of Upgrade/UpdateRuntime fields should be set",
+			p: &ProposalContent{
+				Upgrade:       &UpgradeProposal{},
+				UpdateRuntime: &UpdateRuntimeProposal{},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "update runtime with invalid runtime descriptor should fail",
+			p: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{},
+			},
+			shouldErr: true,
+		},
 	} {
 		err := tc.p.ValidateBasic()
 		if tc.shouldErr {
@@ -137,6 +154,34 @@ func TestProposalContentEquals(t *testing.T) {
 			},
 			equals: false,
 		},
+		{
+			msg: "update runtime proposals should be equal",
+			p1: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{
+					Descriptor: registry.Runtime{ID: common.NewTestNamespaceFromSeed([]byte("test"), 0)},
+				},
+			},
+			p2: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{
+					Descriptor: registry.Runtime{ID: common.NewTestNamespaceFromSeed([]byte("test"), 0)},
+				},
+			},
+			equals: true,
+		},
+		{
+			msg: "update runtime proposals should not be equal",
+			p1: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{
+					Descriptor: registry.Runtime{ID: common.NewTestNamespaceFromSeed([]byte("test"), 0)},
+				},
+			},
+			p2: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{
+					Descriptor: registry.Runtime{ID: common.NewTestNamespaceFromSeed([]byte("test2"), 0)},
+				},
+			},
+			equals: false,
+		},
 	} {
 		require.Equal(t, tc.equals, tc.p1.Equals(tc.p2), tc.msg)
 	}
@@ -161,6 +206,14 @@ func TestProposalContentPrettyPrint(t *testing.T) {
 				CancelUpgrade: &CancelUpgradeProposal{ProposalID: 42},
 			},
 		},
+		{
+			expRegex: "^Update Runtime:",
+			p: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{
+					Descriptor: registry.Runtime{ID: common.NewTestNamespaceFromSeed([]byte("test"), 0)},
+				},
+			},
+		},
 		{
 			expRegex: ProposalContentInvalidText,
 			p:        &ProposalContent{},
@@ -28,6 +28,10 @@ const (
 	// keys.
 	CfgDebugAllowTestKeys = "debug.allow_test_keys"
 
+	// CfgDebugSignatureContextDiagnostics is the command line flag to enable
+	// signature context mismatch diagnostics.
+	CfgDebugSignatureContextDiagnostics = "debug.signature_context_diagnostics"
+
 	// CfgDebugRlimit is the command flag to set RLIMIT_NOFILE on launch.
 	CfgDebugRlimit = "debug.rlimit"
 
@@ -45,6 +49,8 @@ var (
 	debugAllowTestKeysFlag = flag.NewFlagSet("", flag.ContinueOnError)
 	debugRlimitFlag        = flag.NewFlagSet("", flag.ContinueOnError)
 
+	debugSignatureContextDiagnosticsFlag = flag.NewFlagSet("", flag.ContinueOnError)
+
 	// RootFlags has the flags that are common across all commands.
 	RootFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
@@ -93,6 +99,7 @@ func Init() error {
 		initDataDir,
 		initLogging,
 		initPublicKeyBlacklist,
+		initSignatureContextDiagnostics,
 		initRlimit,
 	}
 
@@ -123,6 +130,10 @@ func init() {
 	_ = debugRlimitFlag.MarkHidden(CfgDebugRlimit)
 	_ = viper.BindPFlags(debugRlimitFlag)
 
+	debugSignatureContextDiagnosticsFlag.Bool(CfgDebugSignatureContextDiagnostics, false, "enable signature context mismatch diagnostics (UNSAFE)")
+	_ = debugSignatureContextDiagnosticsFlag.MarkHidden(CfgDebugSignatureContextDiagnostics)
+	_ = viper.BindPFlags(debugSignatureContextDiagnosticsFlag)
+
 	RootFlags.StringVar(&cfgFile, CfgConfigFile, "", "config file")
 	RootFlags.String(CfgDataDir, "", "data directory")
 	_ = viper.BindPFlags(RootFlags)
@@ -130,6 +141,7 @@ func init() {
 	RootFlags.AddFlagSet(loggingFlags)
 	RootFlags.AddFlagSet(debugAllowTestKeysFlag)
 	RootFlags.AddFlagSet(debugRlimitFlag)
+	RootFlags.AddFlagSet(debugSignatureContextDiagnosticsFlag)
 	RootFlags.AddFlagSet(flags.DebugDontBlameOasisFlag)
 }
 
@@ -193,6 +205,12 @@ func initPublicKeyBlacklist() error {
 	return nil
 }
 
+func initSignatureContextDiagnostics() error {
+	enabled := flags.DebugDontBlameOasis() && viper.GetBool(CfgDebugSignatureContextDiagnostics)
+	signature.SetContextMismatchDiagnostics(enabled)
+	return nil
+}
+
 func initRlimit() error {
 	// Suppress this for tooling, as it likely does not matter.
 	if !IsNodeCmd() {
@@ -58,6 +58,7 @@ func NewServerTCP(cert *tls.Certificate, installWrapper bool) (*cmnGrpc.Server,
 		Port:           uint16(viper.GetInt(CfgServerPort)),
 		Identity:       &identity.Identity{},
 		InstallWrapper: installWrapper,
+		DefaultTimeout: viper.GetDuration(cmnGrpc.CfgServerDefaultTimeout),
 	}
 	config.Identity.SetTLSCertificate(cert)
 	return cmnGrpc.NewServer(config)
@@ -83,6 +84,7 @@ func NewServerLocal(installWrapper bool) (*cmnGrpc.Server, error) {
 		Name:           "internal",
 		Path:           path,
 		InstallWrapper: installWrapper,
+		DefaultTimeout: viper.GetDuration(cmnGrpc.CfgServerDefaultTimeout),
 	}
 
 	return cmnGrpc.NewServer(config)
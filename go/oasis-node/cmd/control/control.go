@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -63,6 +65,20 @@ var (
 		Run:   doStatus,
 	}
 
+	controlSetTagIndexerCmd = &cobra.Command{
+		Use:   "set-tag-indexer <runtime-id> <enabled>",
+		Short: "enable or disable the tag indexer for a runtime",
+		Args:  cobra.ExactArgs(2),
+		Run:   doSetTagIndexer,
+	}
+
+	controlCompactLocalStorageCmd = &cobra.Command{
+		Use:   "compact-local-storage <runtime-id>",
+		Short: "compact the local storage database of a runtime",
+		Args:  cobra.ExactArgs(1),
+		Run:   doCompactLocalStorage,
+	}
+
 	logger = logging.GetLogger("cmd/control")
 )
 
@@ -230,6 +246,57 @@ func doStatus(cmd *cobra.Command, args []string) {
 	fmt.Println(string(formatted))
 }
 
+func doSetTagIndexer(cmd *cobra.Command, args []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(args[0]); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	enabled, err := strconv.ParseBool(args[1])
+	if err != nil {
+		logger.Error("malformed enabled flag",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	err = client.SetTagIndexerEnabled(context.Background(), &control.SetTagIndexerEnabledRequest{
+		RuntimeID: runtimeID,
+		Enabled:   enabled,
+	})
+	if err != nil {
+		logger.Error("failed to set tag indexer status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func doCompactLocalStorage(cmd *cobra.Command, args []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(args[0]); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.CompactLocalStorage(context.Background(), runtimeID); err != nil {
+		logger.Error("failed to compact local storage",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 // Register registers the client sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	controlCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
@@ -242,5 +309,7 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlUpgradeBinaryCmd)
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
 	controlCmd.AddCommand(controlStatusCmd)
+	controlCmd.AddCommand(controlSetTagIndexerCmd)
+	controlCmd.AddCommand(controlCompactLocalStorageCmd)
 	parentCmd.AddCommand(controlCmd)
 }
@@ -3,6 +3,8 @@ package control
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -31,6 +33,12 @@ var (
 		Run:   doSetEpoch,
 	}
 
+	controlForceEpochTransitionCmd = &cobra.Command{
+		Use:   "force-epoch-transition",
+		Short: "advance mock epochtime by one epoch",
+		Run:   doForceEpochTransition,
+	}
+
 	controlWaitNodesCmd = &cobra.Command{
 		Use:   "wait-nodes",
 		Short: "wait for specific number of nodes to register",
@@ -45,6 +53,12 @@ var (
 		Run: doWaitReady,
 	}
 
+	controlConsensusAppsCmd = &cobra.Command{
+		Use:   "consensus-apps",
+		Short: "list the registered consensus applications",
+		Run:   doConsensusApps,
+	}
+
 	logger = logging.GetLogger("cmd/debug/control")
 )
 
@@ -81,6 +95,20 @@ func doSetEpoch(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doForceEpochTransition(cmd *cobra.Command, args []string) {
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	logger.Info("forcing epoch transition")
+
+	if err := client.ForceEpochTransition(context.Background()); err != nil {
+		logger.Error("failed to force epoch transition",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 func doWaitNodes(cmd *cobra.Command, args []string) {
 	conn, client := doConnect(cmd)
 	defer conn.Close()
@@ -115,6 +143,27 @@ func doWaitReady(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doConsensusApps(cmd *cobra.Command, args []string) {
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	apps, err := client.GetConsensusApplications(context.Background())
+	if err != nil {
+		logger.Error("failed to query consensus applications",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	formatted, err := json.MarshalIndent(apps, "", "  ")
+	if err != nil {
+		logger.Error("failed to format consensus applications",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(formatted))
+}
+
 // Register registers the dummy sub-command and all of its children.
 func Register(parentCmd *cobra.Command) {
 	controlCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
@@ -122,7 +171,9 @@ func Register(parentCmd *cobra.Command) {
 	controlWaitNodesCmd.Flags().IntVarP(&nodes, "nodes", "n", 1, "number of nodes to wait for")
 
 	controlCmd.AddCommand(controlSetEpochCmd)
+	controlCmd.AddCommand(controlForceEpochTransitionCmd)
 	controlCmd.AddCommand(controlWaitNodesCmd)
 	controlCmd.AddCommand(controlWaitReadyCmd)
+	controlCmd.AddCommand(controlConsensusAppsCmd)
 	parentCmd.AddCommand(controlCmd)
 }
@@ -3,6 +3,7 @@ package dumpdb
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"github.com/spf13/viper"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci"
@@ -46,6 +48,8 @@ const (
 	cfgDumpOutput     = "dump.output"
 	cfgDumpReadOnlyDB = "dump.read_only_db"
 	cfgDumpVersion    = "dump.version"
+	cfgDumpKey        = "dump.key"
+	cfgDumpKeyOutput  = "dump.key_output"
 )
 
 var (
@@ -78,23 +82,6 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Load the old genesis document, required for filling in parameters
-	// that are not persisted to ABCI state.
-	fp, err := genesisFile.NewFileProvider(flags.GenesisFile())
-	if err != nil {
-		logger.Error("failed to load existing genesis document",
-			"err", err,
-		)
-		return
-	}
-	oldDoc, err := fp.GetGenesisDocument()
-	if err != nil {
-		logger.Error("failed to get existing genesis document",
-			"err", err,
-		)
-		return
-	}
-
 	// Initialize the ABCI state storage for access.
 	//
 	// Note: While it would be great to always use read-only DB access,
@@ -133,6 +120,29 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Dump a single state value if requested.
+	if key := viper.GetString(cfgDumpKey); key != "" {
+		ok = doDumpKey(ctx, cmd, ldb, dumpVersion, key)
+		return
+	}
+
+	// Load the old genesis document, required for filling in parameters
+	// that are not persisted to ABCI state.
+	fp, err := genesisFile.NewFileProvider(flags.GenesisFile())
+	if err != nil {
+		logger.Error("failed to load existing genesis document",
+			"err", err,
+		)
+		return
+	}
+	oldDoc, err := fp.GetGenesisDocument()
+	if err != nil {
+		logger.Error("failed to get existing genesis document",
+			"err", err,
+		)
+		return
+	}
+
 	// Generate the dump by querying all of the relevant backends, and
 	// extracting the immutable parameters from the current genesis
 	// document.
@@ -239,6 +249,14 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	}
 	doc.Consensus = *consensusSt
 
+	// Make sure that the extra data carried over from the old genesis document is within bounds.
+	if err = doc.SanityCheckExtraData(genesis.DefaultMaxExtraDataEntrySize, genesis.DefaultMaxExtraDataSize); err != nil {
+		logger.Error("state dump extra data failed sanity check",
+			"err", err,
+		)
+		return
+	}
+
 	logger.Info("writing state dump",
 		"output", viper.GetString(cfgDumpOutput),
 	)
@@ -271,6 +289,70 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	ok = true
 }
 
+func doDumpKey(ctx context.Context, cmd *cobra.Command, ldb storage.LocalBackend, version int64, key string) bool {
+	rawKey, err := hex.DecodeString(key)
+	if err != nil {
+		logger.Error("malformed state key",
+			"err", err,
+		)
+		return false
+	}
+
+	qs := &dumpQueryState{
+		ldb:    ldb,
+		height: version,
+	}
+	state, err := tendermintAPI.NewImmutableState(ctx, qs, version)
+	if err != nil {
+		logger.Error("failed to get ABCI state",
+			"err", err,
+		)
+		return false
+	}
+	defer state.Close()
+
+	value, err := state.Get(ctx, rawKey)
+	if err != nil {
+		logger.Error("failed to get state value",
+			"err", err,
+		)
+		return false
+	}
+	if value == nil {
+		logger.Error("state key does not exist",
+			"key", key,
+			"version", version,
+		)
+		return false
+	}
+
+	raw, err := cbor.ToJSON(value)
+	if err != nil {
+		logger.Error("failed to transcode state value into JSON",
+			"err", err,
+		)
+		return false
+	}
+
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgDumpKeyOutput)
+	if err != nil {
+		logger.Error("failed to get output writer for state value",
+			"err", err,
+		)
+		return false
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+	if _, err = w.Write(raw); err != nil {
+		logger.Error("failed to write state value",
+			"err", err,
+		)
+		return false
+	}
+	return true
+}
+
 func dumpRegistry(ctx context.Context, qs *dumpQueryState) (*registry.Genesis, error) {
 	qf := registryApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
@@ -418,5 +500,7 @@ func init() {
 	dumpDBFlags.String(cfgDumpOutput, "dump.json", "path to dumped ABCI state")
 	dumpDBFlags.Bool(cfgDumpReadOnlyDB, false, "read-only DB access")
 	dumpDBFlags.Int64(cfgDumpVersion, 0, "ABCI state version to dump (0 = most recent)")
+	dumpDBFlags.String(cfgDumpKey, "", "hex-encoded ABCI state key to dump as JSON instead of the full state")
+	dumpDBFlags.String(cfgDumpKeyOutput, "", "path to dumped ABCI state value (default: stdout)")
 	_ = viper.BindPFlags(dumpDBFlags)
 }
@@ -215,6 +215,11 @@ func doFixGenesis(cmd *cobra.Command, args []string) {
 			"err", err,
 		)
 	}
+	if err = newDoc.SanityCheckExtraData(genesis.DefaultMaxExtraDataEntrySize, genesis.DefaultMaxExtraDataSize); err != nil {
+		logger.Warn("new genesis document extra data sanity check failed",
+			"err", err,
+		)
+	}
 
 	// Write out the new genesis document.
 	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgNewGenesis)
@@ -56,6 +56,10 @@ const (
 	cfgHaltEpoch     = "halt.epoch"
 	cfgInitialHeight = "initial_height"
 
+	// Extra data config flags.
+	cfgExtraDataMaxEntrySize = "extra_data.max_entry_size"
+	cfgExtraDataMaxSize      = "extra_data.max_size"
+
 	// Registry config flags.
 	CfgRegistryMaxNodeExpiration             = "registry.max_node_expiration"
 	CfgRegistryDisableRuntimeRegistration    = "registry.disable_runtime_registration"
@@ -63,6 +67,7 @@ const (
 	CfgRegistryDebugAllowTestRuntimes        = "registry.debug.allow_test_runtimes"
 	cfgRegistryDebugBypassStake              = "registry.debug.bypass_stake" // nolint: gosec
 	cfgRegistryEnableRuntimeGovernanceModels = "registry.enable_runtime_governance_models"
+	cfgRegistryEnableNodeListHash            = "registry.enable_node_list_hash"
 
 	// Scheduler config flags.
 	cfgSchedulerMinValidators          = "scheduler.min_validators"
@@ -70,14 +75,16 @@ const (
 	cfgSchedulerMaxValidatorsPerEntity = "scheduler.max_validators_per_entity"
 	cfgSchedulerDebugBypassStake       = "scheduler.debug.bypass_stake" // nolint: gosec
 	cfgSchedulerDebugStaticValidators  = "scheduler.debug.static_validators"
+	cfgSchedulerEnforceAdmissionPolicy = "scheduler.enforce_admission_policy"
 
 	// Governance config flags.
-	CfgGovernanceMinProposalDeposit        = "governance.min_proposal_deposit"
-	CfgGovernanceQuorum                    = "governance.quorum"
-	CfgGovernanceThreshold                 = "governance.threshold"
-	CfgGovernanceUpgradeCancelMinEpochDiff = "governance.upgrade_cancel_min_epoch_diff"
-	CfgGovernanceUpgradeMinEpochDiff       = "governance.upgrade_min_epoch_diff"
-	CfgGovernanceVotingPeriod              = "governance.voting_period"
+	CfgGovernanceMinProposalDeposit           = "governance.min_proposal_deposit"
+	CfgGovernanceQuorum                       = "governance.quorum"
+	CfgGovernanceThreshold                    = "governance.threshold"
+	CfgGovernanceUpgradeCancelMinEpochDiff    = "governance.upgrade_cancel_min_epoch_diff"
+	CfgGovernanceUpgradeMinEpochDiff          = "governance.upgrade_min_epoch_diff"
+	CfgGovernanceVotingPeriod                 = "governance.voting_period"
+	CfgGovernanceEnableUpdateRuntimeProposals = "governance.enable_update_runtime_proposals"
 
 	// Beacon config flags.
 	CfgBeaconBackend                    = "beacon.backend"
@@ -124,6 +131,7 @@ const (
 
 var (
 	checkGenesisFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	hashGenesisFlags  = flag.NewFlagSet("", flag.ContinueOnError)
 	dumpGenesisFlags  = flag.NewFlagSet("", flag.ContinueOnError)
 	initGenesisFlags  = flag.NewFlagSet("", flag.ContinueOnError)
 
@@ -150,6 +158,12 @@ var (
 		Run:   doCheckGenesis,
 	}
 
+	hashGenesisCmd = &cobra.Command{
+		Use:   "hash",
+		Short: "print the genesis document's canonical hash",
+		Run:   doHashGenesis,
+	}
+
 	logger = logging.GetLogger("cmd/genesis")
 )
 
@@ -225,18 +239,20 @@ func doInitGenesis(cmd *cobra.Command, args []string) {
 			MaxValidatorsPerEntity: viper.GetInt(cfgSchedulerMaxValidatorsPerEntity),
 			DebugBypassStake:       viper.GetBool(cfgSchedulerDebugBypassStake),
 			DebugStaticValidators:  viper.GetBool(cfgSchedulerDebugStaticValidators),
+			EnforceAdmissionPolicy: viper.GetBool(cfgSchedulerEnforceAdmissionPolicy),
 		},
 	}
 
 	doc.Governance = governance.Genesis{
 		Parameters: governance.ConsensusParameters{
-			GasCosts:                  governance.DefaultGasCosts, // TODO: configurable.
-			MinProposalDeposit:        *quantity.NewFromUint64(viper.GetUint64(CfgGovernanceMinProposalDeposit)),
-			Quorum:                    uint8(viper.GetInt(CfgGovernanceQuorum)),
-			Threshold:                 uint8(viper.GetInt(CfgGovernanceThreshold)),
-			UpgradeCancelMinEpochDiff: beacon.EpochTime(viper.GetUint64(CfgGovernanceUpgradeCancelMinEpochDiff)),
-			UpgradeMinEpochDiff:       beacon.EpochTime(viper.GetUint64(CfgGovernanceUpgradeMinEpochDiff)),
-			VotingPeriod:              beacon.EpochTime(viper.GetUint64(CfgGovernanceVotingPeriod)),
+			GasCosts:                     governance.DefaultGasCosts, // TODO: configurable.
+			MinProposalDeposit:           *quantity.NewFromUint64(viper.GetUint64(CfgGovernanceMinProposalDeposit)),
+			Quorum:                       uint8(viper.GetInt(CfgGovernanceQuorum)),
+			Threshold:                    uint8(viper.GetInt(CfgGovernanceThreshold)),
+			UpgradeCancelMinEpochDiff:    beacon.EpochTime(viper.GetUint64(CfgGovernanceUpgradeCancelMinEpochDiff)),
+			UpgradeMinEpochDiff:          beacon.EpochTime(viper.GetUint64(CfgGovernanceUpgradeMinEpochDiff)),
+			VotingPeriod:                 beacon.EpochTime(viper.GetUint64(CfgGovernanceVotingPeriod)),
+			EnableUpdateRuntimeProposals: viper.GetBool(CfgGovernanceEnableUpdateRuntimeProposals),
 		},
 	}
 
@@ -307,6 +323,15 @@ func doInitGenesis(cmd *cobra.Command, args []string) {
 		)
 		return
 	}
+	if err := doc.SanityCheckExtraData(
+		uint64(viper.GetSizeInBytes(cfgExtraDataMaxEntrySize)),
+		uint64(viper.GetSizeInBytes(cfgExtraDataMaxSize)),
+	); err != nil {
+		logger.Error("genesis document failed sanity check",
+			"err", err,
+		)
+		return
+	}
 
 	canonJSON, err := doc.CanonicalJSON()
 	if err != nil {
@@ -337,6 +362,7 @@ func AppendRegistryState(doc *genesis.Document, entities, runtimes, nodes []stri
 			MaxNodeExpiration:             viper.GetUint64(CfgRegistryMaxNodeExpiration),
 			DisableRuntimeRegistration:    viper.GetBool(CfgRegistryDisableRuntimeRegistration),
 			EnableRuntimeGovernanceModels: make(map[registry.RuntimeGovernanceModel]bool),
+			EnableNodeListHash:            viper.GetBool(cfgRegistryEnableNodeListHash),
 		},
 		Entities: make([]*entity.SignedEntity, 0, len(entities)),
 		Runtimes: make([]*registry.Runtime, 0, len(runtimes)),
@@ -716,17 +742,38 @@ func doCheckGenesis(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doHashGenesis(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	provider, err := genesisFile.NewFileProvider(flags.GenesisFile())
+	if err != nil {
+		logger.Error("failed to open genesis file", "err", err)
+		os.Exit(1)
+	}
+	doc, err := provider.GetGenesisDocument()
+	if err != nil {
+		logger.Error("failed to get genesis document", "err", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(doc.Hash())
+}
+
 // Register registers the genesis sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	initGenesisCmd.Flags().AddFlagSet(initGenesisFlags)
 	dumpGenesisCmd.Flags().AddFlagSet(dumpGenesisFlags)
 	dumpGenesisCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
 	checkGenesisCmd.Flags().AddFlagSet(checkGenesisFlags)
+	hashGenesisCmd.Flags().AddFlagSet(hashGenesisFlags)
 
 	for _, v := range []*cobra.Command{
 		initGenesisCmd,
 		dumpGenesisCmd,
 		checkGenesisCmd,
+		hashGenesisCmd,
 	} {
 		genesisCmd.AddCommand(v)
 	}
@@ -738,6 +785,9 @@ func init() {
 	_ = viper.BindPFlags(checkGenesisFlags)
 	checkGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
 
+	_ = viper.BindPFlags(hashGenesisFlags)
+	hashGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
+
 	dumpGenesisFlags.Int64(cfgBlockHeight, consensus.HeightLatest, "block height at which to dump state")
 	_ = viper.BindPFlags(dumpGenesisFlags)
 	dumpGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
@@ -750,6 +800,8 @@ func init() {
 	initGenesisFlags.String(cfgChainID, "", "genesis chain id")
 	initGenesisFlags.Uint64(cfgHaltEpoch, math.MaxUint64, "genesis halt epoch height")
 	initGenesisFlags.Int64(cfgInitialHeight, 1, "initial block height")
+	initGenesisFlags.String(cfgExtraDataMaxEntrySize, strconv.Itoa(genesis.DefaultMaxExtraDataEntrySize), "maximum size of a single genesis extra data entry (in bytes)")
+	initGenesisFlags.String(cfgExtraDataMaxSize, strconv.Itoa(genesis.DefaultMaxExtraDataSize), "maximum total size of genesis extra data (in bytes)")
 
 	// Registry config flags.
 	initGenesisFlags.Uint64(CfgRegistryMaxNodeExpiration, 5, "maximum node registration lifespan in epochs")
@@ -758,6 +810,7 @@ func init() {
 	initGenesisFlags.Bool(CfgRegistryDebugAllowTestRuntimes, false, "enable test runtime registration")
 	initGenesisFlags.Bool(cfgRegistryDebugBypassStake, false, "bypass all stake checks and operations (UNSAFE)")
 	initGenesisFlags.StringSlice(cfgRegistryEnableRuntimeGovernanceModels, []string{"entity"}, "set of enabled runtime governance models")
+	initGenesisFlags.Bool(cfgRegistryEnableNodeListHash, false, "commit to the node list hash at the start of each epoch")
 	_ = initGenesisFlags.MarkHidden(cfgRegistryDebugAllowUnroutableAddresses)
 	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowTestRuntimes)
 	_ = initGenesisFlags.MarkHidden(cfgRegistryDebugBypassStake)
@@ -768,6 +821,7 @@ func init() {
 	initGenesisFlags.Int(cfgSchedulerMaxValidatorsPerEntity, 1, "maximum number of validators per entity")
 	initGenesisFlags.Bool(cfgSchedulerDebugBypassStake, false, "bypass all stake checks and operations (UNSAFE)")
 	initGenesisFlags.Bool(cfgSchedulerDebugStaticValidators, false, "bypass all validator elections (UNSAFE)")
+	initGenesisFlags.Bool(cfgSchedulerEnforceAdmissionPolicy, false, "enforce the runtime admission policy when electing committees")
 	_ = initGenesisFlags.MarkHidden(cfgSchedulerDebugBypassStake)
 	_ = initGenesisFlags.MarkHidden(cfgSchedulerDebugStaticValidators)
 
@@ -778,6 +832,7 @@ func init() {
 	initGenesisFlags.Uint64(CfgGovernanceUpgradeCancelMinEpochDiff, 300, "minimum number of epochs in advance for canceling proposals")
 	initGenesisFlags.Uint64(CfgGovernanceUpgradeMinEpochDiff, 300, "minimum number of epochs the upgrade needs to be scheduled in advance")
 	initGenesisFlags.Uint64(CfgGovernanceVotingPeriod, 100, "voting period (in epochs)")
+	initGenesisFlags.Bool(CfgGovernanceEnableUpdateRuntimeProposals, false, "allow runtime update proposals")
 
 	// Beacon config flags.
 	initGenesisFlags.String(CfgBeaconBackend, "insecure", "beacon backend")
@@ -23,12 +23,14 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
 const (
 	cfgProposalCancelUpgradeID   = "proposal.cancel_upgrade.id"
 	cfgProposalUpgradeDescriptor = "proposal.upgrade.descriptor"
+	cfgProposalUpdateRuntime     = "proposal.update_runtime.descriptor"
 
 	cfgVote           = "vote"
 	cfgVoteProposalID = "vote.proposal.id"
@@ -143,9 +145,39 @@ func doGenSubmitProposal(cmd *cobra.Command, args []string) {
 				ProposalID: viper.GetUint64(cfgProposalCancelUpgradeID),
 			},
 		})
+	// Runtime descriptor update.
+	case viper.GetString(cfgProposalUpdateRuntime) != "":
+		descriptorBytes, err := ioutil.ReadFile(viper.GetString(cfgProposalUpdateRuntime))
+		if err != nil {
+			logger.Error("failed to read runtime descriptor",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		var rt registry.Runtime
+		if err = json.Unmarshal(descriptorBytes, &rt); err != nil {
+			logger.Error("can't parse runtime descriptor",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		if err = rt.ValidateBasic(true); err != nil {
+			logger.Error("submitted runtime descriptor is not valid",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		tx = governance.NewSubmitProposalTx(nonce, fee, &governance.ProposalContent{
+			UpdateRuntime: &governance.UpdateRuntimeProposal{
+				Descriptor: rt,
+			},
+		})
 	default:
-		logger.Error(fmt.Sprintf("missing required arguments: either '%v' or '%v' required",
-			cfgProposalUpgradeDescriptor, cfgProposalCancelUpgradeID,
+		logger.Error(fmt.Sprintf("missing required arguments: one of '%v', '%v' or '%v' required",
+			cfgProposalUpgradeDescriptor, cfgProposalCancelUpgradeID, cfgProposalUpdateRuntime,
 		))
 		os.Exit(1)
 	}
@@ -294,6 +326,7 @@ func init() {
 
 	submitProposalFlags.String(cfgProposalUpgradeDescriptor, "", "Path to the proposal upgrade descriptor")
 	submitProposalFlags.Uint64(cfgProposalCancelUpgradeID, 0, "Cancel upgrade proposal ID")
+	submitProposalFlags.String(cfgProposalUpdateRuntime, "", "Path to the updated runtime descriptor")
 	_ = viper.BindPFlags(submitProposalFlags)
 	submitProposalFlags.AddFlagSet(cmdConsensus.TxFlags)
 	submitProposalFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
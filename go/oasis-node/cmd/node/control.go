@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -142,6 +143,17 @@ func (n *Node) GetRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 			}
 		}
 
+		// Fetch executor worker status.
+		if executorNode := n.ExecutorWorker.GetRuntime(rt.ID()); executorNode != nil {
+			status.Executor, err = executorNode.GetStatus(ctx)
+			if err != nil {
+				n.logger.Error("failed to fetch executor worker status",
+					"err", err,
+					"runtime_id", rt.ID(),
+				)
+			}
+		}
+
 		// Fetch storage worker status.
 		if storageNode := n.StorageWorker.GetRuntime(rt.ID()); storageNode != nil {
 			status.Storage, err = storageNode.GetStatus(ctx)
@@ -153,6 +165,15 @@ func (n *Node) GetRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 			}
 		}
 
+		// Fetch local storage statistics.
+		status.LocalStorage, err = rt.LocalStorage().Stats()
+		if err != nil {
+			n.logger.Error("failed to fetch local storage statistics",
+				"err", err,
+				"runtime_id", rt.ID(),
+			)
+		}
+
 		runtimes[rt.ID()] = status
 	}
 	return runtimes, nil
@@ -162,3 +183,25 @@ func (n *Node) GetRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 func (n *Node) GetPendingUpgrades(ctx context.Context) ([]*upgrade.PendingUpgrade, error) {
 	return n.Upgrader.PendingUpgrades(ctx)
 }
+
+// Implements control.ControlledNode.
+func (n *Node) SetTagIndexerEnabled(runtimeID common.Namespace, enabled bool) error {
+	// Seed node doesn't have a runtime registry.
+	if n.RuntimeRegistry == nil {
+		return fmt.Errorf("node: runtime %s is not supported", runtimeID)
+	}
+	return n.RuntimeRegistry.SetTagIndexerEnabled(runtimeID, enabled)
+}
+
+// Implements control.ControlledNode.
+func (n *Node) CompactLocalStorage(runtimeID common.Namespace) error {
+	// Seed node doesn't have a runtime registry.
+	if n.RuntimeRegistry == nil {
+		return fmt.Errorf("node: runtime %s is not supported", runtimeID)
+	}
+	rt, err := n.RuntimeRegistry.GetRuntime(runtimeID)
+	if err != nil {
+		return err
+	}
+	return rt.LocalStorage().Compact()
+}
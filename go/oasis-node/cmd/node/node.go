@@ -54,6 +54,7 @@ import (
 	sentryAPI "github.com/oasisprotocol/oasis-core/go/sentry/api"
 	stakingAPI "github.com/oasisprotocol/oasis-core/go/staking/api"
 	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
+	storageClient "github.com/oasisprotocol/oasis-core/go/storage/client"
 	"github.com/oasisprotocol/oasis-core/go/upgrade"
 	upgradeAPI "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	workerBeacon "github.com/oasisprotocol/oasis-core/go/worker/beacon"
@@ -156,6 +157,29 @@ func (n *Node) Wait() {
 	n.svcMgr.Wait()
 }
 
+// WaitGRPCStarted waits for all of the node's gRPC servers to be started.
+func (n *Node) WaitGRPCStarted(ctx context.Context) error {
+	servers := []*grpc.Server{n.grpcInternal}
+	if n.externalGrpcEnabled() {
+		servers = append(servers, n.CommonWorker.Grpc)
+	}
+
+	for _, srv := range servers {
+		select {
+		case <-srv.Started():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (n *Node) externalGrpcEnabled() bool {
+	return n.StorageWorker.Enabled() ||
+		n.KeymanagerWorker.Enabled() ||
+		n.ConsensusWorker.Enabled()
+}
+
 func (n *Node) waitReady() {
 	if n.NodeController == nil {
 		n.logger.Error("failed while waiting for node: node controller not initialized")
@@ -430,6 +454,18 @@ func (n *Node) initRuntimeWorkers() error {
 }
 
 func (n *Node) startRuntimeWorkers() error {
+	// Only start the external gRPC server if any workers are enabled. All services have been
+	// registered during initialization, so start it before any runtime services to make sure
+	// that the listener is ready by the time committee traffic can arrive.
+	if n.externalGrpcEnabled() {
+		if err := n.CommonWorker.Grpc.Start(); err != nil {
+			n.logger.Error("failed to start external gRPC server",
+				"err", err,
+			)
+			return err
+		}
+	}
+
 	// Start the common worker.
 	if err := n.CommonWorker.Start(); err != nil {
 		return err
@@ -475,18 +511,6 @@ func (n *Node) startRuntimeWorkers() error {
 		return fmt.Errorf("consensus worker: %w", err)
 	}
 
-	// Only start the external gRPC server if any workers are enabled.
-	if n.StorageWorker.Enabled() ||
-		n.KeymanagerWorker.Enabled() ||
-		n.ConsensusWorker.Enabled() {
-		if err := n.CommonWorker.Grpc.Start(); err != nil {
-			n.logger.Error("failed to start external gRPC server",
-				"err", err,
-			)
-			return err
-		}
-	}
-
 	// Close readyCh once all workers and runtimes are initialized.
 	go n.waitReady()
 
@@ -780,6 +804,7 @@ func init() {
 		cmdSigner.Flags,
 		pprof.Flags,
 		storage.Flags,
+		storageClient.Flags,
 		tendermint.Flags,
 		seed.Flags,
 		ias.Flags,
@@ -303,17 +303,15 @@ func doList(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	ctx := context.Background()
 	for _, node := range nodes {
-		var s string
 		switch cmdFlags.Verbose() {
 		case true:
-			b, _ := json.Marshal(node)
-			s = string(b)
+			node.PrettyPrint(ctx, "", os.Stdout)
+			fmt.Println()
 		default:
-			s = node.ID.String()
+			fmt.Printf("%v\n", node.ID)
 		}
-
-		fmt.Printf("%v\n", s)
 	}
 }
 
@@ -53,6 +53,12 @@ var (
 		Run:   doList,
 	}
 
+	schemaCmd = &cobra.Command{
+		Use:   "schema",
+		Short: "print the runtime descriptor JSON schema",
+		Run:   doSchema,
+	}
+
 	logger = logging.GetLogger("cmd/registry/runtime")
 )
 
@@ -146,11 +152,24 @@ func doList(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doSchema(cmd *cobra.Command, args []string) {
+	b, err := json.MarshalIndent(registry.RuntimeJSONSchema(), "", "  ")
+	if err != nil {
+		logger.Error("failed to marshal runtime descriptor schema",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s\n", b)
+}
+
 // Register registers the runtime sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	for _, v := range []*cobra.Command{
 		registerCmd,
 		listCmd,
+		schemaCmd,
 	} {
 		runtimeCmd.AddCommand(v)
 	}
@@ -140,18 +140,13 @@ type testNode struct {
 	entitySigner signature.Signer
 
 	dataDir string
-	start   time.Time
 }
 
-func (n *testNode) Stop() {
-	const waitTime = 1 * time.Second
-
-	// HACK: The gRPC server will cause a segfault if it is torn down
-	// while it is still in the process of being initialized.  There is
-	// currently no way to wait for it to launch either.
-	if elapsed := time.Since(n.start); elapsed < waitTime {
-		time.Sleep(waitTime - elapsed)
-	}
+func (n *testNode) Stop(t *testing.T) {
+	// The gRPC servers must not be torn down while they are still being initialized.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, n.Node.WaitGRPCStarted(ctx), "WaitGRPCStarted")
 
 	n.Node.Stop()
 	n.Node.Wait()
@@ -190,7 +185,6 @@ func newTestNode(t *testing.T) *testNode {
 		dataDir:      dataDir,
 		entity:       entity,
 		entitySigner: entitySigner,
-		start:        time.Now(),
 	}
 	t.Logf("starting node, data directory: %v", dataDir)
 	n.Node, err = node.NewTestNode()
@@ -219,7 +213,7 @@ func (tc *testCase) Run(t *testing.T, node *testNode) {
 func TestNode(t *testing.T) {
 	node := newTestNode(t)
 	defer func() {
-		node.Stop()
+		node.Stop(t)
 		switch t.Failed() {
 		case true:
 			t.Logf("one or more tests failed, preserving data directory: %v", node.dataDir)
@@ -390,6 +384,7 @@ func testBeacon(t *testing.T, node *testNode) {
 
 	timeSource := (node.Consensus.Beacon()).(beacon.SetableBackend)
 	beaconTests.BeaconImplementationTests(t, timeSource)
+	beaconTests.EpochChangeHookImplementationTest(t, timeSource)
 }
 
 func testStorage(t *testing.T, node *testNode) {
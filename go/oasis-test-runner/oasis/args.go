@@ -266,6 +266,14 @@ func (args *argBuilder) tendermintStateSync(
 	return args
 }
 
+func (args *argBuilder) tendermintPinnedStateVersion(version uint64) *argBuilder {
+	args.vec = append(args.vec, Argument{
+		Name:   tendermintFull.CfgDebugUnsafePinnedStateVersion,
+		Values: []string{strconv.FormatUint(version, 10)},
+	})
+	return args
+}
+
 func (args *argBuilder) tendermintUpgradeStopDelay(delay time.Duration) *argBuilder {
 	args.vec = append(args.vec, Argument{
 		Name:   tendermintFull.CfgUpgradeStopDelay,
@@ -563,6 +563,8 @@ type ClientFixture struct {
 
 	// MaxTransactionAge configures the MaxTransactionAge configuration of the client.
 	MaxTransactionAge int64 `json:"max_transaction_age"`
+
+	LogWatcherHandlerFactories []log.WatcherHandlerFactory `json:"-"`
 }
 
 // Create instantiates the client node described by the fixture.
@@ -575,6 +577,7 @@ func (f *ClientFixture) Create(net *Network) (*Client, error) {
 			AllowEarlyTermination:       f.AllowEarlyTermination,
 			SupplementarySanityInterval: f.Consensus.SupplementarySanityInterval,
 			EnableProfiling:             f.EnableProfiling,
+			LogWatcherHandlerFactories:  f.LogWatcherHandlerFactories,
 			ExtraArgs:                   f.ExtraArgs,
 		},
 		MaxTransactionAge:  f.MaxTransactionAge,
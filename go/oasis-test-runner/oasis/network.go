@@ -631,6 +631,9 @@ func (net *Network) startOasisNode(
 			node.consensusStateSync.TrustHash,
 		)
 	}
+	if node.consensusPinnedStateVersion != 0 {
+		extraArgs = extraArgs.tendermintPinnedStateVersion(node.consensusPinnedStateVersion)
+	}
 	if viper.IsSet(metrics.CfgMetricsAddr) {
 		extraArgs = extraArgs.appendNodeMetrics(node)
 	}
@@ -706,6 +709,8 @@ func (net *Network) MakeGenesis() error {
 		"--consensus.backend", net.cfg.Consensus.Backend,
 		"--consensus.tendermint.timeout_commit", net.cfg.Consensus.Parameters.TimeoutCommit.String(),
 		"--registry.enable_runtime_governance_models", "entity,runtime",
+		"--registry.enable_node_list_hash", "true",
+		"--scheduler.enforce_admission_policy", "true",
 		"--registry.debug.allow_unroutable_addresses", "true",
 		"--" + genesis.CfgRegistryDebugAllowTestRuntimes, "true",
 		"--scheduler.max_validators_per_entity", strconv.Itoa(len(net.Validators())),
@@ -754,6 +759,7 @@ func (net *Network) MakeGenesis() error {
 			"--" + genesis.CfgGovernanceUpgradeCancelMinEpochDiff, strconv.FormatUint(uint64(cfg.UpgradeCancelMinEpochDiff), 10),
 			"--" + genesis.CfgGovernanceUpgradeMinEpochDiff, strconv.FormatUint(uint64(cfg.UpgradeMinEpochDiff), 10),
 			"--" + genesis.CfgGovernanceVotingPeriod, strconv.FormatUint(uint64(cfg.VotingPeriod), 10),
+			"--" + genesis.CfgGovernanceEnableUpdateRuntimeProposals + "=" + strconv.FormatBool(cfg.EnableUpdateRuntimeProposals),
 		}...)
 	}
 	for _, v := range net.entities {
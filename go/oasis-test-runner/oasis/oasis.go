@@ -105,9 +105,10 @@ type Node struct { // nolint: maligned
 	disableDefaultLogWatcherHandlerFactories bool
 	logWatcherHandlerFactories               []log.WatcherHandlerFactory
 
-	consensus            ConsensusFixture
-	consensusStateSync   *ConsensusStateSyncCfg
-	customGrpcSocketPath string
+	consensus                   ConsensusFixture
+	consensusStateSync          *ConsensusStateSyncCfg
+	consensusPinnedStateVersion uint64
+	customGrpcSocketPath        string
 
 	pprofPort uint16
 
@@ -317,6 +318,15 @@ func (n *Node) SetConsensusStateSync(cfg *ConsensusStateSyncCfg) {
 	n.consensusStateSync = cfg
 }
 
+// SetConsensusPinnedStateVersion configures the historical consensus state version the node's
+// state should be pinned to (0 means no pinning).
+func (n *Node) SetConsensusPinnedStateVersion(version uint64) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.consensusPinnedStateVersion = version
+}
+
 func (n *Node) setProvisionedIdentity(persistTLS bool, seed string) error {
 	if len(seed) < 1 {
 		seed = n.Name
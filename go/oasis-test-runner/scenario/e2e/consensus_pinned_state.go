@@ -0,0 +1,172 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// ConsensusPinnedState is the scenario where a validator is restarted with its consensus state
+// pinned to a historical version and is then only used for queries.
+var ConsensusPinnedState scenario.Scenario = &consensusPinnedStateImpl{
+	E2E: *NewE2E("consensus-pinned-state"),
+}
+
+type consensusPinnedStateImpl struct {
+	E2E
+}
+
+func (sc *consensusPinnedStateImpl) Clone() scenario.Scenario {
+	return &consensusPinnedStateImpl{
+		E2E: sc.E2E.Clone(),
+	}
+}
+
+func (sc *consensusPinnedStateImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.E2E.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	f.Network.SetInsecureBeacon()
+
+	return f, nil
+}
+
+func (sc *consensusPinnedStateImpl) Run(childEnv *env.Env) error {
+	if err := sc.Net.Start(); err != nil {
+		return err
+	}
+
+	sc.Logger.Info("waiting for network to come up")
+	ctx := context.Background()
+	if err := sc.Net.Controller().WaitNodesRegistered(ctx, len(sc.Net.Validators())); err != nil {
+		return err
+	}
+
+	blockCh, blockSub, err := sc.Net.Controller().Consensus.WatchBlocks(ctx)
+	if err != nil {
+		return err
+	}
+	defer blockSub.Close()
+
+	sc.Logger.Info("waiting for some blocks")
+	var blk *consensus.Block
+	for {
+		select {
+		case blk = <-blockCh:
+			if blk.Height < 20 {
+				continue
+			}
+		case <-time.After(30 * time.Second):
+			return fmt.Errorf("timed out waiting for blocks")
+		}
+
+		break
+	}
+	pinnedHeight := blk.Height - 10
+
+	// Restart the last validator with its state pinned to an earlier version. This must not
+	// trigger any block processing (e.g., Tendermint handshake replay) on the pinned state.
+	val := sc.Net.Validators()[len(sc.Net.Validators())-1]
+	sc.Logger.Info("restarting validator with pinned state",
+		"validator", val.Name,
+		"pinned_height", pinnedHeight,
+	)
+	val.SetConsensusPinnedStateVersion(uint64(pinnedHeight))
+	if err = val.Restart(ctx); err != nil {
+		return fmt.Errorf("failed to restart validator: %w", err)
+	}
+
+	valCtrl, err := oasis.NewController(val.SocketPath())
+	if err != nil {
+		return fmt.Errorf("failed to create controller for validator %s: %w", val.Name, err)
+	}
+
+	// Wait for the pinned node to start serving queries.
+	var status *consensus.Status
+	queryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	for {
+		status, err = valCtrl.Consensus.GetStatus(queryCtx)
+		if err == nil {
+			break
+		}
+		select {
+		case <-time.After(1 * time.Second):
+		case <-queryCtx.Done():
+			return fmt.Errorf("failed to get status for pinned validator: %w", err)
+		}
+	}
+	if status.LatestHeight != pinnedHeight {
+		return fmt.Errorf("pinned validator reports unexpected latest height (expected: %d got: %d)",
+			pinnedHeight, status.LatestHeight,
+		)
+	}
+
+	// Queries at the latest height should reflect the pinned state.
+	expectedNodes, err := sc.Net.Controller().Registry.GetNodes(ctx, pinnedHeight)
+	if err != nil {
+		return fmt.Errorf("failed to query nodes at pinned height: %w", err)
+	}
+	nodes, err := valCtrl.Registry.GetNodes(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query nodes from pinned validator: %w", err)
+	}
+	if len(nodes) != len(expectedNodes) {
+		return fmt.Errorf("pinned validator returned unexpected number of nodes (expected: %d got: %d)",
+			len(expectedNodes), len(nodes),
+		)
+	}
+	for i := range nodes {
+		if !nodes[i].ID.Equal(expectedNodes[i].ID) {
+			return fmt.Errorf("pinned validator returned unexpected node (expected: %s got: %s)",
+				expectedNodes[i].ID, nodes[i].ID,
+			)
+		}
+	}
+
+	// Blocks are not available as Tendermint is not running.
+	if _, err = valCtrl.Consensus.GetBlock(ctx, consensus.HeightLatest); err == nil {
+		return fmt.Errorf("pinned validator should not serve blocks")
+	}
+
+	// The pinned state must not advance.
+	time.Sleep(5 * time.Second)
+	if status, err = valCtrl.Consensus.GetStatus(ctx); err != nil {
+		return fmt.Errorf("failed to get status for pinned validator: %w", err)
+	}
+	if status.LatestHeight != pinnedHeight {
+		return fmt.Errorf("pinned validator state advanced (expected: %d got: %d)", pinnedHeight, status.LatestHeight)
+	}
+	select {
+	case err = <-val.Exit():
+		return fmt.Errorf("pinned validator exited: %w", err)
+	default:
+	}
+
+	// Restart the validator without pinning, it should catch up with the network.
+	sc.Logger.Info("restarting validator without pinned state")
+	val.SetConsensusPinnedStateVersion(0)
+	if err = val.Restart(ctx); err != nil {
+		return fmt.Errorf("failed to restart validator: %w", err)
+	}
+	if err = valCtrl.WaitSync(ctx); err != nil {
+		return err
+	}
+	status, err = valCtrl.Consensus.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get status for validator: %w", err)
+	}
+	if status.LatestHeight <= blk.Height {
+		return errors.New("validator did not process any blocks after unpinning the state")
+	}
+
+	return nil
+}
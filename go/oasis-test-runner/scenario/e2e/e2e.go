@@ -426,6 +426,8 @@ func RegisterScenarios() error {
 		EarlyQueryInitHeight,
 		// Consensus state sync.
 		ConsensusStateSync,
+		// Consensus state pinned to a historical version.
+		ConsensusPinnedState,
 		// Multiple seeds test.
 		MultipleSeeds,
 		// Seed API test.
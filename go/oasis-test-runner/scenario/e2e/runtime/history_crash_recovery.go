@@ -0,0 +1,177 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/log"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+const (
+	// crashRecoveryCrashPoint is the crash point triggered between committing a block to the
+	// history keeper and broadcasting it.
+	crashRecoveryCrashPoint = "roothash.after_index"
+	// crashRecoveryCrashProbability is the probability of crashing at the crash point.
+	crashRecoveryCrashProbability = 0.2
+	// crashRecoveryTxCount is the number of txs that should be submitted (as we are the only
+	// submitter, this is also the number of blocks).
+	crashRecoveryTxCount = 20
+	// crashRecoveryCatchUpTimeout is the time the crashing client has to catch up.
+	crashRecoveryCatchUpTimeout = 2 * time.Minute
+)
+
+// HistoryCrashRecovery is the scenario that crashes a client node after committing blocks to the
+// runtime history and checks that no block is duplicated or missing after it restarts.
+var HistoryCrashRecovery scenario.Scenario = newHistoryCrashRecoveryImpl()
+
+type historyCrashRecoveryImpl struct {
+	runtimeImpl
+}
+
+func newHistoryCrashRecoveryImpl() scenario.Scenario {
+	return &historyCrashRecoveryImpl{
+		runtimeImpl: *newRuntimeImpl("history-crash-recovery", nil),
+	}
+}
+
+func (sc *historyCrashRecoveryImpl) Clone() scenario.Scenario {
+	return &historyCrashRecoveryImpl{
+		runtimeImpl: *sc.runtimeImpl.Clone().(*runtimeImpl),
+	}
+}
+
+func (sc *historyCrashRecoveryImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.runtimeImpl.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Avoid unexpected blocks.
+	f.Network.SetMockEpoch()
+	// Add a second client that crashes after committing blocks to its runtime history. The first
+	// client is used to submit transactions so that the crashes do not interfere with them.
+	f.Clients = append(f.Clients, oasis.ClientFixture{
+		NodeFixture: oasis.NodeFixture{
+			ExtraArgs: []oasis.Argument{
+				{
+					Name:   "debug.crash." + crashRecoveryCrashPoint,
+					Values: []string{fmt.Sprintf("%f", crashRecoveryCrashProbability)},
+				},
+			},
+		},
+		Runtimes: []int{1},
+		LogWatcherHandlerFactories: []log.WatcherHandlerFactory{
+			log.AssertJSONContains("crash_point_id", crashRecoveryCrashPoint, "crash point not triggered"),
+		},
+	})
+
+	return f, nil
+}
+
+func (sc *historyCrashRecoveryImpl) Run(childEnv *env.Env) error {
+	if err := sc.Net.Start(); err != nil {
+		return err
+	}
+
+	fixture, err := sc.Fixture()
+	if err != nil {
+		return err
+	}
+
+	if err = sc.initialEpochTransitions(fixture); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	// Submit transactions.
+	for i := 0; i < crashRecoveryTxCount; i++ {
+		sc.Logger.Info("submitting transaction to runtime",
+			"seq", i,
+		)
+
+		if _, err = sc.submitKeyValueRuntimeInsertTx(ctx, runtimeID, "hello", fmt.Sprintf("world %d", i), 0); err != nil {
+			return err
+		}
+	}
+
+	latestBlk, err := sc.Net.ClientController().RuntimeClient.GetBlock(ctx, &api.GetBlockRequest{
+		RuntimeID: runtimeID,
+		Round:     api.RoundLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	// Wait for the crashing client to catch up. It may be restarting, so retry on errors.
+	crashing := sc.Net.Clients()[1]
+	ctrl, err := oasis.NewController(crashing.SocketPath())
+	if err != nil {
+		return fmt.Errorf("failed to create controller for crashing client: %w", err)
+	}
+	defer ctrl.Close()
+
+	sc.Logger.Info("waiting for the crashing client to catch up",
+		"latest_round", latestBlk.Header.Round,
+	)
+	catchUpCtx, cancel := context.WithTimeout(ctx, crashRecoveryCatchUpTimeout)
+	defer cancel()
+	for {
+		var blk *block.Block
+		blk, err = ctrl.RuntimeClient.GetBlock(catchUpCtx, &api.GetBlockRequest{
+			RuntimeID: runtimeID,
+			Round:     api.RoundLatest,
+		})
+		if err == nil && blk.Header.Round >= latestBlk.Header.Round {
+			break
+		}
+
+		select {
+		case <-catchUpCtx.Done():
+			return fmt.Errorf("crashing client failed to catch up: %w", catchUpCtx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+
+	// Make sure that no block is duplicated or missing in the runtime history.
+	genesisBlk, err := ctrl.RuntimeClient.GetGenesisBlock(ctx, runtimeID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch genesis block: %w", err)
+	}
+
+	sc.Logger.Info("checking the runtime history of the crashing client",
+		"genesis_round", genesisBlk.Header.Round,
+		"latest_round", latestBlk.Header.Round,
+	)
+	prevBlk := genesisBlk
+	for round := genesisBlk.Header.Round + 1; round <= latestBlk.Header.Round; round++ {
+		var blk *block.Block
+		blk, err = ctrl.RuntimeClient.GetBlock(ctx, &api.GetBlockRequest{
+			RuntimeID: runtimeID,
+			Round:     round,
+		})
+		if err != nil {
+			return fmt.Errorf("block %d is missing: %w", round, err)
+		}
+		if blk.Header.Round != round {
+			return fmt.Errorf("block %d has an unexpected round %d", round, blk.Header.Round)
+		}
+		if prevHash := prevBlk.Header.EncodedHash(); !blk.Header.PreviousHash.Equal(&prevHash) {
+			return fmt.Errorf("block %d does not follow block %d", round, prevBlk.Header.Round)
+		}
+		prevBlk = blk
+	}
+
+	// The crashing client should agree with the first client on the latest block.
+	if latestHash, prevHash := latestBlk.Header.EncodedHash(), prevBlk.Header.EncodedHash(); !latestHash.Equal(&prevHash) {
+		return fmt.Errorf("latest block mismatch (expected: %s got: %s)", latestHash, prevHash)
+	}
+
+	return nil
+}
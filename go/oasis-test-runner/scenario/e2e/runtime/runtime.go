@@ -604,6 +604,8 @@ func RegisterScenarios() error {
 		RuntimeUpgrade,
 		// HistoryReindex test.
 		HistoryReindex,
+		// HistoryCrashRecovery test.
+		HistoryCrashRecovery,
 	} {
 		if err := cmd.Register(s); err != nil {
 			return err
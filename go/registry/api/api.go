@@ -121,6 +121,21 @@ var (
 	// has runtimes.
 	ErrEntityHasRuntimes = errors.New(ModuleName, 19, "registry: entity still has runtimes")
 
+	// ErrNodeListNotRetained is the error returned when the node list for the requested epoch
+	// is no longer available (e.g., due to state pruning).
+	ErrNodeListNotRetained = errors.New(ModuleName, 20, "registry: node list for epoch not retained")
+
+	// ErrNodeNotAdmitted is the error returned when a node is not admitted by a runtime's
+	// admission policy.
+	ErrNodeNotAdmitted = errors.New(ModuleName, 21, "registry: node not admitted by runtime admission policy")
+
+	// ErrForbiddenPublicKey is the error returned when a registration is signed by a public key
+	// that is blacklisted (e.g., a well-known insecure test key).
+	ErrForbiddenPublicKey = errors.New(ModuleName, 22, "registry: forbidden public key")
+
+	// ErrNoNodeListHash is the error returned when the node list hash is not available.
+	ErrNoNodeListHash = errors.New(ModuleName, 23, "registry: node list hash not available")
+
 	// MethodRegisterEntity is the method name for entity registrations.
 	MethodRegisterEntity = transaction.NewMethodName(ModuleName, "RegisterEntity", entity.SignedEntity{})
 	// MethodDeregisterEntity is the method name for entity deregistrations.
@@ -154,16 +169,13 @@ var (
 	KeyManagerRuntimeAllowedRoles = node.RoleKeyManager
 
 	// ConsensusAddressRequiredRoles are the Node roles that require Consensus Address.
-	ConsensusAddressRequiredRoles = node.RoleValidator
+	ConsensusAddressRequiredRoles = node.ConsensusAddressRequiredRoles
 
 	// TLSAddressRequiredRoles are the Node roles that require TLS Address.
-	TLSAddressRequiredRoles = node.RoleComputeWorker |
-		node.RoleStorageWorker |
-		node.RoleKeyManager |
-		node.RoleConsensusRPC
+	TLSAddressRequiredRoles = node.TLSAddressRequiredRoles
 
 	// P2PAddressRequiredRoles are the Node roles that require P2P Address.
-	P2PAddressRequiredRoles = node.RoleComputeWorker
+	P2PAddressRequiredRoles = node.P2PAddressRequiredRoles
 )
 
 // Backend is a registry implementation.
@@ -187,6 +199,21 @@ type Backend interface {
 	// GetNodes gets a list of all registered nodes.
 	GetNodes(context.Context, int64) ([]*node.Node, error)
 
+	// GetNodesAtEpoch gets the node list as it was at the start of the given
+	// epoch.
+	//
+	// The node list will be sorted by node ID in lexicographically ascending
+	// order.
+	GetNodesAtEpoch(context.Context, beacon.EpochTime) ([]*node.Node, error)
+
+	// GetNodeListHash returns the hash of the node list as it was at the
+	// start of the given epoch (see NodeList.Hash).
+	//
+	// The hash is committed to in consensus state, so it can be used to
+	// verify a node list without verifying each node descriptor. It is only
+	// available when enabled via the EnableNodeListHash consensus parameter.
+	GetNodeListHash(context.Context, beacon.EpochTime) (*hash.Hash, error)
+
 	// GetNodeByConsensusAddress looks up a node by its consensus address at the
 	// specified block height. The nature and format of the consensus address depends
 	// on the specific consensus backend implementation used.
@@ -204,6 +231,14 @@ type Backend interface {
 	// order.
 	WatchNodeList(context.Context) (<-chan *NodeList, pubsub.ClosableSubscription, error)
 
+	// WatchNodeListSince returns a channel that produces a stream of NodeList,
+	// first replaying the node lists of all epochs starting with the given
+	// epoch and then continuing with the same stream as WatchNodeList.
+	//
+	// In case the node list for the given epoch is no longer retained,
+	// ErrNodeListNotRetained is returned.
+	WatchNodeListSince(context.Context, beacon.EpochTime) (<-chan *NodeList, pubsub.ClosableSubscription, error)
+
 	// GetRuntime gets a runtime by ID.
 	GetRuntime(context.Context, *NamespaceQuery) (*Runtime, error)
 
@@ -316,6 +351,25 @@ type NodeList struct {
 	Nodes []*node.Node `json:"nodes"`
 }
 
+// Hash returns the hash of the node list.
+//
+// The hash is computed over the canonically ordered (see SortNodeList) node
+// descriptors, so it does not depend on the order of nodes in the list.
+func (nl *NodeList) Hash() hash.Hash {
+	nodes := make([]*node.Node, len(nl.Nodes))
+	copy(nodes, nl.Nodes)
+	SortNodeList(nodes)
+
+	return hash.NewFrom(nodes)
+}
+
+// WatchNodeListRequest is a WatchNodeList request.
+type WatchNodeListRequest struct {
+	// Since is the optional epoch starting with which node lists should be
+	// replayed before streaming live node lists.
+	Since *beacon.EpochTime `json:"since,omitempty"`
+}
+
 // NodeLookup interface implements various ways for the verification
 // functions to look-up nodes in the registry's state.
 type NodeLookup interface {
@@ -363,6 +417,12 @@ func VerifyRegisterEntityArgs(logger *logging.Logger, sigEnt *entity.SignedEntit
 		ctx = RegisterEntitySignatureContext
 	}
 
+	if sigEnt.Signed.Signature.PublicKey.IsBlacklisted() {
+		logger.Error("RegisterEntity: registration signed by a blacklisted public key",
+			"signed_entity", sigEnt,
+		)
+		return nil, ErrForbiddenPublicKey
+	}
 	if err := sigEnt.Open(ctx, &ent); err != nil {
 		logger.Error("RegisterEntity: invalid signature",
 			"signed_entity", sigEnt,
@@ -436,6 +496,15 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		sigCtx = RegisterNodeSignatureContext
 	}
 
+	for _, sig := range sigNode.MultiSigned.Signatures {
+		if sig.PublicKey.IsBlacklisted() {
+			logger.Error("RegisterNode: registration signed by a blacklisted public key",
+				"signed_node", sigNode,
+				"public_key", sig.PublicKey,
+			)
+			return nil, nil, ErrForbiddenPublicKey
+		}
+	}
 	if err := sigNode.Open(sigCtx, &n); err != nil {
 		logger.Error("RegisterNode: invalid signature",
 			"signed_node", sigNode,
@@ -515,6 +584,15 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		return nil, nil, fmt.Errorf("%w: invalid role specified", ErrInvalidArgument)
 	}
 
+	// Make sure that all fields required by the node's roles are present.
+	if err := n.ValidateRoles(); err != nil {
+		logger.Error("RegisterNode: missing fields required by roles",
+			"node", n,
+			"err", err,
+		)
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidArgument, err)
+	}
+
 	// TODO: Key manager nodes maybe should be restricted to only being a
 	// key manager at the expense of breaking some of our test configs.
 
@@ -582,8 +660,7 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		return nil, nil, fmt.Errorf("%w: registration not signed by consensus ID", ErrInvalidArgument)
 	}
 	expectedSigners = append(expectedSigners, n.Consensus.ID)
-	consensusAddressRequired := n.HasRoles(ConsensusAddressRequiredRoles)
-	if err := verifyAddresses(params, consensusAddressRequired, n.Consensus.Addresses); err != nil {
+	if err := verifyAddresses(params, n.Consensus.Addresses); err != nil {
 		addrs, _ := json.Marshal(n.Consensus.Addresses)
 		logger.Error("RegisterNode: missing/invalid consensus addresses",
 			"node", n,
@@ -599,8 +676,7 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		)
 		return nil, nil, fmt.Errorf("%w: invalid TLS public key", ErrInvalidArgument)
 	}
-	tlsAddressRequired := n.HasRoles(TLSAddressRequiredRoles)
-	if err := verifyAddresses(params, tlsAddressRequired, n.TLS.Addresses); err != nil {
+	if err := verifyAddresses(params, n.TLS.Addresses); err != nil {
 		addrs, _ := json.Marshal(n.TLS.Addresses)
 		logger.Error("RegisterNode: missing/invalid committee addresses",
 			"node", n,
@@ -633,8 +709,7 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		return nil, nil, fmt.Errorf("%w: registration not signed by P2P ID", ErrInvalidArgument)
 	}
 	expectedSigners = append(expectedSigners, n.P2P.ID)
-	p2pAddressRequired := n.HasRoles(P2PAddressRequiredRoles)
-	if err := verifyAddresses(params, p2pAddressRequired, n.P2P.Addresses); err != nil {
+	if err := verifyAddresses(params, n.P2P.Addresses); err != nil {
 		addrs, _ := json.Marshal(n.P2P.Addresses)
 		logger.Error("RegisterNode: missing/invald P2P addresses",
 			"node", n,
@@ -779,12 +854,9 @@ func VerifyAddress(addr node.Address, allowUnroutable bool) error {
 	return nil
 }
 
-func verifyAddresses(params *ConsensusParameters, addressRequired bool, addresses interface{}) error {
+func verifyAddresses(params *ConsensusParameters, addresses interface{}) error {
 	switch addrs := addresses.(type) {
 	case []node.ConsensusAddress:
-		if len(addrs) == 0 && addressRequired {
-			return fmt.Errorf("%w: missing consensus address", ErrInvalidArgument)
-		}
 		for _, v := range addrs {
 			if !v.ID.IsValid() {
 				return fmt.Errorf("%w: consensus address ID invalid", ErrInvalidArgument)
@@ -794,9 +866,6 @@ func verifyAddresses(params *ConsensusParameters, addressRequired bool, addresse
 			}
 		}
 	case []node.TLSAddress:
-		if len(addrs) == 0 && addressRequired {
-			return fmt.Errorf("%w: missing TLS address", ErrInvalidArgument)
-		}
 		for _, v := range addrs {
 			if !v.PubKey.IsValid() {
 				return fmt.Errorf("%w: TLS address public key invalid", ErrInvalidArgument)
@@ -806,9 +875,6 @@ func verifyAddresses(params *ConsensusParameters, addressRequired bool, addresse
 			}
 		}
 	case []node.Address:
-		if len(addrs) == 0 && addressRequired {
-			return fmt.Errorf("%w: missing node address", ErrInvalidArgument)
-		}
 		for _, v := range addrs {
 			if err := VerifyAddress(v, params.DebugAllowUnroutableAddresses); err != nil {
 				return err
@@ -1060,11 +1126,19 @@ func VerifyRuntime( // nolint: gocyclo
 func VerifyRegisterComputeRuntimeArgs(ctx context.Context, logger *logging.Logger, rt *Runtime, runtimeLookup RuntimeLookup) error {
 	// Check runtime's key manager, if key manager ID is set.
 	if rt.KeyManager != nil {
+		if rt.ID.Equal(rt.KeyManager) {
+			logger.Error("RegisterRuntime: runtime has self as key manager",
+				"runtime", rt.ID,
+			)
+			return fmt.Errorf("%w: compute runtime has self as key manager", ErrInvalidArgument)
+		}
+
 		km, err := runtimeLookup.AnyRuntime(ctx, *rt.KeyManager)
 		if err != nil {
 			logger.Error("RegisterRuntime: error when fetching the runtime's key manager from registry",
 				"runtime", rt.ID,
 				"key_manager", rt.KeyManager,
+				"err", err,
 			)
 			return err
 		}
@@ -1077,7 +1151,7 @@ func VerifyRegisterComputeRuntimeArgs(ctx context.Context, logger *logging.Logge
 				"expected_kind", KindKeyManager,
 				"actual_kind", km.Kind,
 			)
-			return ErrInvalidArgument
+			return fmt.Errorf("%w: key manager runtime is not a key manager", ErrInvalidArgument)
 		}
 
 		// Currently the keymanager implementation assumes SGX. Unless this is a
@@ -1214,6 +1288,10 @@ type ConsensusParameters struct {
 
 	// EnableRuntimeGovernanceModels is a set of enabled runtime governance models.
 	EnableRuntimeGovernanceModels map[RuntimeGovernanceModel]bool `json:"enable_runtime_governance_models,omitempty"`
+
+	// EnableNodeListHash is true iff the registry should commit to the hash of
+	// the node list at the start of each epoch.
+	EnableNodeListHash bool `json:"enable_node_list_hash,omitempty"`
 }
 
 const (
@@ -1,13 +1,20 @@
 package api
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 )
@@ -22,6 +29,8 @@ func TestVerifyNodeUpdate(t *testing.T) {
 	consensusID2 := signature.NewPublicKey("0100000000000000000000000000000000000000000000000000000000000002")
 	entityID1 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000001")
 	entityID2 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000002")
+	tlsKey1 := signature.NewPublicKey("2000000000000000000000000000000000000000000000000000000000000001")
+	tlsKey2 := signature.NewPublicKey("2000000000000000000000000000000000000000000000000000000000000002")
 
 	existingNode := node.Node{
 		ID:       nodeID1,
@@ -29,6 +38,10 @@ func TestVerifyNodeUpdate(t *testing.T) {
 		Consensus: node.ConsensusInfo{
 			ID: consensusID1,
 		},
+		TLS: node.TLSInfo{
+			PubKey:     tlsKey1,
+			NextPubKey: tlsKey2,
+		},
 		Roles: node.RoleComputeWorker,
 		Runtimes: []*node.Runtime{
 			{ID: rtID1},
@@ -79,6 +92,18 @@ func TestVerifyNodeUpdate(t *testing.T) {
 			err:   ErrNodeUpdateNotAllowed,
 			msg:   "node consensus ID update should not be allowed",
 		},
+		{
+			nodeFn: func() *node.Node {
+				nd := existingNode
+				nd.TLS = node.TLSInfo{
+					PubKey: tlsKey2,
+				}
+				return &nd
+			},
+			epoch: 0,
+			err:   nil,
+			msg:   "node TLS certificate rotation update should be allowed",
+		},
 		{
 			nodeFn: func() *node.Node {
 				nd := existingNode
@@ -134,3 +159,323 @@ func TestVerifyNodeUpdate(t *testing.T) {
 		require.Equal(t, tc.err, err, tc.msg)
 	}
 }
+
+func TestVerifyRuntimeUpdate(t *testing.T) {
+	logger := logging.GetLogger("registry/api/tests")
+
+	rtID1 := common.NewTestNamespaceFromSeed([]byte("runtime 1"), 0)
+	rtID2 := common.NewTestNamespaceFromSeed([]byte("runtime 2"), 0)
+	kmID1 := common.NewTestNamespaceFromSeed([]byte("key manager 1"), common.NamespaceKeyManager)
+	kmID2 := common.NewTestNamespaceFromSeed([]byte("key manager 2"), common.NamespaceKeyManager)
+	entityID1 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000001")
+	entityID2 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000002")
+
+	existingRt := Runtime{
+		ID:       rtID1,
+		EntityID: entityID1,
+		Kind:     KindCompute,
+		Executor: ExecutorParameters{
+			GroupSize: 3,
+		},
+		AdmissionPolicy: RuntimeAdmissionPolicy{
+			AnyNode: &AnyNodeRuntimeAdmissionPolicy{},
+		},
+		GovernanceModel: GovernanceEntity,
+	}
+	existingKmRt := existingRt
+	existingKmRt.KeyManager = &kmID1
+
+	for _, tc := range []struct {
+		currentRt *Runtime
+		rtFn      func() *Runtime
+		err       error
+		msg       string
+	}{
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				return &existingRt
+			},
+			err: nil,
+			msg: "same runtime update should be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.Executor.GroupSize = 5
+				return &rt
+			},
+			err: nil,
+			msg: "runtime committee size update should be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.AdmissionPolicy = RuntimeAdmissionPolicy{
+					EntityWhitelist: &EntityWhitelistRuntimeAdmissionPolicy{},
+				}
+				return &rt
+			},
+			err: nil,
+			msg: "runtime admission policy update should be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.ID = rtID2
+				return &rt
+			},
+			err: ErrRuntimeUpdateNotAllowed,
+			msg: "runtime ID update should not be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.EntityID = entityID2
+				return &rt
+			},
+			err: ErrRuntimeUpdateNotAllowed,
+			msg: "runtime owner update should not be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.Kind = KindKeyManager
+				return &rt
+			},
+			err: ErrRuntimeUpdateNotAllowed,
+			msg: "runtime kind update should not be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.Genesis.Round = 42
+				return &rt
+			},
+			err: ErrRuntimeUpdateNotAllowed,
+			msg: "runtime genesis update should not be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.KeyManager = &kmID1
+				return &rt
+			},
+			err: nil,
+			msg: "setting a key manager should be allowed",
+		},
+		{
+			currentRt: &existingKmRt,
+			rtFn: func() *Runtime {
+				rt := existingKmRt
+				rt.KeyManager = &kmID2
+				return &rt
+			},
+			err: ErrRuntimeUpdateNotAllowed,
+			msg: "changing the key manager should not be allowed",
+		},
+		{
+			currentRt: &existingKmRt,
+			rtFn: func() *Runtime {
+				rt := existingKmRt
+				rt.KeyManager = nil
+				return &rt
+			},
+			err: ErrRuntimeUpdateNotAllowed,
+			msg: "removing the key manager should not be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.GovernanceModel = GovernanceRuntime
+				return &rt
+			},
+			err: nil,
+			msg: "entity to runtime governance transition should be allowed",
+		},
+		{
+			currentRt: &existingRt,
+			rtFn: func() *Runtime {
+				rt := existingRt
+				rt.GovernanceModel = GovernanceConsensus
+				return &rt
+			},
+			err: ErrRuntimeUpdateNotAllowed,
+			msg: "entity to consensus governance transition should not be allowed",
+		},
+	} {
+		err := VerifyRuntimeUpdate(logger, tc.currentRt, tc.rtFn())
+		require.Equal(t, tc.err, err, tc.msg)
+	}
+}
+
+type testRuntimeLookup map[common.Namespace]*Runtime
+
+func (l testRuntimeLookup) Runtime(ctx context.Context, id common.Namespace) (*Runtime, error) {
+	return l.AnyRuntime(ctx, id)
+}
+
+func (l testRuntimeLookup) SuspendedRuntime(ctx context.Context, id common.Namespace) (*Runtime, error) {
+	return nil, ErrNoSuchRuntime
+}
+
+func (l testRuntimeLookup) AnyRuntime(ctx context.Context, id common.Namespace) (*Runtime, error) {
+	rt, ok := l[id]
+	if !ok {
+		return nil, ErrNoSuchRuntime
+	}
+	return rt, nil
+}
+
+func (l testRuntimeLookup) AllRuntimes(ctx context.Context) ([]*Runtime, error) {
+	var rts []*Runtime
+	for _, rt := range l {
+		rts = append(rts, rt)
+	}
+	return rts, nil
+}
+
+func TestVerifyRegisterComputeRuntimeArgs(t *testing.T) {
+	require := require.New(t)
+
+	logger := logging.GetLogger("registry/api/tests")
+	ctx := context.Background()
+
+	kmID := common.NewTestNamespaceFromSeed([]byte("key manager"), common.NamespaceKeyManager)
+	rtID1 := common.NewTestNamespaceFromSeed([]byte("runtime 1"), 0)
+	rtID2 := common.NewTestNamespaceFromSeed([]byte("runtime 2"), 0)
+	missingID := common.NewTestNamespaceFromSeed([]byte("missing key manager"), common.NamespaceKeyManager)
+
+	lookup := testRuntimeLookup{
+		kmID:  &Runtime{ID: kmID, Kind: KindKeyManager},
+		rtID2: &Runtime{ID: rtID2, Kind: KindCompute},
+	}
+
+	for _, tc := range []struct {
+		keyManager *common.Namespace
+		err        error
+		msg        string
+	}{
+		{nil, nil, "runtime without a key manager should be allowed"},
+		{&kmID, nil, "runtime with a registered key manager should be allowed"},
+		{&missingID, ErrNoSuchRuntime, "runtime with a missing key manager should be rejected"},
+		{&rtID2, ErrInvalidArgument, "runtime with a compute runtime as key manager should be rejected"},
+		{&rtID1, ErrInvalidArgument, "runtime with self as key manager should be rejected"},
+	} {
+		rt := Runtime{
+			ID:         rtID1,
+			Kind:       KindCompute,
+			KeyManager: tc.keyManager,
+		}
+		err := VerifyRegisterComputeRuntimeArgs(ctx, logger, &rt, lookup)
+		switch tc.err {
+		case nil:
+			require.NoError(err, tc.msg)
+		default:
+			require.True(errors.Is(err, tc.err), tc.msg)
+		}
+	}
+}
+
+func TestVerifyRegisterArgsBlacklistedKeys(t *testing.T) {
+	require := require.New(t)
+
+	logger := logging.GetLogger("registry/api/tests")
+
+	// Use dedicated keys so that blacklisting them does not affect other tests.
+	entitySigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+
+	nodeSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	n := node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        nodeSigner.Public(),
+		EntityID:  ent.ID,
+	}
+	sigNode, err := node.MultiSignNode([]signature.Signer{nodeSigner}, RegisterNodeSignatureContext, &n)
+	require.NoError(err, "MultiSignNode")
+
+	verifyNode := func() error {
+		_, _, err := VerifyRegisterNodeArgs(
+			context.Background(),
+			&ConsensusParameters{},
+			logger,
+			sigNode,
+			&ent,
+			time.Now(),
+			false,
+			false,
+			0,
+			nil,
+			nil,
+		)
+		return err
+	}
+
+	// Keys should be allowed before they are blacklisted.
+	_, err = VerifyRegisterEntityArgs(logger, sigEnt, false, false)
+	require.NoError(err, "entity registration with a non-blacklisted key should be allowed")
+	err = verifyNode()
+	require.NotEqual(ErrForbiddenPublicKey, err, "node registration with a non-blacklisted key should be allowed")
+
+	// Blacklisted keys should be rejected.
+	require.NoError(entitySigner.Public().Blacklist(), "Blacklist entity key")
+	require.NoError(nodeSigner.Public().Blacklist(), "Blacklist node key")
+	_, err = VerifyRegisterEntityArgs(logger, sigEnt, false, false)
+	require.Equal(ErrForbiddenPublicKey, err, "entity registration with a blacklisted key should be rejected")
+	err = verifyNode()
+	require.Equal(ErrForbiddenPublicKey, err, "node registration with a blacklisted key should be rejected")
+}
+
+func TestNodeListHash(t *testing.T) {
+	require := require.New(t)
+
+	var nodes []*node.Node
+	for i := 0; i < 4; i++ {
+		var id signature.PublicKey
+		id[0] = byte(i)
+		nodes = append(nodes, &node.Node{
+			Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:         id,
+			Expiration: uint64(i),
+		})
+	}
+
+	nl := NodeList{Nodes: []*node.Node{nodes[0], nodes[1], nodes[2]}}
+	h := nl.Hash()
+
+	// The hash should not depend on the node order.
+	reordered := NodeList{Nodes: []*node.Node{nodes[2], nodes[0], nodes[1]}}
+	require.Equal(h, reordered.Hash(), "node list hash should not depend on node order")
+	require.Equal(nodes[2], reordered.Nodes[0], "Hash should not reorder the node list")
+
+	// Adding or removing a node should change the hash.
+	added := NodeList{Nodes: []*node.Node{nodes[0], nodes[1], nodes[2], nodes[3]}}
+	require.NotEqual(h, added.Hash(), "adding a node should change the hash")
+	removed := NodeList{Nodes: []*node.Node{nodes[0], nodes[2]}}
+	require.NotEqual(h, removed.Hash(), "removing a node should change the hash")
+
+	// Changing a node descriptor should change the hash.
+	changed := *nodes[1]
+	changed.Expiration = 42
+	modified := NodeList{Nodes: []*node.Node{nodes[0], &changed, nodes[2]}}
+	require.NotEqual(h, modified.Hash(), "changing a node should change the hash")
+
+	var empty NodeList
+	require.NotEqual(h, empty.Hash(), "empty node list should have a different hash")
+}
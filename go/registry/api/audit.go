@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+// AuditReport is a report of inconsistencies between registered entities and
+// nodes.
+type AuditReport struct {
+	// Height is the block height at which the audit was performed.
+	Height int64 `json:"height"`
+
+	// OrphanNodes are the nodes that reference an entity which is not registered.
+	OrphanNodes []signature.PublicKey `json:"orphan_nodes,omitempty"`
+
+	// MissingNodes are the nodes that are allowed by an entity's node list but are not
+	// registered, keyed by entity.
+	MissingNodes map[signature.PublicKey][]signature.PublicKey `json:"missing_nodes,omitempty"`
+
+	// UnprocessedExpiredNodes are the nodes that have expired but their expiration has not been
+	// processed.
+	UnprocessedExpiredNodes []signature.PublicKey `json:"unprocessed_expired_nodes,omitempty"`
+}
+
+// IsConsistent returns true iff the audit found no inconsistencies.
+func (r *AuditReport) IsConsistent() bool {
+	return len(r.OrphanNodes) == 0 && len(r.MissingNodes) == 0 && len(r.UnprocessedExpiredNodes) == 0
+}
+
+// Audit checks the consistency between the registered entities and nodes at the given block
+// height, where epoch is the epoch at that height.
+func Audit(ctx context.Context, backend Backend, height int64, epoch beacon.EpochTime) (*AuditReport, error) {
+	entities, err := backend.GetEntities(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("registry: audit failed to get entities: %w", err)
+	}
+	nodes, err := backend.GetNodes(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("registry: audit failed to get nodes: %w", err)
+	}
+
+	report := &AuditReport{
+		Height: height,
+	}
+
+	registeredEntities := make(map[signature.PublicKey]bool, len(entities))
+	for _, ent := range entities {
+		registeredEntities[ent.ID] = true
+	}
+	registeredNodes := make(map[signature.PublicKey]bool, len(nodes))
+	for _, n := range nodes {
+		registeredNodes[n.ID] = true
+
+		if !registeredEntities[n.EntityID] {
+			report.OrphanNodes = append(report.OrphanNodes, n.ID)
+		}
+
+		if n.IsExpired(uint64(epoch)) {
+			status, err := backend.GetNodeStatus(ctx, &IDQuery{Height: height, ID: n.ID})
+			if err != nil {
+				return nil, fmt.Errorf("registry: audit failed to get node status: %w", err)
+			}
+			if !status.ExpirationProcessed {
+				report.UnprocessedExpiredNodes = append(report.UnprocessedExpiredNodes, n.ID)
+			}
+		}
+	}
+
+	for _, ent := range entities {
+		for _, nodeID := range ent.Nodes {
+			if registeredNodes[nodeID] {
+				continue
+			}
+			if report.MissingNodes == nil {
+				report.MissingNodes = make(map[signature.PublicKey][]signature.PublicKey)
+			}
+			report.MissingNodes[ent.ID] = append(report.MissingNodes[ent.ID], nodeID)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+type testAuditBackend struct {
+	Backend
+
+	entities []*entity.Entity
+	nodes    []*node.Node
+	statuses map[signature.PublicKey]*NodeStatus
+}
+
+func (b *testAuditBackend) GetEntities(ctx context.Context, height int64) ([]*entity.Entity, error) {
+	return b.entities, nil
+}
+
+func (b *testAuditBackend) GetNodes(ctx context.Context, height int64) ([]*node.Node, error) {
+	return b.nodes, nil
+}
+
+func (b *testAuditBackend) GetNodeStatus(ctx context.Context, query *IDQuery) (*NodeStatus, error) {
+	status, ok := b.statuses[query.ID]
+	if !ok {
+		return nil, ErrNoSuchNode
+	}
+	return status, nil
+}
+
+func TestAudit(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+
+	entityID1 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000001")
+	entityID2 := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000002")
+	nodeID1 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	nodeID2 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+	nodeID3 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000003")
+
+	backend := &testAuditBackend{
+		entities: []*entity.Entity{
+			{ID: entityID1, Nodes: []signature.PublicKey{nodeID1, nodeID2}},
+			{ID: entityID2, Nodes: []signature.PublicKey{nodeID3}},
+		},
+		nodes: []*node.Node{
+			{ID: nodeID1, EntityID: entityID1, Expiration: 10},
+			{ID: nodeID2, EntityID: entityID1, Expiration: 10},
+			{ID: nodeID3, EntityID: entityID2, Expiration: 10},
+		},
+		statuses: map[signature.PublicKey]*NodeStatus{
+			nodeID1: {},
+			nodeID2: {},
+			nodeID3: {},
+		},
+	}
+
+	report, err := Audit(ctx, backend, 42, 5)
+	require.NoError(err, "Audit")
+	require.True(report.IsConsistent(), "consistent registry should pass the audit")
+	require.EqualValues(42, report.Height)
+
+	// Deregister the second entity, leaving its node orphaned.
+	backend.entities = backend.entities[:1]
+	report, err = Audit(ctx, backend, 42, 5)
+	require.NoError(err, "Audit")
+	require.False(report.IsConsistent(), "orphan node should fail the audit")
+	require.Equal([]signature.PublicKey{nodeID3}, report.OrphanNodes)
+	require.Empty(report.MissingNodes)
+	require.Empty(report.UnprocessedExpiredNodes)
+
+	// Remove a node that is in an entity's node list.
+	backend.nodes = backend.nodes[:1]
+	report, err = Audit(ctx, backend, 42, 5)
+	require.NoError(err, "Audit")
+	require.Empty(report.OrphanNodes)
+	require.Equal(map[signature.PublicKey][]signature.PublicKey{entityID1: {nodeID2}}, report.MissingNodes)
+
+	// Expired nodes are only inconsistent if their expiration has not been processed.
+	backend.nodes = []*node.Node{
+		{ID: nodeID1, EntityID: entityID1, Expiration: 10},
+		{ID: nodeID2, EntityID: entityID1, Expiration: 10},
+	}
+	backend.statuses[nodeID1].ExpirationProcessed = true
+	report, err = Audit(ctx, backend, 42, 11)
+	require.NoError(err, "Audit")
+	require.Equal([]signature.PublicKey{nodeID2}, report.UnprocessedExpiredNodes)
+}
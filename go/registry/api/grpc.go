@@ -5,6 +5,8 @@ import (
 
 	"google.golang.org/grpc"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -27,6 +29,10 @@ var (
 	methodGetNodeStatus = serviceName.NewMethod("GetNodeStatus", IDQuery{})
 	// methodGetNodes is the GetNodes method.
 	methodGetNodes = serviceName.NewMethod("GetNodes", int64(0))
+	// methodGetNodesAtEpoch is the GetNodesAtEpoch method.
+	methodGetNodesAtEpoch = serviceName.NewMethod("GetNodesAtEpoch", beacon.EpochTime(0))
+	// methodGetNodeListHash is the GetNodeListHash method.
+	methodGetNodeListHash = serviceName.NewMethod("GetNodeListHash", beacon.EpochTime(0))
 	// methodGetRuntime is the GetRuntime method.
 	methodGetRuntime = serviceName.NewMethod("GetRuntime", NamespaceQuery{})
 	// methodGetRuntimes is the GetRuntimes method.
@@ -41,7 +47,7 @@ var (
 	// methodWatchNodes is the WatchNodes method.
 	methodWatchNodes = serviceName.NewMethod("WatchNodes", nil)
 	// methodWatchNodeList is the WatchNodeList method.
-	methodWatchNodeList = serviceName.NewMethod("WatchNodeList", nil)
+	methodWatchNodeList = serviceName.NewMethod("WatchNodeList", WatchNodeListRequest{})
 	// methodWatchRuntimes is the WatchRuntimes method.
 	methodWatchRuntimes = serviceName.NewMethod("WatchRuntimes", nil)
 
@@ -74,6 +80,14 @@ var (
 				MethodName: methodGetNodes.ShortName(),
 				Handler:    handlerGetNodes,
 			},
+			{
+				MethodName: methodGetNodesAtEpoch.ShortName(),
+				Handler:    handlerGetNodesAtEpoch,
+			},
+			{
+				MethodName: methodGetNodeListHash.ShortName(),
+				Handler:    handlerGetNodeListHash,
+			},
 			{
 				MethodName: methodGetRuntime.ShortName(),
 				Handler:    handlerGetRuntime,
@@ -254,6 +268,52 @@ func handlerGetNodes( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetNodesAtEpoch( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var epoch beacon.EpochTime
+	if err := dec(&epoch); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetNodesAtEpoch(ctx, epoch)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetNodesAtEpoch.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetNodesAtEpoch(ctx, req.(beacon.EpochTime))
+	}
+	return interceptor(ctx, epoch, info, handler)
+}
+
+func handlerGetNodeListHash( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var epoch beacon.EpochTime
+	if err := dec(&epoch); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetNodeListHash(ctx, epoch)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetNodeListHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetNodeListHash(ctx, req.(beacon.EpochTime))
+	}
+	return interceptor(ctx, epoch, info, handler)
+}
+
 func handlerGetRuntime( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -403,12 +463,24 @@ func handlerWatchNodes(srv interface{}, stream grpc.ServerStream) error {
 }
 
 func handlerWatchNodeList(srv interface{}, stream grpc.ServerStream) error {
-	if err := stream.RecvMsg(nil); err != nil {
+	// For backwards compatibility the request is optional.
+	var req *WatchNodeListRequest
+	if err := stream.RecvMsg(&req); err != nil {
 		return err
 	}
 
 	ctx := stream.Context()
-	ch, sub, err := srv.(Backend).WatchNodeList(ctx)
+	var (
+		ch  <-chan *NodeList
+		sub pubsub.ClosableSubscription
+		err error
+	)
+	switch {
+	case req != nil && req.Since != nil:
+		ch, sub, err = srv.(Backend).WatchNodeListSince(ctx, *req.Since)
+	default:
+		ch, sub, err = srv.(Backend).WatchNodeList(ctx)
+	}
 	if err != nil {
 		return err
 	}
@@ -550,6 +622,22 @@ func (c *registryClient) GetNodes(ctx context.Context, height int64) ([]*node.No
 	return rsp, nil
 }
 
+func (c *registryClient) GetNodesAtEpoch(ctx context.Context, epoch beacon.EpochTime) ([]*node.Node, error) {
+	var rsp []*node.Node
+	if err := c.conn.Invoke(ctx, methodGetNodesAtEpoch.FullName(), epoch, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *registryClient) GetNodeListHash(ctx context.Context, epoch beacon.EpochTime) (*hash.Hash, error) {
+	var rsp hash.Hash
+	if err := c.conn.Invoke(ctx, methodGetNodeListHash.FullName(), epoch, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) WatchNodes(ctx context.Context) (<-chan *NodeEvent, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -586,13 +674,21 @@ func (c *registryClient) WatchNodes(ctx context.Context) (<-chan *NodeEvent, pub
 }
 
 func (c *registryClient) WatchNodeList(ctx context.Context) (<-chan *NodeList, pubsub.ClosableSubscription, error) {
+	return c.watchNodeList(ctx, nil)
+}
+
+func (c *registryClient) WatchNodeListSince(ctx context.Context, since beacon.EpochTime) (<-chan *NodeList, pubsub.ClosableSubscription, error) {
+	return c.watchNodeList(ctx, &WatchNodeListRequest{Since: &since})
+}
+
+func (c *registryClient) watchNodeList(ctx context.Context, req *WatchNodeListRequest) (<-chan *NodeList, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
 	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[2], methodWatchNodeList.FullName())
 	if err != nil {
 		return nil, nil, err
 	}
-	if err = stream.SendMsg(nil); err != nil {
+	if err = stream.SendMsg(req); err != nil {
 		return nil, nil, err
 	}
 	if err = stream.CloseSend(); err != nil {
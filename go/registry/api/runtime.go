@@ -11,6 +11,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/jsonschema"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
@@ -28,6 +29,16 @@ var (
 	// ErrUnsupportedRuntimeGovernanceModel is the error returned when the
 	// parsed runtime governance model is malformed or unknown.
 	ErrUnsupportedRuntimeGovernanceModel = errors.New("runtime: unsupported governance model")
+
+	// ErrUnsupportedTxnSchedulerAlgorithm is the error returned when the
+	// transaction scheduler algorithm is unknown.
+	ErrUnsupportedTxnSchedulerAlgorithm = errors.New("runtime: unsupported transaction scheduler algorithm")
+
+	// txnSchedulerAlgorithms are the supported transaction scheduler
+	// algorithms, mapped to whether the algorithm is a batching one.
+	txnSchedulerAlgorithms = map[string]bool{
+		TxnSchedulerSimple: true,
+	}
 )
 
 // RuntimeKind represents the runtime functionality.
@@ -136,18 +147,28 @@ type TxnSchedulerParameters struct {
 
 // ValidateBasic performs basic transaction scheduler parameter validity checks.
 func (t *TxnSchedulerParameters) ValidateBasic() error {
-	// Ensure txnscheduler parameters have sensible values.
-	if t.Algorithm != TxnSchedulerSimple {
-		return fmt.Errorf("invalid transaction scheduler algorithm")
-	}
-	if t.BatchFlushTimeout < 50*time.Millisecond {
-		return fmt.Errorf("transaction scheduler batch flush timeout parameter too small")
-	}
-	if t.MaxBatchSize < 1 {
-		return fmt.Errorf("transaction scheduler max batch size parameter too small")
+	return t.validateBasic(txnSchedulerAlgorithms)
+}
+
+func (t *TxnSchedulerParameters) validateBasic(algorithms map[string]bool) error {
+	isBatching, ok := algorithms[t.Algorithm]
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrUnsupportedTxnSchedulerAlgorithm, t.Algorithm)
 	}
-	if t.MaxBatchSizeBytes < 1024 {
-		return fmt.Errorf("transaction scheduler max batch bytes size parameter too small")
+
+	// Ensure txnscheduler parameters have sensible values.
+	if isBatching {
+		if t.BatchFlushTimeout < 50*time.Millisecond {
+			return fmt.Errorf("transaction scheduler batch flush timeout parameter too small")
+		}
+		if t.MaxBatchSize < 1 {
+			return fmt.Errorf("transaction scheduler max batch size parameter too small")
+		}
+		if t.MaxBatchSizeBytes < 1024 {
+			return fmt.Errorf("transaction scheduler max batch bytes size parameter too small")
+		}
+	} else if t.BatchFlushTimeout != 0 || t.MaxBatchSize != 0 || t.MaxBatchSizeBytes != 0 {
+		return fmt.Errorf("transaction scheduler batching parameters set for non-batching algorithm")
 	}
 	if t.ProposerTimeout < 2 {
 		return fmt.Errorf("transaction scheduler proposer timeout parameter too small")
@@ -242,6 +263,20 @@ type RuntimeAdmissionPolicy struct {
 	EntityWhitelist *EntityWhitelistRuntimeAdmissionPolicy `json:"entity_whitelist,omitempty"`
 }
 
+// VerifyNode checks whether the given node is admitted by the admission policy.
+//
+// Note that this does not check the per-entity node limits of the entity
+// whitelist policy, as these are enforced at registration time.
+func (ap *RuntimeAdmissionPolicy) VerifyNode(n *node.Node) error {
+	if ap.EntityWhitelist == nil {
+		return nil
+	}
+	if _, ok := ap.EntityWhitelist.Entities[n.EntityID]; !ok {
+		return ErrNodeNotAdmitted
+	}
+	return nil
+}
+
 // SchedulingConstraints are the node scheduling constraints.
 //
 // Multiple fields may be set in which case the ALL the constraints must be satisfied.
@@ -611,6 +646,78 @@ func (rtg *RuntimeGenesis) SanityCheck(isGenesis bool) error {
 	return nil
 }
 
+// RuntimeJSONSchema returns the JSON Schema describing the JSON serialization
+// of a runtime descriptor.
+func RuntimeJSONSchema() jsonschema.Schema {
+	g := jsonschema.NewGenerator()
+	g.Override(RuntimeKind(0), jsonschema.Schema{
+		"type":        "integer",
+		"enum":        []interface{}{KindCompute, KindKeyManager},
+		"description": "Runtime kind (1: compute, 2: key manager).",
+	})
+	g.Override(RuntimeGovernanceModel(0), jsonschema.Schema{
+		"type": "string",
+		"enum": []interface{}{gmEntity, gmRuntime, gmConsensus},
+	})
+	g.Override(node.TEEHardware(0), jsonschema.Schema{
+		"type":        "integer",
+		"enum":        []interface{}{node.TEEHardwareInvalid, node.TEEHardwareIntelSGX},
+		"description": "TEE hardware (0: none, 1: Intel SGX).",
+	})
+	g.Describe(Runtime{}, map[string]string{
+		"v":                "Runtime descriptor version.",
+		"id":               "Globally unique long term identifier of the runtime.",
+		"entity_id":        "Public key identifying the entity controlling the runtime.",
+		"genesis":          "Runtime genesis information.",
+		"kind":             "Runtime kind (1: compute, 2: key manager).",
+		"tee_hardware":     "Runtime's TEE hardware requirements (0: none, 1: Intel SGX).",
+		"versions":         "Runtime version information.",
+		"key_manager":      "Key manager runtime ID for this runtime.",
+		"executor":         "Parameters of the executor committee.",
+		"txn_scheduler":    "Transaction scheduling parameters of the executor committee.",
+		"storage":          "Parameters of the storage committee.",
+		"admission_policy": "Which nodes are allowed to register for this runtime.",
+		"constraints":      "Node scheduling constraints.",
+		"staking":          "Runtime's staking-related parameters.",
+		"governance_model": "Runtime governance model.",
+	})
+	g.Describe(ExecutorParameters{}, map[string]string{
+		"group_size":         "Size of the committee.",
+		"group_backup_size":  "Size of the discrepancy resolution group.",
+		"allowed_stragglers": "Number of allowed stragglers.",
+		"round_timeout":      "Round timeout in consensus blocks.",
+		"max_messages":       "Maximum number of messages that can be emitted by the runtime in a single round.",
+	})
+	g.Describe(TxnSchedulerParameters{}, map[string]string{
+		"algorithm":             "Transaction scheduling algorithm.",
+		"batch_flush_timeout":   "How long to wait for a scheduled batch (in nanoseconds) when using the \"simple\" algorithm.",
+		"max_batch_size":        "Maximum size of a scheduled batch.",
+		"max_batch_size_bytes":  "Maximum size of a scheduled batch in bytes.",
+		"propose_batch_timeout": "Timeout (in consensus blocks) for the scheduler to propose a batch.",
+	})
+	g.Describe(StorageParameters{}, map[string]string{
+		"group_size":                  "Size of the storage group.",
+		"min_write_replication":       "Number of nodes to which any writes must be replicated before being assumed to be committed.",
+		"max_apply_write_log_entries": "Maximum number of write log entries when performing an Apply operation.",
+		"max_apply_ops":               "Maximum number of apply operations in a batch.",
+		"checkpoint_interval":         "Expected runtime state checkpoint interval (in rounds).",
+		"checkpoint_num_kept":         "Expected minimum number of checkpoints to keep.",
+		"checkpoint_chunk_size":       "Chunk size parameter for checkpoint creation.",
+	})
+	g.Describe(RuntimeAdmissionPolicy{}, map[string]string{
+		"any_node":         "Allow any node to register.",
+		"entity_whitelist": "Allow only nodes of whitelisted entities to register.",
+	})
+	g.Describe(EntityWhitelistRuntimeAdmissionPolicy{}, map[string]string{
+		"entities": "Whitelisted entities, keyed by entity public key.",
+	})
+	g.Describe(EntityWhitelistConfig{}, map[string]string{
+		"max_nodes": "Maximum number of nodes per role that the entity can register (missing roles imply zero nodes).",
+	})
+
+	return g.Generate(Runtime{})
+}
+
 // RuntimeDescriptorProvider is an interface that provides access to runtime descriptors.
 type RuntimeDescriptorProvider interface {
 	// ActiveDescriptor waits for the runtime to be initialized and then returns its active
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/jsonschema"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestRuntimeJSONSchema(t *testing.T) {
+	require := require.New(t)
+
+	schema := RuntimeJSONSchema()
+	_, err := json.Marshal(schema)
+	require.NoError(err, "runtime schema should be serializable")
+
+	kmID := common.NewTestNamespaceFromSeed([]byte("key manager"), common.NamespaceKeyManager)
+	entityID := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000001")
+	var threshold quantity.Quantity
+	require.NoError(threshold.FromUint64(1000), "FromUint64")
+
+	rt := Runtime{
+		Versioned:   cbor.NewVersioned(LatestRuntimeDescriptorVersion),
+		ID:          common.NewTestNamespaceFromSeed([]byte("runtime"), 0),
+		EntityID:    entityID,
+		Kind:        KindCompute,
+		TEEHardware: node.TEEHardwareIntelSGX,
+		Version: VersionInfo{
+			Version: version.Version{Major: 1, Minor: 2, Patch: 3},
+			TEE:     []byte("tee"),
+		},
+		KeyManager: &kmID,
+		Executor: ExecutorParameters{
+			GroupSize:    3,
+			RoundTimeout: 20,
+		},
+		TxnScheduler: TxnSchedulerParameters{
+			Algorithm:         TxnSchedulerSimple,
+			BatchFlushTimeout: time.Second,
+			MaxBatchSize:      100,
+			MaxBatchSizeBytes: 1024,
+		},
+		AdmissionPolicy: RuntimeAdmissionPolicy{
+			EntityWhitelist: &EntityWhitelistRuntimeAdmissionPolicy{
+				Entities: map[signature.PublicKey]EntityWhitelistConfig{
+					entityID: {MaxNodes: map[node.RolesMask]uint16{node.RoleComputeWorker: 2}},
+				},
+			},
+		},
+		Constraints: map[scheduler.CommitteeKind]map[scheduler.Role]SchedulingConstraints{
+			scheduler.KindComputeExecutor: {
+				scheduler.RoleWorker: {
+					MinPoolSize: &MinPoolSizeConstraint{Limit: 3},
+				},
+			},
+		},
+		Staking: RuntimeStakingParameters{
+			Thresholds: map[staking.ThresholdKind]quantity.Quantity{
+				staking.KindNodeCompute: threshold,
+			},
+		},
+		GovernanceModel: GovernanceEntity,
+	}
+	raw, err := json.Marshal(&rt)
+	require.NoError(err, "json.Marshal")
+
+	// All fields of the serialized runtime descriptor should be described by the schema.
+	var doc map[string]interface{}
+	require.NoError(json.Unmarshal(raw, &doc), "json.Unmarshal")
+	props := schema["properties"].(map[string]interface{})
+	for name, value := range doc {
+		require.Contains(props, name, "serialized field should be in the schema")
+
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		nestedProps, ok := props[name].(jsonschema.Schema)["properties"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for nestedName := range nested {
+			require.Contains(nestedProps, nestedName, "serialized field %s.%s should be in the schema", name, nestedName)
+		}
+	}
+
+	// Fields of the runtime descriptor and its parameters should be documented.
+	for _, name := range []string{"", "executor", "txn_scheduler", "storage", "admission_policy"} {
+		s := schema
+		if name != "" {
+			s = props[name].(jsonschema.Schema)
+		}
+		for propName, prop := range s["properties"].(map[string]interface{}) {
+			require.NotEmpty(prop.(jsonschema.Schema)["description"], "field %s.%s should have a description", name, propName)
+		}
+	}
+
+	require.Equal([]interface{}{KindCompute, KindKeyManager}, props["kind"].(jsonschema.Schema)["enum"], "runtime kinds")
+	require.Equal([]interface{}{gmEntity, gmRuntime, gmConsensus}, props["governance_model"].(jsonschema.Schema)["enum"], "governance models")
+}
+
+func TestTxnSchedulerParametersValidateBasic(t *testing.T) {
+	require := require.New(t)
+
+	// Also support a non-batching algorithm, as none is defined yet.
+	const nonBatching = "test-non-batching"
+	algorithms := map[string]bool{
+		TxnSchedulerSimple: true,
+		nonBatching:        false,
+	}
+
+	for _, tc := range []struct {
+		params TxnSchedulerParameters
+		valid  bool
+		msg    string
+	}{
+		{
+			TxnSchedulerParameters{
+				Algorithm:         TxnSchedulerSimple,
+				BatchFlushTimeout: time.Second,
+				MaxBatchSize:      1000,
+				MaxBatchSizeBytes: 16 * 1024 * 1024,
+				ProposerTimeout:   5,
+			},
+			true,
+			"valid batching configuration should pass",
+		},
+		{
+			TxnSchedulerParameters{
+				Algorithm:         TxnSchedulerSimple,
+				BatchFlushTimeout: time.Millisecond,
+				MaxBatchSize:      1000,
+				MaxBatchSizeBytes: 16 * 1024 * 1024,
+				ProposerTimeout:   5,
+			},
+			false,
+			"batching configuration with a too small flush timeout should fail",
+		},
+		{
+			TxnSchedulerParameters{
+				Algorithm:         "unknown",
+				BatchFlushTimeout: time.Second,
+				MaxBatchSize:      1000,
+				MaxBatchSizeBytes: 16 * 1024 * 1024,
+				ProposerTimeout:   5,
+			},
+			false,
+			"unknown algorithm should fail",
+		},
+		{
+			TxnSchedulerParameters{
+				Algorithm:       nonBatching,
+				ProposerTimeout: 5,
+			},
+			true,
+			"non-batching algorithm without batching parameters should pass",
+		},
+		{
+			TxnSchedulerParameters{
+				Algorithm:       nonBatching,
+				MaxBatchSize:    1000,
+				ProposerTimeout: 5,
+			},
+			false,
+			"batching parameters under a non-batching algorithm should fail",
+		},
+	} {
+		err := tc.params.validateBasic(algorithms)
+		switch tc.valid {
+		case true:
+			require.NoError(err, tc.msg)
+		case false:
+			require.Error(err, tc.msg)
+		}
+	}
+
+	err := (&TxnSchedulerParameters{Algorithm: "unknown"}).ValidateBasic()
+	require.True(errors.Is(err, ErrUnsupportedTxnSchedulerAlgorithm), "unknown algorithm error should be typed")
+}
@@ -167,6 +167,13 @@ func SanityCheckNodes(
 		nodesByPoint: make(map[string]*node.Node),
 	}
 
+	// Verify all of the node signatures in a single batch first, so that any invalid signature
+	// can be pinpointed. Valid signatures are added to the verified signature cache, so opening
+	// the nodes below does not need to verify them again.
+	if err := verifyNodeSignatures(nodes); err != nil {
+		return nil, err
+	}
+
 	for _, signedNode := range nodes {
 
 		// Open the node to get the referenced entity.
@@ -216,6 +223,36 @@ func SanityCheckNodes(
 	return nodeLookup, nil
 }
 
+// verifyNodeSignatures verifies the signatures of all of the given nodes in a single batch and
+// reports which signer produced an invalid signature (if any).
+func verifyNodeSignatures(nodes []*node.MultiSignedNode) error {
+	var (
+		contexts []signature.Context
+		messages [][]byte
+		sigs     []signature.Signature
+		owners   []int
+	)
+	for i, signedNode := range nodes {
+		for _, sig := range signedNode.Signatures {
+			contexts = append(contexts, RegisterGenesisNodeSignatureContext)
+			messages = append(messages, signedNode.Blob)
+			sigs = append(sigs, sig)
+			owners = append(owners, i)
+		}
+	}
+
+	valid, err := signature.VerifyBatchDetailed(contexts, messages, sigs)
+	if err != nil {
+		return fmt.Errorf("registry: sanity check failed: unable to verify node signatures: %w", err)
+	}
+	for i, ok := range valid {
+		if !ok {
+			return fmt.Errorf("registry: sanity check failed: invalid signature by %s on node %d", sigs[i].PublicKey, owners[i])
+		}
+	}
+	return nil
+}
+
 // SanityCheckStake ensures entities' stake accumulator claims are consistent
 // with general state and entities have enough stake for themselves and all
 // their registered nodes and runtimes.
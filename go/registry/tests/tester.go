@@ -3,7 +3,7 @@ package tests
 
 import (
 	"context"
-	"crypto"
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"math"
@@ -17,11 +17,11 @@ import (
 	beaconTests "github.com/oasisprotocol/oasis-core/go/beacon/tests"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
-	"github.com/oasisprotocol/oasis-core/go/common/crypto/drbg"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/pvss"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/testkeys"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -301,7 +301,7 @@ func testRegistryEntityNodes( // nolint: gocyclo
 				}
 
 				err = tn.Register(consensus, tn.SignedValidReRegistration)
-				require.NoError(err, "Re-registering a node with different address and more runtimes should work")
+				require.NoError(err, "Re-registering a node with different address, more runtimes and a rotated TLS certificate should work")
 
 				for _, v := range tn.invalidReReg {
 					err = tn.Register(consensus, v.signed)
@@ -315,6 +315,11 @@ func testRegistryEntityNodes( // nolint: gocyclo
 				case <-time.After(recvTimeout):
 					t.Fatalf("failed to receive node registration event")
 				}
+
+				nod, err = backend.GetNode(ctx, &api.IDQuery{ID: tn.Node.ID, Height: consensusAPI.HeightLatest})
+				require.NoError(err, "GetNode")
+				require.EqualValues(tn.UpdatedNode.TLS, nod.TLS, "retrieved node should use the rotated TLS certificate")
+				require.False(tn.Node.TLS.PubKey.Equal(nod.TLS.PubKey), "TLS certificate should be rotated")
 			}
 		}
 
@@ -359,6 +364,43 @@ func testRegistryEntityNodes( // nolint: gocyclo
 		registeredNodes, nerr := backend.GetNodes(ctx, consensusAPI.HeightLatest)
 		require.NoError(nerr, "GetNodes")
 		require.EqualValues(expectedNodeList, registeredNodes, "node list")
+
+		// Node list at the current epoch should match.
+		registeredNodes, nerr = backend.GetNodesAtEpoch(ctx, epoch)
+		require.NoError(nerr, "GetNodesAtEpoch")
+		require.EqualValues(expectedNodeList, registeredNodes, "node list at current epoch")
+
+		// The committed node list hash should match the node list.
+		nlHash, nerr := backend.GetNodeListHash(ctx, epoch)
+		require.NoError(nerr, "GetNodeListHash")
+		expectedNl := api.NodeList{Nodes: expectedNodeList}
+		require.Equal(expectedNl.Hash(), *nlHash, "node list hash at current epoch")
+
+		// Nodes were registered during the previous epoch, so they should not
+		// be part of the node list at the start of the previous epoch.
+		registeredNodes, nerr = backend.GetNodesAtEpoch(ctx, epoch-1)
+		require.NoError(nerr, "GetNodesAtEpoch(prior epoch)")
+		for _, n := range expectedNodeList {
+			require.NotContains(registeredNodes, n, "node list at prior epoch")
+		}
+
+		// Watching the node list since the prior epoch should first replay the
+		// prior epoch's node list and then continue with the current one.
+		nodeListCh, nodeListSub, nerr := backend.WatchNodeListSince(ctx, epoch-1)
+		require.NoError(nerr, "WatchNodeListSince")
+		defer nodeListSub.Close()
+
+		for _, expected := range [][]*node.Node{registeredNodes, expectedNodeList} {
+			select {
+			case nl := <-nodeListCh:
+				require.EqualValues(expected, nl.Nodes, "replayed node list")
+			case <-time.After(recvTimeout):
+				t.Fatalf("failed to receive node list")
+			}
+		}
+
+		_, _, nerr = backend.WatchNodeListSince(ctx, epoch+1)
+		require.Error(nerr, "WatchNodeListSince should fail for a future epoch")
 	})
 
 	t.Run("NodeUnfreeze", func(t *testing.T) {
@@ -992,14 +1034,7 @@ func (n *TestNode) Register(consensus consensusAPI.Backend, sigNode *node.MultiS
 	return consensusAPI.SignAndSubmitTx(context.Background(), consensus, n.Signer, api.NewRegisterNodeTx(0, nil, sigNode))
 }
 
-func randomIdentity(rng *drbg.Drbg) *identity.Identity {
-	mustGenerateSigner := func() signature.Signer {
-		signer, err := memorySigner.NewSigner(rng)
-		if err != nil {
-			panic(err)
-		}
-		return signer
-	}
+func randomIdentity(keys *testkeys.Factory) *identity.Identity {
 	mustGenerateScalar := func() pvss.Scalar {
 		// Note: This is non-deterministic, but that's ok for now.
 		scalar, _, err := pvss.NewKeyPair()
@@ -1010,9 +1045,9 @@ func randomIdentity(rng *drbg.Drbg) *identity.Identity {
 	}
 
 	ident := &identity.Identity{
-		NodeSigner:      mustGenerateSigner(),
-		P2PSigner:       mustGenerateSigner(),
-		ConsensusSigner: mustGenerateSigner(),
+		NodeSigner:      keys.MustNext(),
+		P2PSigner:       keys.MustNext(),
+		ConsensusSigner: keys.MustNext(),
 		BeaconScalar:    mustGenerateScalar(),
 	}
 
@@ -1033,21 +1068,21 @@ func (ent *TestEntity) NewTestNodes(nCompute, nStorage int, idNonce []byte, runt
 	}
 	n := nCompute + nStorage
 
-	rng, err := drbg.New(crypto.SHA512, hashForDrbg(ent.Entity.ID[:]), idNonce, []byte("TestNodes"))
+	keys, err := testkeys.NewFactory(ent.Entity.ID[:], idNonce, []byte("TestNodes"))
 	if err != nil {
 		return nil, err
 	}
 
 	nodes := make([]*TestNode, 0, n)
 	for i := 0; i < n; i++ {
-		nodeIdentity := randomIdentity(rng)
+		nodeIdentity := randomIdentity(keys)
 		nodeSigners := []signature.Signer{
 			nodeIdentity.NodeSigner,
 			nodeIdentity.P2PSigner,
 			nodeIdentity.ConsensusSigner,
 			nodeIdentity.GetTLSSigner(),
 		}
-		invalidIdentity := randomIdentity(rng)
+		invalidIdentity := randomIdentity(keys)
 
 		var nod TestNode
 		nod.Signer = nodeIdentity.NodeSigner
@@ -1360,10 +1395,30 @@ func (ent *TestEntity) NewTestNodes(nCompute, nStorage int, idNonce []byte, runt
 		}
 		nod.UpdatedNode.P2P.ID = nod.Node.P2P.ID
 		nod.UpdatedNode.P2P.Addresses = append(nod.UpdatedNode.P2P.Addresses, addr)
-		nod.UpdatedNode.TLS.PubKey = nod.Node.TLS.PubKey
-		nod.UpdatedNode.TLS.Addresses = nod.Node.TLS.Addresses
+		// Rotate the TLS certificate on re-registration.
+		rotatedCert, err := tls.Generate(identity.CommonName)
+		if err != nil {
+			return nil, err
+		}
+		rotatedTLSSigner := memorySigner.NewFromRuntime(rotatedCert.PrivateKey.(ed25519.PrivateKey))
+		nod.UpdatedNode.TLS.PubKey = rotatedTLSSigner.Public()
+		nod.UpdatedNode.TLS.Addresses = []node.TLSAddress{
+			{
+				PubKey:  nod.UpdatedNode.TLS.PubKey,
+				Address: nod.Node.TLS.Addresses[0].Address,
+			},
+		}
 		nod.UpdatedNode.Consensus.ID = nod.Node.Consensus.ID // This should remain the same or we'll get "node update not allowed".
-		nod.SignedValidReRegistration, err = node.MultiSignNode(nodeSigners, api.RegisterNodeSignatureContext, nod.UpdatedNode)
+		nod.SignedValidReRegistration, err = node.MultiSignNode(
+			[]signature.Signer{
+				nodeIdentity.NodeSigner,
+				nodeIdentity.P2PSigner,
+				nodeIdentity.ConsensusSigner,
+				rotatedTLSSigner,
+			},
+			api.RegisterNodeSignatureContext,
+			nod.UpdatedNode,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -1434,7 +1489,7 @@ func (ent *TestEntity) NewTestNodes(nCompute, nStorage int, idNonce []byte, runt
 // NewTestEntities returns the specified number of TestEntities, generated
 // deterministically from the seed.
 func NewTestEntities(seed []byte, n int) ([]*TestEntity, error) {
-	rng, err := drbg.New(crypto.SHA512, hashForDrbg(seed), nil, []byte("TestEntity"))
+	keys, err := testkeys.NewFactory(seed, nil, []byte("TestEntity"))
 	if err != nil {
 		return nil, err
 	}
@@ -1442,7 +1497,7 @@ func NewTestEntities(seed []byte, n int) ([]*TestEntity, error) {
 	entities := make([]*TestEntity, 0, n)
 	for i := 0; i < n; i++ {
 		var ent TestEntity
-		if ent.Signer, err = memorySigner.NewSigner(rng); err != nil {
+		if ent.Signer, err = keys.Next(); err != nil {
 			return nil, err
 		}
 		ent.Entity = &entity.Entity{
@@ -1797,12 +1852,6 @@ func NewTestRuntime(seed []byte, ent *TestEntity, isKeyManager bool) (*TestRunti
 	return &rt, nil
 }
 
-func hashForDrbg(seed []byte) []byte {
-	h := crypto.SHA512.New()
-	_, _ = h.Write(seed)
-	return h.Sum(nil)
-}
-
 func publicKeyToNamespace(pk signature.PublicKey, isKeyManager bool) common.Namespace {
 	flags := common.NamespaceTest
 	if isKeyManager {
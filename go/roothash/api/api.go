@@ -114,6 +114,9 @@ type Backend interface {
 	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *AnnotatedBlock, pubsub.ClosableSubscription, error)
 
 	// WatchEvents returns a stream of protocol events.
+	//
+	// The last execution discrepancy event if any will get pushed to the
+	// stream immediately in case the discrepancy has not yet been resolved.
 	WatchEvents(ctx context.Context, runtimeID common.Namespace) (<-chan *Event, pubsub.ClosableSubscription, error)
 
 	// TrackRuntime adds a runtime the history of which should be tracked.
@@ -345,6 +348,9 @@ type ExecutorCommittedEvent struct {
 type ExecutionDiscrepancyDetectedEvent struct {
 	// Timeout signals whether the discrepancy was due to a timeout.
 	Timeout bool `json:"timeout"`
+
+	// Kind is the kind of the detected discrepancy.
+	Kind commitment.DiscrepancyKind `json:"kind,omitempty"`
 }
 
 // FinalizedEvent is a finalized event.
@@ -361,6 +367,79 @@ type FinalizedEvent struct {
 	BadComputeNodes []signature.PublicKey `json:"bad_compute_nodes,omitempty"`
 }
 
+// RoundFailReason is the reason why a round has failed.
+type RoundFailReason uint8
+
+const (
+	// RoundFailReasonUnknown is the reason for round failures with an unknown cause.
+	RoundFailReasonUnknown RoundFailReason = 0
+	// RoundFailReasonProposerTimeout is the reason for round failures due to the proposer not
+	// proposing a batch in time.
+	RoundFailReasonProposerTimeout RoundFailReason = 1
+	// RoundFailReasonNoProposerCommitment is the reason for round failures due to the round
+	// timing out without the proposer submitting a commitment.
+	RoundFailReasonNoProposerCommitment RoundFailReason = 2
+	// RoundFailReasonInsufficientVotes is the reason for round failures due to discrepancy
+	// resolution not gathering enough votes.
+	RoundFailReasonInsufficientVotes RoundFailReason = 3
+	// RoundFailReasonMajorityFailure is the reason for round failures due to the majority of
+	// the discrepancy resolution commitments indicating failure (e.g., storage being
+	// unavailable).
+	RoundFailReasonMajorityFailure RoundFailReason = 4
+	// RoundFailReasonBadProposerCommitment is the reason for round failures due to the
+	// discrepancy resolution majority not agreeing with the proposer commitment.
+	RoundFailReasonBadProposerCommitment RoundFailReason = 5
+)
+
+// RoundFailReasonFromError returns the round failure reason corresponding to the given error
+// returned while trying to finalize a round.
+func RoundFailReasonFromError(err error) RoundFailReason {
+	switch err {
+	case commitment.ErrNoProposerCommitment:
+		return RoundFailReasonNoProposerCommitment
+	case commitment.ErrInsufficientVotes:
+		return RoundFailReasonInsufficientVotes
+	case commitment.ErrMajorityFailure:
+		return RoundFailReasonMajorityFailure
+	case commitment.ErrBadProposerCommitment:
+		return RoundFailReasonBadProposerCommitment
+	default:
+		return RoundFailReasonUnknown
+	}
+}
+
+// String returns a string representation of a round failure reason.
+func (r RoundFailReason) String() string {
+	switch r {
+	case RoundFailReasonUnknown:
+		return "unknown"
+	case RoundFailReasonProposerTimeout:
+		return "proposer timeout"
+	case RoundFailReasonNoProposerCommitment:
+		return "no proposer commitment"
+	case RoundFailReasonInsufficientVotes:
+		return "insufficient votes"
+	case RoundFailReasonMajorityFailure:
+		return "majority failure"
+	case RoundFailReasonBadProposerCommitment:
+		return "bad proposer commitment"
+	default:
+		return fmt.Sprintf("[unknown round fail reason: %d]", r)
+	}
+}
+
+// RoundFailedEvent is a round failed event.
+type RoundFailedEvent struct {
+	// Round is the round that failed.
+	Round uint64 `json:"round"`
+
+	// Reason is the reason why the round has failed.
+	Reason RoundFailReason `json:"reason"`
+
+	// Detail is a human-readable description of the failure.
+	Detail string `json:"detail,omitempty"`
+}
+
 // MessageEvent is a runtime message processed event.
 type MessageEvent struct {
 	Module string `json:"module,omitempty"`
@@ -383,6 +462,7 @@ type Event struct {
 	ExecutorCommitted            *ExecutorCommittedEvent            `json:"executor_committed,omitempty"`
 	ExecutionDiscrepancyDetected *ExecutionDiscrepancyDetectedEvent `json:"execution_discrepancy,omitempty"`
 	Finalized                    *FinalizedEvent                    `json:"finalized,omitempty"`
+	RoundFailed                  *RoundFailedEvent                  `json:"round_failed,omitempty"`
 	Message                      *MessageEvent                      `json:"message,omitempty"`
 }
 
@@ -394,6 +474,16 @@ type MetricsMonitorable interface {
 	// All blocks from all tracked runtimes will be pushed into the stream
 	// immediately as they are finalized.
 	WatchAllBlocks() (<-chan *block.Block, *pubsub.Subscription)
+
+	// WatchAllBlocksFrom returns a channel that produces a stream of blocks,
+	// starting with blocks from all tracked runtimes finalized at or after
+	// the given consensus height.
+	//
+	// Blocks finalized before the call are replayed first from the block
+	// histories of runtimes tracked via TrackRuntime, after which all blocks
+	// are pushed into the stream as they are finalized. In case the given
+	// height is no longer retained, an error is returned.
+	WatchAllBlocksFrom(ctx context.Context, height int64) (<-chan *block.Block, pubsub.ClosableSubscription, error)
 }
 
 // GenesisRuntimeState contains state for runtimes that are restored in a genesis block.
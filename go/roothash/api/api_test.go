@@ -589,3 +589,24 @@ func TestEquivocationExecutorEvidenceValidateBasic(t *testing.T) {
 		}
 	}
 }
+
+func TestRoundFailReasonFromError(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		err    error
+		reason RoundFailReason
+	}{
+		{commitment.ErrNoProposerCommitment, RoundFailReasonNoProposerCommitment},
+		{commitment.ErrInsufficientVotes, RoundFailReasonInsufficientVotes},
+		{commitment.ErrMajorityFailure, RoundFailReasonMajorityFailure},
+		{commitment.ErrBadProposerCommitment, RoundFailReasonBadProposerCommitment},
+		{commitment.ErrBadExecutorCommitment, RoundFailReasonUnknown},
+		{nil, RoundFailReasonUnknown},
+	} {
+		require.Equal(tc.reason, RoundFailReasonFromError(tc.err), "RoundFailReasonFromError(%v)", tc.err)
+	}
+
+	require.Equal("insufficient votes", RoundFailReasonInsufficientVotes.String())
+	require.Equal("[unknown round fail reason: 255]", RoundFailReason(255).String())
+}
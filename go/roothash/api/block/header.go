@@ -85,6 +85,18 @@ func (h *Header) IsParentOf(child *Header) bool {
 	return h.PreviousHash.Equal(&childHash)
 }
 
+// Before returns true iff the header should be ordered before the other
+// header.
+//
+// Headers are ordered by round and then by namespace, so the ordering is
+// deterministic and does not depend on the (second granularity) timestamp.
+func (h *Header) Before(other *Header) bool {
+	if h.Round != other.Round {
+		return h.Round < other.Round
+	}
+	return bytes.Compare(h.Namespace[:], other.Namespace[:]) < 0
+}
+
 // MostlyEqual compares vs another header for equality, omitting the
 // StorageSignatures field as it is not universally guaranteed to be present.
 //
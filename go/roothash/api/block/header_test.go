@@ -1,7 +1,9 @@
 package block
 
 import (
+	"bytes"
 	"math/big"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -114,3 +116,46 @@ func TestVerifyStorageReceipt(t *testing.T) {
 	err = header.VerifyStorageReceipt(&receipt)
 	require.NoError(t, err, "correct receipt")
 }
+
+func TestHeaderBefore(t *testing.T) {
+	require := require.New(t)
+
+	nsA := common.NewTestNamespaceFromSeed([]byte("header before test: a"), 0)
+	nsB := common.NewTestNamespaceFromSeed([]byte("header before test: b"), 0)
+	if bytes.Compare(nsA[:], nsB[:]) > 0 {
+		nsA, nsB = nsB, nsA
+	}
+
+	// All headers have the same timestamp.
+	const timestamp = 1580461674
+	hdrA1 := Header{Namespace: nsA, Round: 1, Timestamp: timestamp}
+	hdrA2 := Header{Namespace: nsA, Round: 2, Timestamp: timestamp}
+	hdrB1 := Header{Namespace: nsB, Round: 1, Timestamp: timestamp}
+	hdrB2 := Header{Namespace: nsB, Round: 2, Timestamp: timestamp}
+
+	for _, tc := range []struct {
+		name     string
+		a, b     *Header
+		expected bool
+	}{
+		{"LowerRound", &hdrA1, &hdrA2, true},
+		{"HigherRound", &hdrA2, &hdrA1, false},
+		{"LowerRoundHigherNamespace", &hdrB1, &hdrA2, true},
+		{"SameRoundLowerNamespace", &hdrA1, &hdrB1, true},
+		{"SameRoundHigherNamespace", &hdrB1, &hdrA1, false},
+		{"Same", &hdrA1, &hdrA1, false},
+	} {
+		require.Equal(tc.expected, tc.a.Before(tc.b), "Before (%s)", tc.name)
+	}
+
+	// Sorting should be deterministic regardless of the input order.
+	expected := []*Header{&hdrA1, &hdrB1, &hdrA2, &hdrB2}
+	for _, input := range [][]*Header{
+		{&hdrB2, &hdrA2, &hdrB1, &hdrA1},
+		{&hdrA2, &hdrB1, &hdrB2, &hdrA1},
+		{&hdrA1, &hdrB1, &hdrA2, &hdrB2},
+	} {
+		sort.Slice(input, func(i, j int) bool { return input[i].Before(input[j]) })
+		require.Equal(expected, input, "sorted headers should be deterministic")
+	}
+}
@@ -0,0 +1,58 @@
+package block
+
+// MonotonicFilter is a filter that only accepts blocks with monotonically
+// increasing rounds.
+//
+// This is useful when a block stream may contain replayed blocks, e.g., when
+// the latest block is replayed on subscription.
+type MonotonicFilter struct {
+	allowEqual bool
+
+	hasLastRound bool
+	lastRound    uint64
+}
+
+// NewMonotonicFilter creates a new monotonic round filter.
+//
+// If allowEqual is true, blocks with the same round as the last accepted block
+// are also accepted (e.g., epoch transition headers may repeat a round).
+// Otherwise rounds must be strictly increasing.
+func NewMonotonicFilter(allowEqual bool) *MonotonicFilter {
+	return &MonotonicFilter{
+		allowEqual: allowEqual,
+	}
+}
+
+// Accept returns true iff a block with the given round should be accepted and
+// if so, records it as the last accepted round.
+//
+// This method is not safe for concurrent use.
+func (f *MonotonicFilter) Accept(round uint64) bool {
+	if f.hasLastRound {
+		if round < f.lastRound || (round == f.lastRound && !f.allowEqual) {
+			return false
+		}
+	}
+
+	f.hasLastRound = true
+	f.lastRound = round
+	return true
+}
+
+// Filter returns a channel that emits all blocks from the given channel that
+// are accepted by the filter. The returned channel is closed when the source
+// channel is closed.
+func (f *MonotonicFilter) Filter(ch <-chan *Block) <-chan *Block {
+	filteredCh := make(chan *Block)
+	go func() {
+		defer close(filteredCh)
+
+		for blk := range ch {
+			if !f.Accept(blk.Header.Round) {
+				continue
+			}
+			filteredCh <- blk
+		}
+	}()
+	return filteredCh
+}
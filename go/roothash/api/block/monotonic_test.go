@@ -0,0 +1,50 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonotonicFilter(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		name       string
+		allowEqual bool
+		rounds     []uint64
+		expected   []uint64
+	}{
+		{"Empty", false, nil, nil},
+		{"Increasing", false, []uint64{0, 1, 2, 5}, []uint64{0, 1, 2, 5}},
+		{"Duplicates", false, []uint64{3, 3, 4, 4, 4, 5}, []uint64{3, 4, 5}},
+		{"OutOfOrder", false, []uint64{5, 3, 6, 4, 6, 7}, []uint64{5, 6, 7}},
+		{"DuplicatesAllowEqual", true, []uint64{3, 3, 4, 4, 5}, []uint64{3, 3, 4, 4, 5}},
+		{"OutOfOrderAllowEqual", true, []uint64{5, 3, 5, 4, 6}, []uint64{5, 5, 6}},
+	} {
+		// Accept.
+		filter := NewMonotonicFilter(tc.allowEqual)
+		var accepted []uint64
+		for _, round := range tc.rounds {
+			if filter.Accept(round) {
+				accepted = append(accepted, round)
+			}
+		}
+		require.EqualValues(tc.expected, accepted, "Accept (%s)", tc.name)
+
+		// Filter.
+		ch := make(chan *Block, len(tc.rounds))
+		for _, round := range tc.rounds {
+			var blk Block
+			blk.Header.Round = round
+			ch <- &blk
+		}
+		close(ch)
+
+		var filtered []uint64
+		for blk := range NewMonotonicFilter(tc.allowEqual).Filter(ch) {
+			filtered = append(filtered, blk.Header.Round)
+		}
+		require.EqualValues(tc.expected, filtered, "Filter (%s)", tc.name)
+	}
+}
@@ -2,6 +2,7 @@ package commitment
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -78,6 +79,38 @@ type NodeLookup interface {
 // for gas accounting.
 type MessageValidator func(msgs []message.Message) error
 
+// DiscrepancyKind is the kind of a detected discrepancy.
+type DiscrepancyKind uint8
+
+const (
+	// DiscrepancyKindUnknown is a discrepancy of an unknown kind (e.g., due to a timeout).
+	DiscrepancyKindUnknown DiscrepancyKind = 0
+	// DiscrepancyKindFailure is a discrepancy caused by a worker indicating failure.
+	DiscrepancyKindFailure DiscrepancyKind = 1
+	// DiscrepancyKindInput is a discrepancy caused by workers processing different inputs (e.g.,
+	// the same transactions in a different order).
+	DiscrepancyKindInput DiscrepancyKind = 2
+	// DiscrepancyKindOutput is a discrepancy caused by workers computing different results from
+	// the same inputs.
+	DiscrepancyKindOutput DiscrepancyKind = 3
+)
+
+// String returns a string representation of a discrepancy kind.
+func (k DiscrepancyKind) String() string {
+	switch k {
+	case DiscrepancyKindUnknown:
+		return "unknown"
+	case DiscrepancyKindFailure:
+		return "failure"
+	case DiscrepancyKindInput:
+		return "input"
+	case DiscrepancyKindOutput:
+		return "output"
+	default:
+		return fmt.Sprintf("[unknown discrepancy kind: %d]", k)
+	}
+}
+
 // Pool is a serializable pool of commitments that can be used to perform
 // discrepancy detection.
 //
@@ -615,6 +648,46 @@ func (p *Pool) getProposerCommitment() (OpenCommitment, error) {
 	return proposerCommit, nil
 }
 
+// ClassifyDiscrepancy returns the kind of the discrepancy detected between the commitments of
+// the primary workers. The first worker commitment (in committee order) that differs from the
+// proposer commitment determines the kind.
+func (p *Pool) ClassifyDiscrepancy() DiscrepancyKind {
+	if p.Committee == nil {
+		return DiscrepancyKindUnknown
+	}
+
+	proposerCommit, err := p.getProposerCommitment()
+	if err != nil {
+		return DiscrepancyKindUnknown
+	}
+	if proposerCommit.IsIndicatingFailure() {
+		return DiscrepancyKindFailure
+	}
+	proposerBody := proposerCommit.ToDDResult().(*ComputeBody)
+
+	for _, n := range p.Committee.Members {
+		if n.Role != scheduler.RoleWorker {
+			continue
+		}
+		commit, ok := p.getCommitment(n.PublicKey)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case commit.IsIndicatingFailure():
+			return DiscrepancyKindFailure
+		case proposerCommit.MostlyEqual(commit):
+			continue
+		case !commit.ToDDResult().(*ComputeBody).InputRoot.Equal(&proposerBody.InputRoot):
+			return DiscrepancyKindInput
+		default:
+			return DiscrepancyKindOutput
+		}
+	}
+	return DiscrepancyKindUnknown
+}
+
 // TryFinalize attempts to finalize the commitments by performing discrepancy
 // detection and discrepancy resolution, based on the state of the pool. It may
 // request the caller to schedule timeouts by setting NextTimeout appropriately.
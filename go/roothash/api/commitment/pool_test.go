@@ -269,6 +269,51 @@ func TestPoolSingleCommitment(t *testing.T) {
 	require.EqualValues(t, &body.Header, &header, "DD should return the same header")
 }
 
+func TestPoolReplayAfterFinalization(t *testing.T) {
+	genesisTestHelpers.SetTestChainContext()
+
+	rt, sks, committee, nl := generateMockCommittee(t, nil)
+	sk1 := sks[0]
+	sk2 := sks[1]
+
+	// Create a pool.
+	pool := Pool{
+		Runtime:   rt,
+		Committee: committee,
+		Round:     0,
+	}
+
+	// Generate a commitment.
+	childBlk, parentBlk, body := generateComputeBody(t, pool.Round)
+
+	sv := &staticSignatureVerifier{
+		storagePublicKey:      body.StorageSignatures[0].PublicKey,
+		txnSchedulerPublicKey: body.TxnSchedSig.PublicKey,
+	}
+
+	commit1, err := SignExecutorCommitment(sk1, rt.ID, &body)
+	require.NoError(t, err, "SignExecutorCommitment")
+	commit2, err := SignExecutorCommitment(sk2, rt.ID, &body)
+	require.NoError(t, err, "SignExecutorCommitment")
+
+	// Adding commitments should succeed and finalize the round.
+	err = pool.AddExecutorCommitment(context.Background(), childBlk, sv, nl, commit1, nil)
+	require.NoError(t, err, "AddExecutorCommitment")
+	err = pool.AddExecutorCommitment(context.Background(), childBlk, sv, nl, commit2, nil)
+	require.NoError(t, err, "AddExecutorCommitment")
+	_, err = pool.ProcessCommitments(false)
+	require.NoError(t, err, "ProcessCommitments")
+
+	// Transition to the next round, based on the newly finalized block.
+	pool.ResetCommitments(parentBlk.Header.Round)
+
+	// Replaying the commitment built against the old block should fail.
+	err = pool.AddExecutorCommitment(context.Background(), parentBlk, sv, nl, commit1, nil)
+	require.Error(t, err, "AddExecutorCommitment(replay)")
+	require.Equal(t, ErrNotBasedOnCorrectBlock, err, "AddExecutorCommitment(replay)")
+	require.Empty(t, pool.ExecuteCommitments, "replayed commitment should not be added")
+}
+
 func TestPoolSingleCommitmentTEE(t *testing.T) {
 	genesisTestHelpers.SetTestChainContext()
 
@@ -746,6 +791,107 @@ func TestPoolFailureIndicatingCommitment(t *testing.T) {
 	})
 }
 
+func TestPoolClassifyDiscrepancy(t *testing.T) {
+	genesisTestHelpers.SetTestChainContext()
+
+	rt, sks, committee, nl := generateMockCommittee(t, nil)
+	sk1 := sks[0]
+	sk2 := sks[1]
+
+	// detectDiscrepancy adds the given commitments to a fresh pool and ensures that a discrepancy
+	// is detected.
+	detectDiscrepancy := func(childBlk *block.Block, body1, body2 *ComputeBody) *Pool {
+		pool := Pool{
+			Runtime:   rt,
+			Committee: committee,
+			Round:     0,
+		}
+		require.Equal(t, DiscrepancyKindUnknown, pool.ClassifyDiscrepancy(), "ClassifyDiscrepancy without commitments")
+
+		commit1, err := SignExecutorCommitment(sk1, rt.ID, body1)
+		require.NoError(t, err, "SignExecutorCommitment")
+		commit2, err := SignExecutorCommitment(sk2, rt.ID, body2)
+		require.NoError(t, err, "SignExecutorCommitment")
+
+		err = pool.AddExecutorCommitment(context.Background(), childBlk, nopSV, nl, commit1, nil)
+		require.NoError(t, err, "AddExecutorCommitment")
+		err = pool.AddExecutorCommitment(context.Background(), childBlk, nopSV, nl, commit2, nil)
+		require.NoError(t, err, "AddExecutorCommitment")
+
+		_, err = pool.ProcessCommitments(false)
+		require.Equal(t, ErrDiscrepancyDetected, err, "ProcessCommitments")
+		return &pool
+	}
+
+	t.Run("NoDiscrepancy", func(t *testing.T) {
+		childBlk, _, body := generateComputeBody(t, 0)
+
+		pool := Pool{
+			Runtime:   rt,
+			Committee: committee,
+			Round:     0,
+		}
+		for _, sk := range []signature.Signer{sk1, sk2} {
+			commit, err := SignExecutorCommitment(sk, rt.ID, &body)
+			require.NoError(t, err, "SignExecutorCommitment")
+			err = pool.AddExecutorCommitment(context.Background(), childBlk, nopSV, nl, commit, nil)
+			require.NoError(t, err, "AddExecutorCommitment")
+		}
+
+		_, err := pool.ProcessCommitments(false)
+		require.NoError(t, err, "ProcessCommitments")
+		require.Equal(t, DiscrepancyKindUnknown, pool.ClassifyDiscrepancy(), "ClassifyDiscrepancy")
+	})
+
+	t.Run("Input", func(t *testing.T) {
+		childBlk, parentBlk, body := generateComputeBody(t, 0)
+
+		// Process the same inputs in a different order, resulting in a different input root and
+		// thus a different I/O root.
+		reorderedBody := body
+		reorderedInputRoot := hash.NewFromBytes([]byte("reordered inputs"))
+		reorderedIORoot := hash.NewFromBytes([]byte("reordered io root"))
+		reorderedBody.Header.IORoot = &reorderedIORoot
+		reorderedBody.StorageSignatures = []signature.Signature{generateStorageReceiptSignature(t, parentBlk, &reorderedBody)}
+		reorderedBody.InputRoot = reorderedInputRoot
+		reorderedBody.InputStorageSigs = []signature.Signature{}
+
+		sk, err := memorySigner.NewSigner(rand.Reader)
+		require.NoError(t, err, "NewSigner")
+		signedDispatch, err := SignProposedBatch(sk, rt.ID, &ProposedBatch{
+			IORoot:            reorderedBody.InputRoot,
+			StorageSignatures: reorderedBody.InputStorageSigs,
+			Header:            childBlk.Header,
+		})
+		require.NoError(t, err, "SignProposedBatch")
+		reorderedBody.TxnSchedSig = signedDispatch.Signature
+
+		pool := detectDiscrepancy(childBlk, &body, &reorderedBody)
+		require.Equal(t, DiscrepancyKindInput, pool.ClassifyDiscrepancy(), "ClassifyDiscrepancy")
+	})
+
+	t.Run("Output", func(t *testing.T) {
+		pool, _, _, _, _ := setupDiscrepancy(t, rt, sks, committee, nl, false)
+		require.Equal(t, DiscrepancyKindOutput, pool.ClassifyDiscrepancy(), "ClassifyDiscrepancy")
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		childBlk, _, body := generateComputeBody(t, 0)
+
+		failedBody := ComputeBody{
+			Header: ComputeResultsHeader{
+				Round:        body.Header.Round,
+				PreviousHash: body.Header.PreviousHash,
+			},
+			Failure: FailureStorageUnavailable,
+		}
+		failedBody.TxnSchedSig = generateTxnSchedulerSignature(t, childBlk, rt.ID, &failedBody)
+
+		pool := detectDiscrepancy(childBlk, &body, &failedBody)
+		require.Equal(t, DiscrepancyKindFailure, pool.ClassifyDiscrepancy(), "ClassifyDiscrepancy")
+	})
+}
+
 func TestPoolSerialization(t *testing.T) {
 	genesisTestHelpers.SetTestChainContext()
 
@@ -938,6 +1084,42 @@ func TestTryFinalize(t *testing.T) {
 		require.EqualValues(t, &correctHeader, &header, "DR should return the same header")
 		require.EqualValues(t, TimeoutNever, pool.NextTimeout, "NextTimeout should be TimeoutNever")
 	})
+
+	t.Run("TimeoutNoProposer", func(t *testing.T) {
+		// Create a pool.
+		pool := Pool{
+			Runtime:   rt,
+			Committee: committee,
+			Round:     0,
+		}
+
+		// Generate a commitment from the worker that is not the proposer.
+		childBlk, _, body := generateComputeBody(t, pool.Round)
+
+		proposer, err := GetTransactionScheduler(committee, pool.Round)
+		require.NoError(t, err, "GetTransactionScheduler")
+		require.NotEqual(t, sk2.Public(), proposer.PublicKey, "sk2 should not be the proposer")
+
+		commit2, err := SignExecutorCommitment(sk2, rt.ID, &body)
+		require.NoError(t, err, "SignExecutorCommitment")
+
+		err = pool.AddExecutorCommitment(context.Background(), childBlk, nopSV, nl, commit2, nil)
+		require.NoError(t, err, "AddExecutorCommitment")
+
+		_, err = pool.TryFinalize(now, roundTimeout, false, true)
+		require.Error(t, err, "TryFinalize")
+		require.Equal(t, ErrStillWaiting, err, "TryFinalize")
+		require.EqualValues(t, now+roundTimeout, pool.NextTimeout, "NextTimeout should be set")
+
+		// Without the proposer commitment, backup workers have nothing to process, so a timeout
+		// should fail the round immediately instead of transitioning to discrepancy resolution.
+		nowAfterTimeout := now + roundTimeout
+		_, err = pool.TryFinalize(nowAfterTimeout, roundTimeout, true, true)
+		require.Error(t, err, "TryFinalize")
+		require.Equal(t, ErrNoProposerCommitment, err)
+		require.Equal(t, false, pool.Discrepancy)
+		require.EqualValues(t, TimeoutNever, pool.NextTimeout, "NextTimeout should be TimeoutNever")
+	})
 }
 
 func TestExecutorTimeoutRequest(t *testing.T) {
@@ -33,6 +33,10 @@ type BlockHistory interface {
 	// GetBlock returns the block at a specific round.
 	GetBlock(ctx context.Context, round uint64) (*block.Block, error)
 
+	// GetAnnotatedBlock returns the block at a specific round, annotated with
+	// the consensus height at which it was finalized.
+	GetAnnotatedBlock(ctx context.Context, round uint64) (*AnnotatedBlock, error)
+
 	// GetLatestBlock returns the block at latest round.
 	GetLatestBlock(ctx context.Context) (*block.Block, error)
 
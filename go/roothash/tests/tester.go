@@ -24,6 +24,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -96,6 +97,26 @@ func RootHashImplementationTests(t *testing.T, backend api.Backend, consensus co
 		return
 	}
 
+	// Track all runtimes with a block history, so that replaying finalized
+	// blocks can be tested.
+	for _, v := range rtStates {
+		dataDir, err := ioutil.TempDir("", "oasis-roothash-test-history_")
+		require.NoError(err, "TempDir")
+		defer os.RemoveAll(dataDir)
+
+		bh, err := history.New(dataDir, v.rt.Runtime.ID, history.NewDefaultConfig())
+		require.NoError(err, "history.New")
+		defer bh.Close()
+
+		err = backend.TrackRuntime(context.Background(), bh)
+		require.NoError(err, "TrackRuntime")
+	}
+
+	// Remember the consensus height before the rounds below are finalized, so
+	// that replaying finalized blocks can be tested.
+	startBlk, err := consensus.GetBlock(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "GetBlock")
+
 	// It only makes sense to run the following tests in case the
 	// EpochTransitionBlock was successful. Otherwise this may leave the
 	// committees set to nil and cause a crash.
@@ -103,6 +124,10 @@ func RootHashImplementationTests(t *testing.T, backend api.Backend, consensus co
 		testSuccessfulRound(t, backend, consensus, identity, rtStates)
 	})
 
+	t.Run("WatchAllBlocksFrom", func(t *testing.T) {
+		testWatchAllBlocksFrom(t, backend, startBlk.Height+1, rtStates)
+	})
+
 	t.Run("RoundTimeout", func(t *testing.T) {
 		testRoundTimeout(t, backend, consensus, identity, rtStates)
 	})
@@ -254,6 +279,66 @@ func testSuccessfulRound(t *testing.T, backend api.Backend, consensus consensusA
 	}
 }
 
+func testWatchAllBlocksFrom(t *testing.T, backend api.Backend, height int64, states []*runtimeState) {
+	require := require.New(t)
+
+	mm, ok := backend.(api.MetricsMonitorable)
+	if !ok {
+		t.Skip("backend does not support watching all blocks")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	// Heights that are not retained should be rejected.
+	_, _, err := mm.WatchAllBlocksFrom(ctx, 0)
+	require.Error(err, "WatchAllBlocksFrom should fail for heights that are not retained")
+
+	// All runtimes have finalized a round since the given height.
+	latestRounds := make(map[common.Namespace]uint64)
+	for _, s := range states {
+		var blk *block.Block
+		blk, err = backend.GetLatestBlock(ctx, &api.RuntimeRequest{
+			RuntimeID: s.rt.Runtime.ID,
+			Height:    consensusAPI.HeightLatest,
+		})
+		require.NoError(err, "GetLatestBlock")
+		latestRounds[s.rt.Runtime.ID] = blk.Header.Round
+	}
+
+	ch, sub, err := mm.WatchAllBlocksFrom(ctx, height)
+	require.NoError(err, "WatchAllBlocksFrom")
+	defer sub.Close()
+
+	// Make sure the replay covers the finalized blocks of all runtimes.
+	seenRounds := make(map[common.Namespace]uint64)
+	done := func() bool {
+		for id, round := range latestRounds {
+			if seen, ok := seenRounds[id]; !ok || seen < round {
+				return false
+			}
+		}
+		return true
+	}
+	for !done() {
+		select {
+		case blk, ok := <-ch:
+			require.True(ok, "block channel should not be closed")
+
+			id := blk.Header.Namespace
+			if _, ok = latestRounds[id]; !ok {
+				continue
+			}
+			if seen, ok := seenRounds[id]; ok {
+				require.True(blk.Header.Round > seen, "block rounds should be monotonically increasing")
+			}
+			seenRounds[id] = blk.Header.Round
+		case <-ctx.Done():
+			t.Fatalf("failed to receive replayed blocks for all runtimes (seen: %v, expected: %v)", seenRounds, latestRounds)
+		}
+	}
+}
+
 func (s *runtimeState) generateExecutorCommitments(t *testing.T, consensus consensusAPI.Backend, identity *identity.Identity, child *block.Block) (
 	parent *block.Block,
 	executorCommits []commitment.ExecutorCommitment,
@@ -470,6 +555,10 @@ func (s *runtimeState) testRoundTimeout(t *testing.T, backend api.Backend, conse
 	require.NoError(err, "WatchBlocks")
 	defer sub.Close()
 
+	evCh, evSub, err := backend.WatchEvents(context.Background(), s.rt.Runtime.ID)
+	require.NoError(err, "WatchEvents")
+	defer evSub.Close()
+
 	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
 	defer cancel()
 
@@ -520,6 +609,9 @@ WaitForRoundTimeoutBlocks:
 			require.EqualValues(child.Header.Round+1, header.Round, "block round")
 			require.EqualValues(block.RoundFailed, header.HeaderType, "block header type must be RoundFailed")
 
+			// Discrepancy resolution should have failed due to insufficient votes.
+			waitForRoundFailedEvent(t, evCh, header.Round, api.RoundFailReasonInsufficientVotes)
+
 			// Nothing more to do after the block was received.
 			return
 		case <-time.After(recvTimeout):
@@ -626,6 +718,10 @@ func (s *runtimeState) testProposerTimeout(t *testing.T, backend api.Backend, co
 	require.NoError(err, "WatchBlocks")
 	defer sub.Close()
 
+	evCh, evSub, err := backend.WatchEvents(context.Background(), s.rt.Runtime.ID)
+	require.NoError(err, "WatchEvents")
+	defer evSub.Close()
+
 	// Wait for enough blocks so we can force trigger a timeout.
 	consBlkCh, blocksSub, err := consensus.WatchBlocks(ctx)
 	require.NoError(err, "consensus.WatchBlocks")
@@ -694,6 +790,9 @@ WaitForProposerTimeoutBlocks:
 			require.EqualValues(child.Header.Round+1, header.Round, "block round")
 			require.EqualValues(block.RoundFailed, header.HeaderType, "block header type must be RoundFailed")
 
+			// The round should have failed due to the proposer timeout.
+			waitForRoundFailedEvent(t, evCh, header.Round, api.RoundFailReasonProposerTimeout)
+
 			// Nothing more to do after the failed block was received.
 			return
 		case <-time.After(recvTimeout):
@@ -702,6 +801,26 @@ WaitForProposerTimeoutBlocks:
 	}
 }
 
+func waitForRoundFailedEvent(t *testing.T, ch <-chan *api.Event, round uint64, reason api.RoundFailReason) {
+	require := require.New(t)
+
+	for {
+		select {
+		case ev := <-ch:
+			if ev.RoundFailed == nil {
+				continue
+			}
+
+			require.EqualValues(round, ev.RoundFailed.Round, "round failed event round")
+			require.Equal(reason, ev.RoundFailed.Reason, "round failed event reason")
+			require.NotEmpty(ev.RoundFailed.Detail, "round failed event detail")
+			return
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive round failed event")
+		}
+	}
+}
+
 type testCommittee struct {
 	committee     *scheduler.Committee
 	workers       []*registryTests.TestNode
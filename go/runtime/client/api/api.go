@@ -37,6 +37,9 @@ var (
 	ErrCheckTxFailed = errors.New(ModuleName, 5, "client: transaction check failed")
 	// ErrNoHostedRuntime is returned when the hosted runtime is not available locally.
 	ErrNoHostedRuntime = errors.New(ModuleName, 6, "client: no hosted runtime is available")
+	// ErrTransactionRoundFailed is an error returned when a reliably submitted transaction has
+	// been resubmitted the maximum number of times after round failures.
+	ErrTransactionRoundFailed = errors.New(ModuleName, 7, "client: transaction round failed")
 )
 
 // RuntimeClient is the runtime client interface.
@@ -47,6 +50,12 @@ type RuntimeClient interface {
 	// for transaction execution results.
 	SubmitTx(ctx context.Context, request *SubmitTxRequest) ([]byte, error)
 
+	// SubmitTxReliable submits a transaction to the runtime transaction scheduler and waits
+	// for transaction execution results. In case a runtime round fails while the transaction
+	// is pending, the transaction is automatically resubmitted up to a configured number of
+	// times.
+	SubmitTxReliable(ctx context.Context, request *SubmitTxRequest) ([]byte, error)
+
 	// SubmitTxNoWait submits a transaction to the runtime transaction scheduler but does
 	// not wait for transaction execution.
 	SubmitTxNoWait(ctx context.Context, request *SubmitTxRequest) error
@@ -70,6 +79,13 @@ type RuntimeClient interface {
 	// block is identified by its hash instead of its round number.
 	GetTxByBlockHash(ctx context.Context, request *GetTxByBlockHashRequest) (*TxResult, error)
 
+	// GetTxByHash fetches the given runtime transaction by its transaction hash.
+	//
+	// This can be used to retrieve results of transactions previously submitted via
+	// SubmitTxNoWait. If the transaction has not (yet) been indexed, ErrNotFound is returned.
+	// In case the transaction has been included in multiple rounds, the latest one is returned.
+	GetTxByHash(ctx context.Context, request *GetTxByHashRequest) (*TxResult, error)
+
 	// GetTxs fetches all runtime transactions in a given block.
 	//
 	// DEPRECATED: This method is deprecated and may be removed in a future release, use
@@ -150,6 +166,12 @@ type GetTxByBlockHashRequest struct {
 	Index     uint32           `json:"index"`
 }
 
+// GetTxByHashRequest is a GetTxByHash request.
+type GetTxByHashRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	TxHash    hash.Hash        `json:"tx_hash"`
+}
+
 // GetTxsRequest is a GetTxs request.
 type GetTxsRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -22,6 +22,8 @@ var (
 
 	// methodSubmitTx is the SubmitTx method.
 	methodSubmitTx = serviceName.NewMethod("SubmitTx", SubmitTxRequest{})
+	// methodSubmitTxReliable is the SubmitTxReliable method.
+	methodSubmitTxReliable = serviceName.NewMethod("SubmitTxReliable", SubmitTxRequest{})
 	// methodSubmitTxNoWait is the SubmitTxNoWait method.
 	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", SubmitTxRequest{})
 	// methodCheckTx is the CheckTx method.
@@ -36,6 +38,8 @@ var (
 	methodGetTx = serviceName.NewMethod("GetTx", GetTxRequest{})
 	// methodGetTxByBlockHash is the GetTxByBlockHash method.
 	methodGetTxByBlockHash = serviceName.NewMethod("GetTxByBlockHash", GetTxByBlockHashRequest{})
+	// methodGetTxByHash is the GetTxByHash method.
+	methodGetTxByHash = serviceName.NewMethod("GetTxByHash", GetTxByHashRequest{})
 	// methodGetTxs is the GetTxs method.
 	methodGetTxs = serviceName.NewMethod("GetTxs", GetTxsRequest{})
 	// methodGetTransactions is the GetTransactions method.
@@ -63,6 +67,10 @@ var (
 				MethodName: methodSubmitTx.ShortName(),
 				Handler:    handlerSubmitTx,
 			},
+			{
+				MethodName: methodSubmitTxReliable.ShortName(),
+				Handler:    handlerSubmitTxReliable,
+			},
 			{
 				MethodName: methodSubmitTxNoWait.ShortName(),
 				Handler:    handlerSubmitTxNoWait,
@@ -91,6 +99,10 @@ var (
 				MethodName: methodGetTxByBlockHash.ShortName(),
 				Handler:    handlerGetTxByBlockHash,
 			},
+			{
+				MethodName: methodGetTxByHash.ShortName(),
+				Handler:    handlerGetTxByHash,
+			},
 			{
 				MethodName: methodGetTxs.ShortName(),
 				Handler:    handlerGetTxs,
@@ -153,6 +165,29 @@ func handlerSubmitTx( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerSubmitTxReliable( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq SubmitTxRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).SubmitTxReliable(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxReliable.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).SubmitTxReliable(ctx, req.(*SubmitTxRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerSubmitTxNoWait( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -351,6 +386,31 @@ func handlerGetTxByBlockHash( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetTxByHash( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetTxByHashRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		rsp, err := srv.(RuntimeClient).GetTxByHash(ctx, &rq)
+		return rsp, errorWrapNotFound(err)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxByHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rsp, err := srv.(RuntimeClient).GetTxByHash(ctx, req.(*GetTxByHashRequest))
+		return rsp, errorWrapNotFound(err)
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetTxs( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -564,6 +624,14 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *SubmitTxRequest)
 	return rsp, nil
 }
 
+func (c *runtimeClient) SubmitTxReliable(ctx context.Context, request *SubmitTxRequest) ([]byte, error) {
+	var rsp []byte
+	if err := c.conn.Invoke(ctx, methodSubmitTxReliable.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *runtimeClient) SubmitTxNoWait(ctx context.Context, request *SubmitTxRequest) error {
 	return c.conn.Invoke(ctx, methodSubmitTxNoWait.FullName(), request, nil)
 }
@@ -612,6 +680,14 @@ func (c *runtimeClient) GetTxByBlockHash(ctx context.Context, request *GetTxByBl
 	return &rsp, nil
 }
 
+func (c *runtimeClient) GetTxByHash(ctx context.Context, request *GetTxByHashRequest) (*TxResult, error) {
+	var rsp TxResult
+	if err := c.conn.Invoke(ctx, methodGetTxByHash.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) GetTxs(ctx context.Context, request *GetTxsRequest) ([][]byte, error) {
 	var rsp [][]byte
 	if err := c.conn.Invoke(ctx, methodGetTxs.FullName(), request, &rsp); err != nil {
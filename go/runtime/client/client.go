@@ -33,6 +33,9 @@ const (
 	// CfgMaxTransactionAge is the number of consensus blocks after which
 	// submitted transactions will be considered expired.
 	CfgMaxTransactionAge = "runtime.client.max_transaction_age"
+	// CfgMaxTransactionRetries is the maximum number of times a transaction submitted via
+	// SubmitTxReliable will be resubmitted after a round failure.
+	CfgMaxTransactionRetries = "runtime.client.max_transaction_retries"
 
 	minMaxTransactionAge = 30
 )
@@ -65,7 +68,8 @@ type runtimeClient struct {
 	txSubmitters map[common.Namespace]*txSubmitter
 	kmClients    map[common.Namespace]*keymanager.Client
 
-	maxTransactionAge int64
+	maxTransactionAge     int64
+	maxTransactionRetries uint64
 
 	logger *logging.Logger
 }
@@ -79,7 +83,7 @@ func (c *runtimeClient) tagIndexer(runtimeID common.Namespace) (tagindexer.Query
 	return rt.TagIndexer(), nil
 }
 
-func (c *runtimeClient) submitTx(ctx context.Context, request *api.SubmitTxRequest) (<-chan *txResult, error) {
+func (c *runtimeClient) submitTx(ctx context.Context, request *api.SubmitTxRequest, reliable bool) (<-chan *txResult, error) {
 	if c.common.p2p == nil {
 		return nil, fmt.Errorf("client: cannot submit transaction, p2p disabled")
 	}
@@ -108,7 +112,7 @@ func (c *runtimeClient) submitTx(ctx context.Context, request *api.SubmitTxReque
 	var ok bool
 	c.Lock()
 	if submitter, ok = c.txSubmitters[request.RuntimeID]; !ok {
-		submitter = newTxSubmitter(c.common, request.RuntimeID, c.common.p2p, c.maxTransactionAge)
+		submitter = newTxSubmitter(c.common, request.RuntimeID, c.common.p2p, c.maxTransactionAge, c.maxTransactionRetries)
 		submitter.Start()
 		c.txSubmitters[request.RuntimeID] = submitter
 	}
@@ -117,9 +121,10 @@ func (c *runtimeClient) submitTx(ctx context.Context, request *api.SubmitTxReque
 	// Send a request for watching a new runtime transaction.
 	respCh := make(chan *txResult)
 	req := &txRequest{
-		ctx:    ctx,
-		respCh: respCh,
-		req:    request,
+		ctx:      ctx,
+		respCh:   respCh,
+		req:      request,
+		reliable: reliable,
 	}
 	req.id.FromBytes(request.Data)
 	select {
@@ -135,37 +140,47 @@ func (c *runtimeClient) submitTx(ctx context.Context, request *api.SubmitTxReque
 	return respCh, nil
 }
 
+func (c *runtimeClient) waitTx(ctx context.Context, respCh <-chan *txResult) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		// The context we're working in was canceled, abort.
+		return nil, ctx.Err()
+	case <-c.common.ctx.Done():
+		// Client is shutting down.
+		return nil, fmt.Errorf("client: shutting down")
+	case resp, ok := <-respCh:
+		if !ok {
+			return nil, fmt.Errorf("client: block watch channel closed unexpectedly (unknown error)")
+		}
+		return resp.result, resp.err
+	}
+}
+
 // Implements api.RuntimeClient.
 func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxRequest) ([]byte, error) {
-	respCh, err := c.submitTx(ctx, request)
+	respCh, err := c.submitTx(ctx, request, false)
 	if err != nil {
 		return nil, err
 	}
 
 	// Wait for result.
-	for {
-		var resp *txResult
-		var ok bool
-
-		select {
-		case <-ctx.Done():
-			// The context we're working in was canceled, abort.
-			return nil, ctx.Err()
-		case <-c.common.ctx.Done():
-			// Client is shutting down.
-			return nil, fmt.Errorf("client: shutting down")
-		case resp, ok = <-respCh:
-			if !ok {
-				return nil, fmt.Errorf("client: block watch channel closed unexpectedly (unknown error)")
-			}
-			return resp.result, resp.err
-		}
+	return c.waitTx(ctx, respCh)
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) SubmitTxReliable(ctx context.Context, request *api.SubmitTxRequest) ([]byte, error) {
+	respCh, err := c.submitTx(ctx, request, true)
+	if err != nil {
+		return nil, err
 	}
+
+	// Wait for result.
+	return c.waitTx(ctx, respCh)
 }
 
 // Implements api.RuntimeClient.
 func (c *runtimeClient) SubmitTxNoWait(ctx context.Context, request *api.SubmitTxRequest) error {
-	_, err := c.submitTx(ctx, request)
+	_, err := c.submitTx(ctx, request, false)
 	return err
 }
 
@@ -314,6 +329,36 @@ func (c *runtimeClient) GetTxByBlockHash(ctx context.Context, request *api.GetTx
 	}, nil
 }
 
+// Implements api.RuntimeClient.
+func (c *runtimeClient) GetTxByHash(ctx context.Context, request *api.GetTxByHashRequest) (*api.TxResult, error) {
+	tagIndexer, err := c.tagIndexer(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	round, txIndex, err := tagIndexer.QueryTxnByHash(ctx, request.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: round})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.getTxnByHash(ctx, blk, request.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.TxResult{
+		Block:  blk,
+		Index:  txIndex,
+		Input:  tx.Input,
+		Output: tx.Output,
+	}, nil
+}
+
 // Implements api.RuntimeClient.
 func (c *runtimeClient) GetTxs(ctx context.Context, request *api.GetTxsRequest) ([][]byte, error) {
 	if request.IORoot.IsEmpty() {
@@ -641,12 +686,13 @@ func New(
 			ctx:             ctx,
 			p2p:             p2p,
 		},
-		quitCh:            make(chan struct{}),
-		hosts:             make(map[common.Namespace]*clientHost),
-		txSubmitters:      make(map[common.Namespace]*txSubmitter),
-		kmClients:         make(map[common.Namespace]*keymanager.Client),
-		maxTransactionAge: maxTransactionAge,
-		logger:            logging.GetLogger("runtime/client"),
+		quitCh:                make(chan struct{}),
+		hosts:                 make(map[common.Namespace]*clientHost),
+		txSubmitters:          make(map[common.Namespace]*txSubmitter),
+		kmClients:             make(map[common.Namespace]*keymanager.Client),
+		maxTransactionAge:     maxTransactionAge,
+		maxTransactionRetries: viper.GetUint64(CfgMaxTransactionRetries),
+		logger:                logging.GetLogger("runtime/client"),
 	}
 
 	// Create all configured runtime hosts.
@@ -667,6 +713,7 @@ func New(
 
 func init() {
 	Flags.Int64(CfgMaxTransactionAge, 1500, "number of consensus blocks after which submitted transactions will be considered expired")
+	Flags.Uint64(CfgMaxTransactionRetries, 3, "maximum number of times a reliably submitted transaction will be resubmitted after a round failure")
 
 	_ = viper.BindPFlags(Flags)
 }
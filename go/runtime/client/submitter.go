@@ -25,6 +25,11 @@ type txRequest struct {
 	req    *api.SubmitTxRequest
 	height int64
 
+	// reliable specifies whether the transaction should be resubmitted on round failures.
+	reliable bool
+	// retries is the number of times the transaction has been resubmitted.
+	retries uint64
+
 	respCh chan<- *txResult
 }
 
@@ -48,10 +53,12 @@ type txSubmitter struct {
 
 	transactions map[hash.Hash]*txRequest
 	newCh        chan *txRequest
+	publishFn    func(tx *txRequest, groupVersion int64)
 
-	maxTransactionAge int64
-	toBeChecked       []*block.Block
-	recheckTicker     *backoff.Ticker
+	maxTransactionAge     int64
+	maxTransactionRetries uint64
+	toBeChecked           []*block.Block
+	recheckTicker         *backoff.Ticker
 
 	stopCh chan struct{}
 	quitCh chan struct{}
@@ -110,6 +117,38 @@ func (w *txSubmitter) checkBlock(blk *block.Block) error {
 	return nil
 }
 
+// handleRoundFailure resubmits all pending reliable transactions as they may have been part of
+// the failed round. Transactions that have exhausted their retries are failed.
+func (w *txSubmitter) handleRoundFailure(round uint64, groupVersion int64) {
+	for key, req := range w.transactions {
+		if !req.reliable {
+			continue
+		}
+
+		if req.retries >= w.maxTransactionRetries {
+			w.logger.Debug("transaction resubmission retries exceeded",
+				"key", key,
+				"round", round,
+				"retries", req.retries,
+			)
+			req.result(&txResult{
+				err: api.ErrTransactionRoundFailed,
+			})
+			close(req.respCh)
+			delete(w.transactions, key)
+			continue
+		}
+
+		req.retries++
+		w.logger.Debug("resubmitting transaction after round failure",
+			"key", key,
+			"round", round,
+			"retries", req.retries,
+		)
+		w.publishFn(req, groupVersion)
+	}
+}
+
 func (w *txSubmitter) checkBlocks() {
 	if len(w.toBeChecked) == 0 {
 		return
@@ -212,6 +251,13 @@ func (w *txSubmitter) start() {
 			w.toBeChecked = append(w.toBeChecked, blk.Block)
 			w.checkBlocks()
 
+			// If this is a failed round, resend reliable transactions as they
+			// may have been lost.
+			if blk.Block.Header.HeaderType == block.RoundFailed {
+				w.handleRoundFailure(blk.Block.Header.Round, latestGroupVersion)
+				continue
+			}
+
 			// If this is an epoch transition block, update latest known group
 			// version and resend all transactions.
 			if blk.Block.Header.HeaderType != block.EpochTransition {
@@ -230,7 +276,7 @@ func (w *txSubmitter) start() {
 			// Republish all transactions as messages with old groupVersion will
 			// be discarded.
 			for _, req := range w.transactions {
-				w.publishTx(req, latestGroupVersion)
+				w.publishFn(req, latestGroupVersion)
 			}
 		case <-recheckCh:
 			// Recheck blocks if needed.
@@ -261,7 +307,7 @@ func (w *txSubmitter) start() {
 		case newRequest := <-w.newCh:
 			w.transactions[newRequest.id] = newRequest
 			newRequest.height = latestHeight
-			w.publishTx(newRequest, latestGroupVersion)
+			w.publishFn(newRequest, latestGroupVersion)
 		case <-w.stopCh:
 			w.logger.Info("stop requested, aborting watcher")
 			return
@@ -284,19 +330,27 @@ func (w *txSubmitter) Stop() {
 	close(w.stopCh)
 }
 
-func newTxSubmitter(common *clientCommon, id common.Namespace, p2pSvc *p2p.P2P, maxTransactionAge int64) *txSubmitter {
+func newTxSubmitter(
+	common *clientCommon,
+	id common.Namespace,
+	p2pSvc *p2p.P2P,
+	maxTransactionAge int64,
+	maxTransactionRetries uint64,
+) *txSubmitter {
 	// Register handler.
 	p2pSvc.RegisterHandler(id, &p2p.BaseHandler{})
 
 	txSubmitter := &txSubmitter{
-		logger:            logging.GetLogger("client/txsubmitter"),
-		common:            common,
-		id:                id,
-		maxTransactionAge: maxTransactionAge,
-		transactions:      make(map[hash.Hash]*txRequest),
-		newCh:             make(chan *txRequest),
-		stopCh:            make(chan struct{}),
-		quitCh:            make(chan struct{}),
+		logger:                logging.GetLogger("client/txsubmitter"),
+		common:                common,
+		id:                    id,
+		maxTransactionAge:     maxTransactionAge,
+		maxTransactionRetries: maxTransactionRetries,
+		transactions:          make(map[hash.Hash]*txRequest),
+		newCh:                 make(chan *txRequest),
+		stopCh:                make(chan struct{}),
+		quitCh:                make(chan struct{}),
 	}
+	txSubmitter.publishFn = txSubmitter.publishTx
 	return txSubmitter
 }
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+)
+
+const recvTimeout = 5 * time.Second
+
+func TestSubmitterRoundFailure(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("runtime client submitter test"), 0)
+
+	// Prepare a storage backend holding the I/O roots of executed transactions.
+	dataDir, err := ioutil.TempDir("", "oasis-runtime-client-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	cfg := storage.Config{
+		Backend:      database.BackendNameBadgerDB,
+		DB:           dataDir,
+		Namespace:    runtimeID,
+		MaxCacheSize: 16 * 1024 * 1024,
+		MemoryOnly:   true,
+	}
+	cfg.Signer, err = memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	backend, err := database.New(&cfg)
+	require.NoError(err, "database.New")
+	defer backend.Cleanup()
+
+	var published []hash.Hash
+	w := &txSubmitter{
+		logger: logging.GetLogger("client/txsubmitter/test"),
+		common: &clientCommon{
+			storage: backend,
+			ctx:     ctx,
+		},
+		id:                    runtimeID,
+		transactions:          make(map[hash.Hash]*txRequest),
+		maxTransactionRetries: 1,
+	}
+	w.publishFn = func(tx *txRequest, groupVersion int64) {
+		published = append(published, tx.id)
+	}
+
+	addRequest := func(data []byte, reliable bool) (*txRequest, <-chan *txResult) {
+		respCh := make(chan *txResult, 1)
+		req := &txRequest{
+			ctx:      ctx,
+			req:      &api.SubmitTxRequest{RuntimeID: runtimeID, Data: data},
+			reliable: reliable,
+			respCh:   respCh,
+		}
+		req.id.FromBytes(data)
+		w.transactions[req.id] = req
+		return req, respCh
+	}
+
+	waitResult := func(ch <-chan *txResult) *txResult {
+		select {
+		case res := <-ch:
+			return res
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive transaction result")
+			return nil
+		}
+	}
+
+	reliableReq, reliableCh := addRequest([]byte("reliable transaction"), true)
+	plainReq, _ := addRequest([]byte("plain transaction"), false)
+
+	// Round fails, only the reliable transaction should be resubmitted.
+	w.handleRoundFailure(1, 0)
+	require.Equal([]hash.Hash{reliableReq.id}, published, "reliable transaction should be resubmitted")
+	require.EqualValues(1, reliableReq.retries)
+	require.Len(w.transactions, 2, "no transactions should be failed")
+
+	// The resubmitted transaction is included in the next round.
+	blk := block.NewGenesisBlock(runtimeID, 0)
+	blk.Header.Round = 2
+
+	ioRoot := storage.Root{
+		Namespace: runtimeID,
+		Version:   blk.Header.Round,
+		Type:      storage.RootTypeIO,
+	}
+	ioRoot.Hash.Empty()
+	tree := transaction.NewTree(nil, ioRoot)
+	defer tree.Close()
+	err = tree.AddTransaction(ctx, transaction.Transaction{
+		Input:  reliableReq.req.Data,
+		Output: []byte("reliable output"),
+	}, nil)
+	require.NoError(err, "AddTransaction")
+	writeLog, ioRootHash, err := tree.Commit(ctx)
+	require.NoError(err, "Commit")
+	_, err = backend.Apply(ctx, &storage.ApplyRequest{
+		Namespace: runtimeID,
+		RootType:  storage.RootTypeIO,
+		SrcRound:  ioRoot.Version,
+		SrcRoot:   ioRoot.Hash,
+		DstRound:  ioRoot.Version,
+		DstRoot:   ioRootHash,
+		WriteLog:  writeLog,
+	})
+	require.NoError(err, "Apply")
+	blk.Header.IORoot = ioRootHash
+
+	err = w.checkBlock(blk)
+	require.NoError(err, "checkBlock")
+	res := waitResult(reliableCh)
+	require.NoError(res.err, "resubmitted transaction should succeed")
+	require.EqualValues([]byte("reliable output"), res.result)
+	require.NotContains(w.transactions, reliableReq.id)
+	require.Contains(w.transactions, plainReq.id)
+
+	// Transactions should be failed after exhausting all retries.
+	published = nil
+	failingReq, failingCh := addRequest([]byte("failing transaction"), true)
+	w.handleRoundFailure(3, 0)
+	require.Equal([]hash.Hash{failingReq.id}, published, "reliable transaction should be resubmitted")
+	w.handleRoundFailure(4, 0)
+	require.Len(published, 1, "transaction should not be resubmitted after exhausting retries")
+	res = waitResult(failingCh)
+	require.ErrorIs(res.err, api.ErrTransactionRoundFailed)
+	require.NotContains(w.transactions, failingReq.id)
+	require.Contains(w.transactions, plainReq.id, "plain transactions should not be affected")
+}
@@ -46,6 +46,13 @@ func ClientImplementationTests(
 		defer cancelFunc()
 		testSubmitTransactionNoWait(ctx, t, runtimeID, client, noWaitInput)
 	})
+
+	byHashInput := "cuttlefish at: " + time.Now().String()
+	t.Run("GetTxByHash", func(t *testing.T) {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), timeout)
+		defer cancelFunc()
+		testGetTxByHash(ctx, t, runtimeID, client, byHashInput)
+	})
 }
 
 func testSubmitTransaction(
@@ -246,3 +253,41 @@ func testSubmitTransactionNoWait(
 	require.EqualValues(t, testInput, tx.Input)
 	require.EqualValues(t, testOutput, tx.Output)
 }
+
+func testGetTxByHash(
+	ctx context.Context,
+	t *testing.T,
+	runtimeID common.Namespace,
+	c api.RuntimeClient,
+	input string,
+) {
+	// Based on SubmitTx and the mock worker.
+	testInput := []byte(input)
+	testOutput := testInput
+	txHash := hash.NewFromBytes(testInput)
+
+	// Transaction should not be found before it is submitted.
+	_, err := c.GetTxByHash(ctx, &api.GetTxByHashRequest{RuntimeID: runtimeID, TxHash: txHash})
+	require.Error(t, err, "GetTxByHash should fail for unknown transactions")
+	require.ErrorIs(t, err, api.ErrNotFound, "GetTxByHash should return a not found error")
+
+	// Query current block.
+	blkLatest, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: runtimeID, Round: api.RoundLatest})
+	require.NoError(t, err, "GetBlock(RoundLatest)")
+
+	// Submit a test transaction without waiting for results.
+	err = c.SubmitTxNoWait(ctx, &api.SubmitTxRequest{Data: testInput, RuntimeID: runtimeID})
+	require.NoError(t, err, "SubmitTxNoWait")
+
+	// Ensure transaction was executed.
+	err = c.WaitBlockIndexed(ctx, &api.WaitBlockIndexedRequest{RuntimeID: runtimeID, Round: blkLatest.Header.Round + 1})
+	require.NoError(t, err, "WaitBlockIndexed")
+
+	// Fetch transaction results by transaction hash.
+	tx, err := c.GetTxByHash(ctx, &api.GetTxByHashRequest{RuntimeID: runtimeID, TxHash: txHash})
+	require.NoError(t, err, "GetTxByHash")
+	require.True(t, tx.Block.Header.Round > blkLatest.Header.Round, "transaction should be in a new block")
+	require.EqualValues(t, 0, tx.Index)
+	require.EqualValues(t, testInput, tx.Input)
+	require.EqualValues(t, testOutput, tx.Output)
+}
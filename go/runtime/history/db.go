@@ -177,6 +177,24 @@ func (d *DB) commit(blk *roothash.AnnotatedBlock, roundResults *roothash.RoundRe
 			)
 		}
 
+		// Committing the last committed block again is a no-op. This makes
+		// commits idempotent so that blocks can safely be re-committed in case
+		// of a crash after the block was committed but before this was noted.
+		if meta.LastConsensusHeight != 0 && blk.Block.Header.Round == meta.LastRound {
+			var existing *roothash.AnnotatedBlock
+			existing, err = d.queryGetBlock(tx, meta.LastRound)
+			switch err {
+			case nil:
+				existingHash, newHash := existing.Block.Header.EncodedHash(), blk.Block.Header.EncodedHash()
+				if existing.Height == blk.Height && existingHash.Equal(&newHash) {
+					return nil
+				}
+			case roothash.ErrNotFound:
+			default:
+				return err
+			}
+		}
+
 		if blk.Height < meta.LastConsensusHeight {
 			return fmt.Errorf("runtime/history: commit at lower consensus height (current: %d wanted: %d)",
 				meta.LastConsensusHeight,
@@ -208,26 +226,35 @@ func (d *DB) commit(blk *roothash.AnnotatedBlock, roundResults *roothash.RoundRe
 	})
 }
 
-func (d *DB) getBlock(round uint64) (*roothash.AnnotatedBlock, error) {
+func (d *DB) queryGetBlock(tx *badger.Txn, round uint64) (*roothash.AnnotatedBlock, error) {
+	item, err := tx.Get(blockKeyFmt.Encode(round))
+	switch err {
+	case nil:
+	case badger.ErrKeyNotFound:
+		return nil, roothash.ErrNotFound
+	default:
+		return nil, err
+	}
+
 	var blk roothash.AnnotatedBlock
-	txErr := d.db.View(func(tx *badger.Txn) error {
-		item, err := tx.Get(blockKeyFmt.Encode(round))
-		switch err {
-		case nil:
-		case badger.ErrKeyNotFound:
-			return roothash.ErrNotFound
-		default:
-			return err
-		}
+	if err = item.Value(func(val []byte) error {
+		return cbor.UnmarshalTrusted(val, &blk)
+	}); err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
 
-		return item.Value(func(val []byte) error {
-			return cbor.UnmarshalTrusted(val, &blk)
-		})
+func (d *DB) getBlock(round uint64) (*roothash.AnnotatedBlock, error) {
+	var blk *roothash.AnnotatedBlock
+	txErr := d.db.View(func(tx *badger.Txn) (err error) {
+		blk, err = d.queryGetBlock(tx, round)
+		return
 	})
 	if txErr != nil {
 		return nil, txErr
 	}
-	return &blk, nil
+	return blk, nil
 }
 
 func (d *DB) getRoundResults(round uint64) (*roothash.RoundResults, error) {
@@ -80,7 +80,14 @@ func TestHistory(t *testing.T) {
 	require.NoError(err, "Commit")
 	putBlk := *blk.Block
 	err = history.Commit(&blk, roundResults)
-	require.Error(err, "Commit should fail for the same round")
+	require.NoError(err, "Commit should be idempotent for the same block")
+	dupBlk := roothash.AnnotatedBlock{
+		Height: blk.Height,
+		Block:  block.NewGenesisBlock(runtimeID, 1),
+	}
+	dupBlk.Block.Header.Round = blk.Block.Header.Round
+	err = history.Commit(&dupBlk, roundResults)
+	require.Error(err, "Commit should fail for a different block at the same round")
 	blk.Block.Header.Round = 5
 	err = history.Commit(&blk, roundResults)
 	require.Error(err, "Commit should fail for a lower round")
@@ -93,6 +100,11 @@ func TestHistory(t *testing.T) {
 	require.NoError(err, "GetBlock")
 	require.Equal(&putBlk, gotBlk, "GetBlock should return the correct block")
 
+	gotAnnBlk, err := history.GetAnnotatedBlock(context.Background(), 10)
+	require.NoError(err, "GetAnnotatedBlock")
+	require.EqualValues(50, gotAnnBlk.Height, "GetAnnotatedBlock should return the correct height")
+	require.Equal(&putBlk, gotAnnBlk.Block, "GetAnnotatedBlock should return the correct block")
+
 	gotLatestBlk, err := history.GetLatestBlock(context.Background())
 	require.NoError(err, "GetLatestBlock")
 	require.Equal(&putBlk, gotLatestBlk, "GetLatestBlock should return the correct block")
@@ -129,6 +141,28 @@ func TestHistory(t *testing.T) {
 	gotResults, err = history.GetRoundResults(context.Background(), 10)
 	require.NoError(err, "GetRoundResults")
 	require.Equal(roundResults, gotResults, "GetRoundResults should return the correct results")
+
+	// Re-committing the last block after a restart (e.g., after crashing
+	// between committing the block and notifying about it) should not result
+	// in duplicate or missing blocks.
+	blk.Block.Header.Round = 10
+	err = history.Commit(&blk, roundResults)
+	require.NoError(err, "Commit should be idempotent after reopening")
+
+	nextBlk := roothash.AnnotatedBlock{
+		Height: 51,
+		Block:  block.NewEmptyBlock(&putBlk, 0, block.Normal),
+	}
+	err = history.Commit(&nextBlk, roundResults)
+	require.NoError(err, "Commit")
+
+	for round, expected := range map[uint64]*block.Block{10: &putBlk, 11: nextBlk.Block} {
+		gotBlk, err = history.GetBlock(context.Background(), round)
+		require.NoError(err, "GetBlock")
+		require.Equal(expected, gotBlk, "GetBlock should return the correct block")
+	}
+	_, err = history.GetBlock(context.Background(), 12)
+	require.Equal(roothash.ErrNotFound, err, "GetBlock should fail for non-committed round")
 }
 
 type testPruneHandler struct {
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
@@ -21,6 +22,14 @@ type Config struct {
 	// used provisioner.
 	Path string
 
+	// ExpectedHash is the optional expected hash of the resource at Path. In case it is set, the
+	// provisioner will refuse to provision the runtime if the resource hash does not match.
+	ExpectedHash *hash.Hash
+
+	// ResourceLimits are the optional resource limits (e.g., CPU, memory) for the provisioned
+	// runtime. Provisioners that do not support resource limits ignore this field.
+	ResourceLimits *ResourceLimits
+
 	// Extra is an optional provisioner-specific configuration.
 	Extra interface{}
 
@@ -31,6 +40,26 @@ type Config struct {
 	LocalConfig map[string]interface{}
 }
 
+// ResourceLimits are the resource limits applied to a provisioned runtime.
+//
+// Any zero limit means that the given resource is not limited.
+type ResourceLimits struct {
+	// CPUShares is the relative CPU share of the runtime (using cgroup v1 semantics, in the range
+	// of 2 to 262144 where 1024 is the default share).
+	CPUShares uint64 `json:"cpu_shares,omitempty" mapstructure:"cpu_shares"`
+
+	// MemoryBytes is the maximum amount of memory (in bytes) that the runtime may use.
+	MemoryBytes uint64 `json:"memory_bytes,omitempty" mapstructure:"memory_bytes"`
+
+	// MaxProcesses is the maximum number of processes (and threads) that the runtime may spawn.
+	MaxProcesses uint64 `json:"max_processes,omitempty" mapstructure:"max_processes"`
+}
+
+// IsEmpty returns true iff no limits are configured.
+func (l *ResourceLimits) IsEmpty() bool {
+	return l == nil || (l.CPUShares == 0 && l.MemoryBytes == 0 && l.MaxProcesses == 0)
+}
+
 // Provisioner is the runtime provisioner interface.
 type Provisioner interface {
 	// NewRuntime provisions a new runtime.
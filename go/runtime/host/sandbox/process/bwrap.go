@@ -179,6 +179,10 @@ func NewBubbleWrap(cfg Config) (Process, error) {
 		Args:   cliArgs,
 		Stdout: cfg.Stdout,
 		Stderr: cfg.Stderr,
+		// The sandbox is moved into the limited cgroup before it is executed so resource limits
+		// will be inherited by the sandboxed process.
+		ResourceLimits: cfg.ResourceLimits,
+		CgroupParent:   cfg.CgroupParent,
 		// Pass all the pipe file descriptors.
 		// NOTE: Entry i becomes file descriptor 3+i.
 		extraFiles: fdPipes.pipes,
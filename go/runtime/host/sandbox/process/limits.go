@@ -0,0 +1,118 @@
+package process
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+)
+
+const (
+	cgroupFileCPUWeight = "cpu.weight"
+	cgroupFileMemoryMax = "memory.max"
+	cgroupFilePidsMax   = "pids.max"
+	cgroupFileProcs     = "cgroup.procs"
+
+	// cgroupNamePrefix is the name prefix of per-process cgroups.
+	cgroupNamePrefix = "oasis-runtime-"
+
+	// shellPath is the path to the shell used to move processes into their cgroup.
+	shellPath = "/bin/sh"
+	// cgroupWrapperScript moves the shell into the cgroup given as its first argument and then
+	// replaces the shell with the given command, so that the command executes under the limits.
+	cgroupWrapperScript = `echo $$ > "$0" && exec "$@"`
+
+	// Bounds of the cgroup v1 CPU shares and cgroup v2 CPU weight values.
+	minCPUShares = 2
+	maxCPUShares = 262144
+	minCPUWeight = 1
+	maxCPUWeight = 10000
+)
+
+// cpuSharesToWeight converts cgroup v1 CPU shares to a cgroup v2 CPU weight.
+func cpuSharesToWeight(shares uint64) uint64 {
+	switch {
+	case shares < minCPUShares:
+		shares = minCPUShares
+	case shares > maxCPUShares:
+		shares = maxCPUShares
+	}
+	return minCPUWeight + ((shares-minCPUShares)*(maxCPUWeight-minCPUWeight))/(maxCPUShares-minCPUShares)
+}
+
+// resourceCgroup is a per-process cgroup used to enforce resource limits.
+type resourceCgroup struct {
+	path string
+}
+
+// procsPath returns the path to the control file used to move processes into the cgroup.
+func (cg *resourceCgroup) procsPath() string {
+	return filepath.Join(cg.path, cgroupFileProcs)
+}
+
+// remove removes the cgroup. It must only be called after all processes in the cgroup exited.
+func (cg *resourceCgroup) remove() {
+	// Cgroup directories can only be removed via rmdir, the control files are removed implicitly.
+	_ = os.Remove(cg.path)
+}
+
+// newResourceCgroup creates a new cgroup under the given parent cgroup and configures the given
+// limits.
+//
+// Processes need to be moved into the cgroup before they are executed (see wrapResourceCgroup)
+// so that the limits are in effect from the start.
+func newResourceCgroup(cgroupParent string, limits *host.ResourceLimits) (*resourceCgroup, error) {
+	if cgroupParent == "" {
+		return nil, fmt.Errorf("resource limits configured but no parent cgroup set")
+	}
+
+	path, err := ioutil.TempDir(cgroupParent, cgroupNamePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	cg := &resourceCgroup{
+		path: path,
+	}
+
+	writeControl := func(name string, value uint64) error {
+		if err := ioutil.WriteFile(filepath.Join(cg.path, name), []byte(strconv.FormatUint(value, 10)), 0o644); err != nil { // nolint: gosec
+			return fmt.Errorf("failed to configure cgroup (%s): %w", name, err)
+		}
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			cg.remove()
+		}
+	}()
+	if limits.CPUShares > 0 {
+		if err = writeControl(cgroupFileCPUWeight, cpuSharesToWeight(limits.CPUShares)); err != nil {
+			return nil, err
+		}
+	}
+	if limits.MemoryBytes > 0 {
+		if err = writeControl(cgroupFileMemoryMax, limits.MemoryBytes); err != nil {
+			return nil, err
+		}
+	}
+	if limits.MaxProcesses > 0 {
+		if err = writeControl(cgroupFilePidsMax, limits.MaxProcesses); err != nil {
+			return nil, err
+		}
+	}
+
+	return cg, nil
+}
+
+// wrapResourceCgroup wraps the given command so that the spawned process moves itself into the
+// cgroup before executing the given binary.
+//
+// Any processes spawned by the process will inherit the cgroup.
+func wrapResourceCgroup(cg *resourceCgroup, path string, args []string) (string, []string) {
+	wrappedArgs := append([]string{"-c", cgroupWrapperScript, cg.procsPath(), path}, args...)
+	return shellPath, wrappedArgs
+}
@@ -14,6 +14,7 @@ type naked struct {
 	sync.Mutex
 
 	cmd *exec.Cmd
+	cg  *resourceCgroup
 
 	err    error
 	waitCh chan struct{}
@@ -71,7 +72,26 @@ func (n *naked) wait() error {
 // NewNaked creates a naked "sandbox" which performs no sandboxing and runs the given binary as a
 // regular child process.
 func NewNaked(cfg Config) (Process, error) {
-	cmd := exec.Command(cfg.Path, cfg.Args...) // nolint: gosec
+	path, args := cfg.Path, cfg.Args
+
+	// Prepare any resource limits. The process moves itself into the limited cgroup before the
+	// binary is executed so that the limits are in effect from the start.
+	var cg *resourceCgroup
+	if !cfg.ResourceLimits.IsEmpty() {
+		var err error
+		if cg, err = newResourceCgroup(cfg.CgroupParent, cfg.ResourceLimits); err != nil {
+			return nil, fmt.Errorf("failed to apply resource limits: %w", err)
+		}
+		path, args = wrapResourceCgroup(cg, path, args)
+	}
+	success := false
+	defer func() {
+		if !success && cg != nil {
+			cg.remove()
+		}
+	}()
+
+	cmd := exec.Command(path, args...) // nolint: gosec
 	// Setup environment variables.
 	if cfg.Env != nil {
 		for k, v := range cfg.Env {
@@ -114,10 +134,16 @@ func NewNaked(cfg Config) (Process, error) {
 
 	n := &naked{
 		cmd:    cmd,
+		cg:     cg,
 		waitCh: make(chan struct{}),
 	}
+	success = true
+
 	go func() {
 		err := n.wait()
+		if n.cg != nil {
+			n.cg.remove()
+		}
 
 		n.Lock()
 		n.err = err
@@ -6,15 +6,82 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 )
 
 func TestNakedSandbox(t *testing.T) {
 	t.Run("BindData", func(t *testing.T) {
 		testBindData(t, NewNaked, "")
 	})
+	t.Run("ResourceLimits", func(t *testing.T) {
+		testResourceLimits(t, NewNaked, "")
+	})
+}
+
+func testResourceLimits(t *testing.T, factory func(Config) (Process, error), sandboxBinary string) {
+	require := require.New(t)
+
+	// Use a regular directory in place of a cgroup as this only verifies that limits are
+	// configured. Actual enforcement depends on the environment.
+	cgroupParent, err := ioutil.TempDir("", "oasis-runtime-host-sandbox-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(cgroupParent)
+
+	limits := &host.ResourceLimits{
+		CPUShares:    1024,
+		MemoryBytes:  16 * 1024 * 1024,
+		MaxProcesses: 4,
+	}
+
+	// Resource limits without a parent cgroup should be rejected.
+	_, err = factory(Config{
+		Path:              "/bin/true",
+		ResourceLimits:    limits,
+		SandboxBinaryPath: sandboxBinary,
+	})
+	require.Error(err, "resource limits without a parent cgroup should fail")
+
+	p, err := factory(Config{
+		Path:              "/bin/true",
+		ResourceLimits:    limits,
+		CgroupParent:      cgroupParent,
+		SandboxBinaryPath: sandboxBinary,
+	})
+	require.NoError(err, "process with resource limits should start")
+	<-p.Wait()
+	require.NoError(p.Error(), "process should execute successfully")
+
+	cgroupPaths, err := filepath.Glob(filepath.Join(cgroupParent, cgroupNamePrefix+"*"))
+	require.NoError(err, "Glob")
+	require.Len(cgroupPaths, 1, "a single cgroup should be created")
+	cgroupPath := cgroupPaths[0]
+	for name, expected := range map[string]uint64{
+		cgroupFileCPUWeight: cpuSharesToWeight(limits.CPUShares),
+		cgroupFileMemoryMax: limits.MemoryBytes,
+		cgroupFilePidsMax:   limits.MaxProcesses,
+		// The process should move itself into the cgroup before executing the binary.
+		cgroupFileProcs: uint64(p.GetPID()),
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(cgroupPath, name))
+		require.NoError(err, "cgroup control file should exist (%s)", name)
+		require.EqualValues(strconv.FormatUint(expected, 10), strings.TrimSpace(string(data)), "configured limit should be reported (%s)", name)
+	}
+}
+
+func TestCPUSharesToWeight(t *testing.T) {
+	require := require.New(t)
+
+	require.EqualValues(minCPUWeight, cpuSharesToWeight(0))
+	require.EqualValues(minCPUWeight, cpuSharesToWeight(minCPUShares))
+	require.EqualValues(39, cpuSharesToWeight(1024))
+	require.EqualValues(maxCPUWeight, cpuSharesToWeight(maxCPUShares))
+	require.EqualValues(maxCPUWeight, cpuSharesToWeight(maxCPUShares+1))
 }
 
 func testBindData(t *testing.T, factory func(Config) (Process, error), sandboxBinary string) {
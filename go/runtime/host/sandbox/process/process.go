@@ -4,6 +4,8 @@ package process
 import (
 	"io"
 	"os"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 )
 
 // Config contains the sandbox configuration.
@@ -42,6 +44,15 @@ type Config struct {
 	// SandboxBinaryPath is the path to the sandbox support binary.
 	SandboxBinaryPath string
 
+	// ResourceLimits are the optional resource limits applied to the process. The limits are in
+	// effect before the binary is executed.
+	ResourceLimits *host.ResourceLimits
+
+	// CgroupParent is the path to the (delegated) cgroup v2 directory under which per-process
+	// cgroups are created in order to enforce resource limits. It is required in case any
+	// resource limits are configured.
+	CgroupParent string
+
 	extraFiles []*os.File
 }
 
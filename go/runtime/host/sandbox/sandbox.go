@@ -4,6 +4,7 @@ package sandbox
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"os"
@@ -12,9 +13,11 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
@@ -34,6 +37,22 @@ const (
 	ctrlChannelBufferSize = 16
 )
 
+var (
+	restartCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_rhp_sandbox_restarts",
+			Help: "Number of restarts of sandboxed runtimes after unexpected termination.",
+		},
+		[]string{"runtime"},
+	)
+
+	sandboxCollectors = []prometheus.Collector{
+		restartCount,
+	}
+
+	metricsOnce sync.Once
+)
+
 // Config contains the sandbox provisioner configuration options.
 type Config struct {
 	// GetSandboxConfig is a function that generates the sandbox configuration. In case it is not
@@ -56,6 +75,43 @@ type Config struct {
 
 	// InsecureNoSandbox disables the sandbox and runs the runtime binary directly.
 	InsecureNoSandbox bool
+
+	// CgroupParent is the path to the (delegated) cgroup v2 directory used to enforce per-runtime
+	// resource limits.
+	CgroupParent string
+
+	// RestartMaxBackoff is the maximum interval between attempts to restart a runtime after it
+	// has terminated unexpectedly. In case it is not specified a default is used.
+	RestartMaxBackoff time.Duration
+}
+
+// verifyRuntimeHash verifies that the runtime resource matches the expected hash (if any).
+func verifyRuntimeHash(logger *logging.Logger, cfg host.Config) error {
+	if cfg.ExpectedHash == nil {
+		return nil
+	}
+
+	f, err := os.Open(cfg.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open runtime binary: %w", err)
+	}
+	defer f.Close()
+
+	hb := hash.NewBuilder()
+	if _, err = io.Copy(hb, f); err != nil {
+		return fmt.Errorf("failed to hash runtime binary: %w", err)
+	}
+	h := hb.Build()
+
+	if !h.Equal(cfg.ExpectedHash) {
+		logger.Error("runtime binary hash mismatch",
+			"path", cfg.Path,
+			"expected_hash", cfg.ExpectedHash,
+			"hash", h,
+		)
+		return fmt.Errorf("runtime binary hash mismatch (expected: %s got: %s)", cfg.ExpectedHash, h)
+	}
+	return nil
 }
 
 type provisioner struct {
@@ -73,6 +129,7 @@ func (p *provisioner) NewRuntime(ctx context.Context, cfg host.Config) (host.Run
 		notifier: pubsub.NewBroker(false),
 		logger:   p.cfg.Logger.With("runtime_id", cfg.RuntimeID),
 	}
+	r.startFn = r.startProcess
 	return r, nil
 }
 
@@ -94,6 +151,7 @@ type sandboxedRuntime struct {
 	ctrlCh chan interface{}
 
 	started  bool
+	startFn  func() error
 	process  process.Process
 	conn     protocol.Connection
 	notifier *pubsub.Broker
@@ -183,6 +241,11 @@ func (r *sandboxedRuntime) EmitEvent(ev *host.Event) {
 }
 
 func (r *sandboxedRuntime) startProcess() (err error) {
+	// Make sure the runtime binary is the expected one.
+	if err = verifyRuntimeHash(r.logger, r.rtCfg); err != nil {
+		return err
+	}
+
 	// Create a temporary directory.
 	runtimeDir, err := ioutil.TempDir("", "oasis-runtime")
 	if err != nil {
@@ -222,6 +285,8 @@ func (r *sandboxedRuntime) startProcess() (err error) {
 		if cErr != nil {
 			return fmt.Errorf("failed to configure process: %w", cErr)
 		}
+		cfg.ResourceLimits = r.rtCfg.ResourceLimits
+		cfg.CgroupParent = r.cfg.CgroupParent
 
 		p, err = process.NewNaked(cfg)
 		if err != nil {
@@ -233,6 +298,8 @@ func (r *sandboxedRuntime) startProcess() (err error) {
 		if cErr != nil {
 			return fmt.Errorf("failed to configure sandbox: %w", cErr)
 		}
+		cfg.ResourceLimits = r.rtCfg.ResourceLimits
+		cfg.CgroupParent = r.cfg.CgroupParent
 
 		if cfg.BindRW == nil {
 			cfg.BindRW = make(map[string]string)
@@ -390,24 +457,29 @@ func (r *sandboxedRuntime) handleAbortRequest(rq *abortRequest) error {
 	return nil
 }
 
-func (r *sandboxedRuntime) manager() {
-	// Initialize a ticker channel for restarting the process. Initialize it with a closed channel
-	// so that the first time, the process will be restarted immediately.
-	var ticker *backoff.Ticker
-	var tickerCh <-chan time.Time
+// immediateCh returns a channel that is ready for receiving immediately.
+func immediateCh() <-chan time.Time {
 	ch := make(chan time.Time)
-	tickerCh = ch
 	close(ch)
+	return ch
+}
+
+func (r *sandboxedRuntime) manager() {
+	// Initialize the restart channel with a closed channel so that the first time, the process
+	// will be started immediately. Subsequent restarts after failures or unexpected termination
+	// are subject to an exponential backoff.
+	restartCh := immediateCh()
+	boff := cmnBackoff.NewExponentialBackOff()
+	if r.cfg.RestartMaxBackoff > 0 {
+		boff.MaxInterval = r.cfg.RestartMaxBackoff
+	}
+	var startedAt time.Time
 
 	defer func() {
 		r.logger.Warn("terminating runtime")
 
-		if ticker != nil {
-			ticker.Stop()
-			ticker = nil
-		}
 		if r.process != nil {
-			r.conn.Close()
+			r.closeConn()
 			r.process.Kill()
 			<-r.process.Wait()
 			r.process = nil
@@ -431,13 +503,13 @@ func (r *sandboxedRuntime) manager() {
 			case <-r.stopCh:
 				r.logger.Warn("termination requested")
 				return
-			case <-tickerCh:
+			case <-restartCh:
 				attempt++
 				r.logger.Info("starting runtime",
 					"attempt", attempt,
 				)
 
-				if err := r.startProcess(); err != nil {
+				if err := r.startFn(); err != nil {
 					r.logger.Error("failed to start runtime",
 						"err", err,
 					)
@@ -449,19 +521,13 @@ func (r *sandboxedRuntime) manager() {
 						},
 					})
 
-					if ticker == nil {
-						ticker = backoff.NewTicker(cmnBackoff.NewExponentialBackOff())
-						tickerCh = ticker.C
-					}
+					restartCh = time.After(boff.NextBackOff())
 					continue
 				}
 
 				// Runtime started successfully.
-				if ticker != nil {
-					ticker.Stop()
-					ticker = nil
-				}
 				attempt = 0
+				startedAt = time.Now()
 			}
 		}
 
@@ -473,6 +539,11 @@ func (r *sandboxedRuntime) manager() {
 				// Request to abort the runtime.
 				rq.ch <- r.handleAbortRequest(rq)
 				close(rq.ch)
+
+				// Requested restarts are performed immediately.
+				if r.process == nil {
+					restartCh = immediateCh()
+				}
 			default:
 				r.logger.Error("received unknown request type",
 					"request_type", fmt.Sprintf("%T", rq),
@@ -489,18 +560,32 @@ func (r *sandboxedRuntime) manager() {
 			)
 
 			r.Lock()
-			r.conn.Close()
+			r.closeConn()
 			r.process = nil
 			r.conn = nil
 			r.Unlock()
 
 			// Notify subscribers that the runtime has stopped.
 			r.notifier.Broadcast(&host.Event{Stopped: &host.StoppedEvent{}})
+
+			// Schedule a restart. In case the runtime has been running for long enough, the
+			// termination is not considered part of a crash loop so the backoff is reset.
+			if time.Since(startedAt) > boff.MaxInterval {
+				boff.Reset()
+			}
+			restartCh = time.After(boff.NextBackOff())
+			restartCount.With(prometheus.Labels{"runtime": r.rtCfg.RuntimeID.String()}).Inc()
 			continue
 		}
 	}
 }
 
+func (r *sandboxedRuntime) closeConn() {
+	if r.conn != nil {
+		r.conn.Close()
+	}
+}
+
 // New creates a new runtime provisioner that uses a local process sandbox.
 func New(cfg Config) (host.Provisioner, error) {
 	// Use a default GetSandboxConfig if none was provided.
@@ -537,5 +622,10 @@ func New(cfg Config) (host.Provisioner, error) {
 	if cfg.Logger == nil {
 		cfg.Logger = logging.GetLogger("runtime/host/sandbox")
 	}
+
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(sandboxCollectors...)
+	})
+
 	return &provisioner{cfg: cfg}, nil
 }
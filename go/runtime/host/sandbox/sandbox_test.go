@@ -1,9 +1,20 @@
 package sandbox
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	tendermint "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
@@ -50,3 +61,147 @@ func TestProvisionerSandbox(t *testing.T) {
 		}, nil)
 	})
 }
+
+func TestVerifyRuntimeHash(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "oasis-runtime-hash-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	binary := []byte("this is definitely a runtime binary")
+	path := filepath.Join(dir, "runtime")
+	err = ioutil.WriteFile(path, binary, 0o600)
+	require.NoError(err, "WriteFile")
+
+	logger := logging.GetLogger("runtime/host/sandbox/test")
+
+	// No expected hash.
+	err = verifyRuntimeHash(logger, host.Config{Path: path})
+	require.NoError(err, "verifyRuntimeHash should succeed without an expected hash")
+
+	// Matching hash.
+	h := hash.NewFromBytes(binary)
+	err = verifyRuntimeHash(logger, host.Config{Path: path, ExpectedHash: &h})
+	require.NoError(err, "verifyRuntimeHash should succeed with a matching hash")
+
+	// Mismatching hash.
+	h = hash.NewFromBytes([]byte("this is a different runtime binary"))
+	err = verifyRuntimeHash(logger, host.Config{Path: path, ExpectedHash: &h})
+	require.Error(err, "verifyRuntimeHash should fail with a mismatching hash")
+
+	// Missing binary.
+	err = verifyRuntimeHash(logger, host.Config{Path: filepath.Join(dir, "missing"), ExpectedHash: &h})
+	require.Error(err, "verifyRuntimeHash should fail with a missing binary")
+}
+
+type mockProcess struct {
+	sync.Once
+
+	waitCh chan struct{}
+	err    error
+}
+
+func newMockProcess() *mockProcess {
+	return &mockProcess{waitCh: make(chan struct{})}
+}
+
+func (p *mockProcess) GetPID() int {
+	return 0
+}
+
+func (p *mockProcess) Wait() <-chan struct{} {
+	return p.waitCh
+}
+
+func (p *mockProcess) Error() error {
+	return p.err
+}
+
+func (p *mockProcess) Kill() {
+	p.crash(fmt.Errorf("killed"))
+}
+
+func (p *mockProcess) crash(err error) {
+	p.Do(func() {
+		p.err = err
+		close(p.waitCh)
+	})
+}
+
+func TestRestartOnCrash(t *testing.T) {
+	require := require.New(t)
+
+	r := &sandboxedRuntime{
+		cfg: Config{
+			RestartMaxBackoff: 1 * time.Second,
+		},
+		stopCh:   make(chan struct{}),
+		quitCh:   make(chan struct{}),
+		ctrlCh:   make(chan interface{}, ctrlChannelBufferSize),
+		notifier: pubsub.NewBroker(false),
+		logger:   logging.GetLogger("runtime/host/sandbox/test"),
+	}
+
+	startedCh := make(chan *mockProcess, 2)
+	r.startFn = func() error {
+		p := newMockProcess()
+		r.process = p
+		r.notifier.Broadcast(&host.Event{Started: &host.StartedEvent{}})
+		startedCh <- p
+		return nil
+	}
+
+	evCh, sub, err := r.WatchEvents(context.Background())
+	require.NoError(err, "WatchEvents")
+	defer sub.Close()
+
+	err = r.Start()
+	require.NoError(err, "Start")
+
+	waitEvent := func(check func(*host.Event) bool, msg string) {
+		for {
+			select {
+			case ev := <-evCh:
+				if check(ev) {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				require.FailNow(msg)
+			}
+		}
+	}
+
+	// Wait for the runtime to start.
+	var p *mockProcess
+	select {
+	case p = <-startedCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow("failed to start runtime")
+	}
+	waitEvent(func(ev *host.Event) bool { return ev.Started != nil }, "failed to receive started event")
+
+	// Crash the runtime, it should be restarted.
+	p.crash(fmt.Errorf("crashed"))
+	waitEvent(func(ev *host.Event) bool { return ev.Stopped != nil }, "failed to receive stopped event")
+	select {
+	case p = <-startedCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow("failed to restart runtime after crash")
+	}
+	waitEvent(func(ev *host.Event) bool { return ev.Started != nil }, "failed to receive started event after restart")
+
+	// Stopping the runtime should not trigger a restart.
+	r.Stop()
+	select {
+	case <-r.quitCh:
+	case <-time.After(5 * time.Second):
+		require.FailNow("failed to stop runtime")
+	}
+	select {
+	case <-p.Wait():
+	default:
+		require.FailNow("runtime process should be killed on stop")
+	}
+	require.Len(startedCh, 0, "runtime should not be restarted after stop")
+}
@@ -65,6 +65,14 @@ type Config struct {
 
 	// InsecureNoSandbox disables the sandbox and runs the loader directly.
 	InsecureNoSandbox bool
+
+	// CgroupParent is the path to the (delegated) cgroup v2 directory used to enforce per-runtime
+	// resource limits.
+	CgroupParent string
+
+	// RestartMaxBackoff is the maximum interval between attempts to restart a runtime after it
+	// has terminated unexpectedly. In case it is not specified a default is used.
+	RestartMaxBackoff time.Duration
 }
 
 // RuntimeExtra is the extra configuration for SGX runtimes.
@@ -375,6 +383,8 @@ func New(cfg Config) (host.Provisioner, error) {
 		HostInfo:          cfg.HostInfo,
 		HostInitializer:   s.hostInitializer,
 		InsecureNoSandbox: cfg.InsecureNoSandbox,
+		CgroupParent:      cfg.CgroupParent,
+		RestartMaxBackoff: cfg.RestartMaxBackoff,
 		Logger:            s.logger,
 	})
 	if err != nil {
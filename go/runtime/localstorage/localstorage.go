@@ -31,10 +31,31 @@ type LocalStorage interface {
 	// Set sets a key to a specific value.
 	Set(key, value []byte) error
 
+	// Stats returns statistics about the local storage database.
+	//
+	// The sizes are cheap estimates maintained by the database and may lag behind recent writes,
+	// while the number of keys is counted by iterating over all keys (but not values).
+	Stats() (*Stats, error)
+
+	// Compact compacts the local storage database and reclaims unused space.
+	Compact() error
+
 	// Stop stops local storage.
 	Stop()
 }
 
+const compactDiscardRatio = 0.5
+
+// Stats are the local storage database statistics.
+type Stats struct {
+	// NumKeys is the number of keys.
+	NumKeys uint64 `json:"num_keys"`
+	// LSMSize is the estimated size of the LSM tree in bytes.
+	LSMSize int64 `json:"lsm_size"`
+	// ValueLogSize is the estimated size of the value log in bytes.
+	ValueLogSize int64 `json:"vlog_size"`
+}
+
 type localStorage struct {
 	logger *logging.Logger
 
@@ -92,6 +113,60 @@ func (s *localStorage) Set(key, value []byte) error {
 	return nil
 }
 
+func (s *localStorage) Stats() (*Stats, error) {
+	var numKeys uint64
+	if err := s.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			numKeys++
+		}
+		return nil
+	}); err != nil {
+		s.logger.Error("failed to count keys",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	lsmSize, vlogSize := s.db.Size()
+
+	return &Stats{
+		NumKeys:      numKeys,
+		LSMSize:      lsmSize,
+		ValueLogSize: vlogSize,
+	}, nil
+}
+
+func (s *localStorage) Compact() error {
+	if err := s.db.Flatten(1); err != nil {
+		s.logger.Error("failed to flatten local storage",
+			"err", err,
+		)
+		return err
+	}
+
+	for {
+		err := s.db.RunValueLogGC(compactDiscardRatio)
+		switch err {
+		case nil:
+			continue
+		case badger.ErrNoRewrite, badger.ErrRejected:
+			// Nothing more to collect or the background GC worker is already running.
+			return nil
+		default:
+			s.logger.Error("failed to GC local storage value log",
+				"err", err,
+			)
+			return err
+		}
+	}
+}
+
 func (s *localStorage) Stop() {
 	s.gc.Close()
 	if err := s.db.Close(); err != nil {
@@ -0,0 +1,59 @@
+package localstorage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+func TestStatsAndCompact(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-localstorage-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("local storage test ns"), 0)
+	ls, err := New(dataDir, "local_storage.badger.db", runtimeID)
+	require.NoError(err, "New")
+	defer ls.Stop()
+
+	const numEntries = 100
+	for i := 0; i < numEntries; i++ {
+		err = ls.Set([]byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d", i)))
+		require.NoError(err, "Set")
+	}
+	// Overwrite some entries to generate garbage.
+	for i := 0; i < numEntries/2; i++ {
+		err = ls.Set([]byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("new value %d", i)))
+		require.NoError(err, "Set")
+	}
+
+	checkStats := func() {
+		stats, err := ls.Stats()
+		require.NoError(err, "Stats")
+		require.EqualValues(numEntries, stats.NumKeys, "number of keys should be correct")
+		require.True(stats.LSMSize >= 0, "LSM size should be non-negative")
+		require.True(stats.ValueLogSize >= 0, "value log size should be non-negative")
+	}
+	checkStats()
+
+	err = ls.Compact()
+	require.NoError(err, "Compact")
+
+	checkStats()
+	for i := 0; i < numEntries; i++ {
+		expected := fmt.Sprintf("value %d", i)
+		if i < numEntries/2 {
+			expected = "new " + expected
+		}
+		value, err := ls.Get([]byte(fmt.Sprintf("key %d", i)))
+		require.NoError(err, "Get")
+		require.EqualValues(expected, value, "data should not be lost after compaction")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
@@ -37,8 +38,19 @@ const (
 	// The value should be a map of runtime IDs to corresponding resource paths (type of the
 	// resource depends on the provisioner).
 	CfgRuntimePaths = "runtime.paths"
+	// CfgRuntimeHashes configures the expected hashes of supported runtime resources.
+	//
+	// The value should be a map of runtime IDs to corresponding hex-encoded hashes. In case a hash
+	// is configured for a runtime, the runtime will not be started unless its resource matches.
+	CfgRuntimeHashes = "runtime.hashes"
 	// CfgSandboxBinary configures the runtime sandbox binary location.
 	CfgSandboxBinary = "runtime.sandbox.binary"
+	// CfgSandboxCgroupParent configures the (delegated) cgroup v2 directory used to enforce
+	// per-runtime resource limits.
+	CfgSandboxCgroupParent = "runtime.sandbox.cgroup_parent"
+	// CfgSandboxRestartMaxBackoff configures the maximum interval between attempts to restart a
+	// runtime after it has terminated unexpectedly.
+	CfgSandboxRestartMaxBackoff = "runtime.sandbox.restart_max_backoff"
 	// CfgRuntimeSGXLoader configures the runtime loader binary required for SGX runtimes.
 	//
 	// The same loader is used for all runtimes.
@@ -50,6 +62,11 @@ const (
 
 	// CfgRuntimeConfig configures node-local runtime configuration.
 	CfgRuntimeConfig = "runtime.config"
+	// CfgRuntimeResourceLimits configures per-runtime resource limits.
+	//
+	// The value should be a map of runtime IDs to corresponding resource limits. Enforcing
+	// resource limits requires the sandbox cgroup parent to be configured.
+	CfgRuntimeResourceLimits = "runtime.resource_limits"
 
 	// CfgHistoryPrunerStrategy configures the history pruner strategy.
 	CfgHistoryPrunerStrategy = "runtime.history.pruner.strategy"
@@ -61,6 +78,9 @@ const (
 
 	// CfgTagIndexerBackend configures the history tag indexer backend.
 	CfgTagIndexerBackend = "runtime.history.tag_indexer.backend"
+	// CfgTagIndexerRuntimes configures the runtimes for which the tag indexer is initially
+	// enabled. In case no runtimes are configured, the tag indexer is enabled for all runtimes.
+	CfgTagIndexerRuntimes = "runtime.history.tag_indexer.runtimes"
 )
 
 // Flags has the configuration flags.
@@ -92,6 +112,10 @@ type RuntimeConfig struct {
 
 	// TagIndexer configures the tag indexer backend.
 	TagIndexer tagindexer.BackendFactory
+
+	// TagIndexerRuntimes is the set of runtimes for which the tag indexer is initially enabled.
+	// In case it is nil, the tag indexer is enabled for all runtimes.
+	TagIndexerRuntimes map[common.Namespace]bool
 }
 
 // RuntimeHostConfig is configuration for a node that hosts runtimes.
@@ -129,6 +153,8 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 		// Register provisioners based on the configured provisioner.
 		var insecureNoSandbox bool
 		sandboxBinary := viper.GetString(CfgSandboxBinary)
+		cgroupParent := viper.GetString(CfgSandboxCgroupParent)
+		restartMaxBackoff := viper.GetDuration(CfgSandboxRestartMaxBackoff)
 		rh.Provisioners = make(map[node.TEEHardware]runtimeHost.Provisioner)
 		switch p := viper.GetString(CfgRuntimeProvisioner); p {
 		case RuntimeProvisionerMock:
@@ -159,6 +185,8 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 				HostInfo:          hostInfo,
 				InsecureNoSandbox: insecureNoSandbox,
 				SandboxBinaryPath: sandboxBinary,
+				CgroupParent:      cgroupParent,
+				RestartMaxBackoff: restartMaxBackoff,
 			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to create runtime provisioner: %w", err)
@@ -171,6 +199,8 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 					HostInfo:          hostInfo,
 					InsecureNoSandbox: insecureNoSandbox,
 					SandboxBinaryPath: sandboxBinary,
+					CgroupParent:      cgroupParent,
+					RestartMaxBackoff: restartMaxBackoff,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("failed to create runtime provisioner: %w", err)
@@ -183,6 +213,8 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 					IAS:               ias,
 					SandboxBinaryPath: sandboxBinary,
 					InsecureNoSandbox: insecureNoSandbox,
+					CgroupParent:      cgroupParent,
+					RestartMaxBackoff: restartMaxBackoff,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("failed to create SGX runtime provisioner: %w", err)
@@ -194,6 +226,7 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 
 		// Configure runtimes.
 		runtimeSGXSignatures := viper.GetStringMapString(CfgRuntimeSGXSignatures)
+		runtimeHashes := viper.GetStringMapString(CfgRuntimeHashes)
 		rh.Runtimes = make(map[common.Namespace]*runtimeHost.Config)
 		for runtimeID, path := range viper.GetStringMapString(CfgRuntimePaths) {
 			var id common.Namespace
@@ -209,10 +242,31 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 				}
 			}
 
+			// Unmarshal any resource limits.
+			var resourceLimits *runtimeHost.ResourceLimits
+			if sub := viper.Sub(CfgRuntimeResourceLimits); sub != nil && sub.IsSet(runtimeID) {
+				resourceLimits = new(runtimeHost.ResourceLimits)
+				if err := sub.UnmarshalKey(runtimeID, resourceLimits); err != nil {
+					return nil, fmt.Errorf("bad runtime resource limits: %w", err)
+				}
+				if !resourceLimits.IsEmpty() && cgroupParent == "" {
+					return nil, fmt.Errorf("runtime resource limits require %s to be set", CfgSandboxCgroupParent)
+				}
+			}
+
 			runtimeHostCfg := &runtimeHost.Config{
-				RuntimeID:   id,
-				Path:        path,
-				LocalConfig: localConfig,
+				RuntimeID:      id,
+				Path:           path,
+				LocalConfig:    localConfig,
+				ResourceLimits: resourceLimits,
+			}
+
+			if hashHex := runtimeHashes[runtimeID]; hashHex != "" {
+				var h hash.Hash
+				if err := h.UnmarshalHex(hashHex); err != nil {
+					return nil, fmt.Errorf("bad runtime hash for runtime '%s': %w", runtimeID, err)
+				}
+				runtimeHostCfg.ExpectedHash = &h
 			}
 
 			// This config is SGX specific, but that's all that's supported
@@ -264,6 +318,17 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 		return nil, fmt.Errorf("runtime/registry: unknown tag indexer backend: %s", tagIndexer)
 	}
 
+	if runtimeIDs := viper.GetStringSlice(CfgTagIndexerRuntimes); len(runtimeIDs) > 0 {
+		cfg.TagIndexerRuntimes = make(map[common.Namespace]bool)
+		for _, runtimeID := range runtimeIDs {
+			var id common.Namespace
+			if err := id.UnmarshalHex(runtimeID); err != nil {
+				return nil, fmt.Errorf("runtime/registry: bad tag indexer runtime identifier '%s': %w", runtimeID, err)
+			}
+			cfg.TagIndexerRuntimes[id] = true
+		}
+	}
+
 	return &cfg, nil
 }
 
@@ -272,7 +337,10 @@ func init() {
 
 	Flags.String(CfgRuntimeProvisioner, RuntimeProvisionerSandboxed, "Runtime provisioner to use")
 	Flags.StringToString(CfgRuntimePaths, nil, "Paths to runtime resources (format: <rt1-ID>=<path>,<rt2-ID>=<path>)")
+	Flags.StringToString(CfgRuntimeHashes, nil, "Expected hashes of runtime resources (format: <rt1-ID>=<hash>,<rt2-ID>=<hash>)")
 	Flags.String(CfgSandboxBinary, "/usr/bin/bwrap", "Path to the sandbox binary (bubblewrap)")
+	Flags.String(CfgSandboxCgroupParent, "", "Path to the (delegated) cgroup v2 directory used to enforce runtime resource limits")
+	Flags.Duration(CfgSandboxRestartMaxBackoff, 0, "Maximum interval between runtime restart attempts (0 = default)")
 	Flags.String(CfgRuntimeSGXLoader, "", "(for SGX runtimes) Path to SGXS runtime loader binary")
 	Flags.StringToString(CfgRuntimeSGXSignatures, nil, "(for SGX runtimes) Paths to signatures (format: <rt1-ID>=<path>,<rt2-ID>=<path>")
 
@@ -281,6 +349,7 @@ func init() {
 	Flags.Uint64(CfgHistoryPrunerKeepLastNum, 600, "Keep last history pruner: number of last rounds to keep")
 
 	Flags.String(CfgTagIndexerBackend, "", "Runtime tag indexer backend (disabled by default)")
+	Flags.StringSlice(CfgTagIndexerRuntimes, nil, "Runtimes to enable the tag indexer for (hex-encoded, all by default)")
 
 	_ = viper.BindPFlags(Flags)
 }
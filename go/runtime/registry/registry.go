@@ -69,6 +69,12 @@ type Registry interface {
 	// FinishInitialization finalizes setup for all runtimes and starts their
 	// tag indexers.
 	FinishInitialization(ctx context.Context) error
+
+	// SetTagIndexerEnabled enables or disables the tag indexer for the given runtime.
+	//
+	// When the tag indexer is enabled after being disabled, all blocks available in the runtime
+	// history are (re)indexed in the background starting with the genesis block.
+	SetTagIndexerEnabled(runtimeID common.Namespace, enabled bool) error
 }
 
 // Runtime is the running node's supported runtime interface.
@@ -395,7 +401,7 @@ func (r *runtime) finishInitialization(ctx context.Context, ident *identity.Iden
 	defer r.Unlock()
 
 	if r.storage == nil {
-		storageBackend, err := client.NewForPublicStorage(ctx, r.id, ident, r.consensus, r)
+		storageBackend, err := client.NewForPublicStorage(ctx, r.id, ident, r.consensus, r, client.NewOptionsFromConfig()...)
 		if err != nil {
 			return fmt.Errorf("runtime/registry: cannot create storage for runtime %s: %w", r.id, err)
 		}
@@ -435,6 +441,18 @@ func (r *runtimeRegistry) GetRuntime(runtimeID common.Namespace) (Runtime, error
 	return rt, nil
 }
 
+func (r *runtimeRegistry) SetTagIndexerEnabled(runtimeID common.Namespace, enabled bool) error {
+	r.RLock()
+	defer r.RUnlock()
+
+	rt := r.runtimes[runtimeID]
+	if rt == nil {
+		return fmt.Errorf("runtime/registry: runtime %s is not supported", runtimeID)
+	}
+	rt.tagIndexer.SetEnabled(enabled)
+	return nil
+}
+
 func (r *runtimeRegistry) Runtimes() []Runtime {
 	r.RLock()
 	defer r.RUnlock()
@@ -561,6 +579,9 @@ func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Nam
 	if err != nil {
 		return fmt.Errorf("runtime/registry: cannot create tag indexer for runtime %s: %w", id, err)
 	}
+	if r.cfg.TagIndexerRuntimes != nil && !r.cfg.TagIndexerRuntimes[id] {
+		tagIndexer.SetEnabled(false)
+	}
 
 	// Start tracking this runtime.
 	if err = r.consensus.RootHash().TrackRuntime(ctx, history); err != nil {
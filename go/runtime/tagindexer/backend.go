@@ -53,6 +53,12 @@ type QueryableBackend interface {
 	// identified by its block round and index.
 	QueryTxnByIndex(ctx context.Context, round uint64, index uint32) (hash.Hash, error)
 
+	// QueryTxnByHash queries the transaction tag index for the block round and index of a
+	// transaction identified by its hash.
+	//
+	// In case the transaction has been included in multiple rounds, the latest one is returned.
+	QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error)
+
 	// QueryTxns queries the transaction tag index of a given runtime with a complex
 	// query and returns multiple results.
 	//
@@ -119,6 +125,10 @@ func (n *nopBackend) QueryTxnByIndex(ctx context.Context, round uint64, index ui
 	return hash.Hash{}, errNopBackend
 }
 
+func (n *nopBackend) QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error) {
+	return 0, 0, errNopBackend
+}
+
 func (n *nopBackend) QueryTxns(ctx context.Context, query api.Query) (Results, error) {
 	return nil, errNopBackend
 }
@@ -87,6 +87,14 @@ func testOperations(t *testing.T, backend Backend) {
 	require.NoError(t, err, "QueryTxnByIndex")
 	require.EqualValues(t, tx2Hash, txnHash)
 
+	round, txnIndex, err = backend.QueryTxnByHash(ctx, tx2Hash)
+	require.NoError(t, err, "QueryTxnByHash")
+	require.EqualValues(t, 42, round)
+	require.EqualValues(t, 1, txnIndex)
+
+	_, _, err = backend.QueryTxnByHash(ctx, tx3Hash)
+	require.Equal(t, api.ErrNotFound, err, "QueryTxnByHash must return a not found error")
+
 	var blockHash2 hash.Hash
 	blockHash2.FromBytes([]byte("this is a fake block hash 2"))
 
@@ -115,6 +123,11 @@ func testOperations(t *testing.T, backend Backend) {
 	require.EqualValues(t, tx3Hash, txnHash)
 	require.EqualValues(t, 0, txnIndex)
 
+	round, txnIndex, err = backend.QueryTxnByHash(ctx, tx3Hash)
+	require.NoError(t, err, "QueryTxnByHash")
+	require.EqualValues(t, 43, round)
+	require.EqualValues(t, 0, txnIndex)
+
 	round, err = backend.QueryBlock(ctx, blockHash1)
 	require.NoError(t, err, "QueryBlock")
 	require.EqualValues(t, 42, round)
@@ -148,6 +161,29 @@ func testOperations(t *testing.T, backend Backend) {
 	require.Len(t, results[42], 2)
 	require.Contains(t, results[42], Result{TxHash: tx1Hash, TxIndex: 0})
 	require.Contains(t, results[42], Result{TxHash: tx2Hash, TxIndex: 1})
+
+	// Include the same transaction again in a later round.
+	var blockHash3 hash.Hash
+	blockHash3.FromBytes([]byte("this is a fake block hash 3"))
+
+	err = backend.Index(
+		ctx,
+		44,
+		blockHash3,
+		// Transactions.
+		[]*transaction.Transaction{
+			{Input: tx3, Output: tx3},
+			{Input: tx1, Output: tx1},
+		},
+		// Tags.
+		transaction.Tags{},
+	)
+	require.NoError(t, err, "Index")
+
+	round, txnIndex, err = backend.QueryTxnByHash(ctx, tx1Hash)
+	require.NoError(t, err, "QueryTxnByHash")
+	require.EqualValues(t, 44, round, "QueryTxnByHash should return the latest round")
+	require.EqualValues(t, 1, txnIndex)
 }
 
 func testLoadIndex(t *testing.T, backend Backend) {
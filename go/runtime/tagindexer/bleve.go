@@ -59,6 +59,7 @@ const (
 	// docTypeTx is the transaction document type.
 	docTypeTx = "tx"
 
+	fieldTxHash  = "TxHash"
 	fieldTxIndex = "TxIndex"
 	fieldTags    = "Tags"
 )
@@ -131,20 +132,27 @@ func (b *bleveBackend) Index(
 		txIndices[tx.Hash()] = uint32(idx)
 	}
 
-	// Generate documents for transactions.
+	// Generate documents for transactions. Transactions without any tags are indexed as well so
+	// that they can be queried by their hash.
 	txDocs := make(map[hash.Hash]txDocument)
+	for txHash, txIndex := range txIndices {
+		txHash := txHash
+		txDocs[txHash] = txDocument{
+			ID:      string(txDocIDKeyFmt.Encode(round, &txHash, txIndex)),
+			Kind:    docTypeTx,
+			Round:   round,
+			TxHash:  string(txHash[:]),
+			TxIndex: txIndex,
+			Tags:    make(map[string][]string),
+		}
+	}
 	for _, tag := range tags {
 		doc, ok := txDocs[tag.TxHash]
 		if !ok {
-			doc.Kind = docTypeTx
-			doc.ID = string(txDocIDKeyFmt.Encode(round, &tag.TxHash, txIndices[tag.TxHash]))
-			doc.Round = round
-			doc.TxHash = string(tag.TxHash[:])
-			doc.TxIndex = txIndices[tag.TxHash]
-			doc.Tags = make(map[string][]string)
+			// Ignore tags for unknown transactions.
+			continue
 		}
 		doc.Tags[string(tag.Key)] = append(doc.Tags[string(tag.Key)], string(tag.Value))
-		txDocs[tag.TxHash] = doc
 	}
 
 	// Generate one document for the block itself.
@@ -255,6 +263,35 @@ func (b *bleveBackend) QueryTxnByIndex(ctx context.Context, round uint64, index
 	return decTxHash, nil
 }
 
+func (b *bleveBackend) QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error) {
+	// Filter by transaction hash.
+	qTxHash := bleve.NewTermQuery(string(txHash[:]))
+	qTxHash.SetField(fieldTxHash)
+
+	q := bleve.NewConjunctionQuery(queryByKindTx, qTxHash)
+	rq := bleve.NewSearchRequest(q)
+	rq.Size = 1
+	// In case the same transaction has been included in multiple rounds, return the latest one.
+	rq.SortBy([]string{"-" + fieldRound, "-" + fieldTxIndex})
+
+	result, err := b.index.SearchInContext(ctx, rq)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(result.Hits) == 0 {
+		return 0, 0, api.ErrNotFound
+	}
+
+	var decRound uint64
+	var decTxHash hash.Hash
+	var decTxIndex uint32
+	if !txDocIDKeyFmt.Decode([]byte(result.Hits[0].ID), &decRound, &decTxHash, &decTxIndex) {
+		return 0, 0, ErrCorrupted
+	}
+
+	return decRound, decTxIndex, nil
+}
+
 func (b *bleveBackend) QueryTxns(ctx context.Context, query api.Query) (Results, error) {
 	qs := []bleveQuery.Query{queryByKindTx}
 
@@ -3,6 +3,9 @@ package tagindexer
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -11,7 +14,9 @@ import (
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
@@ -31,14 +36,153 @@ type Service struct {
 
 	runtimeID common.Namespace
 	backend   Backend
+	history   history.History
 	roothash  roothash.Backend
 
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 
+	enabledLock sync.Mutex
+	enabled     bool
+	enabledCh   chan struct{}
+
 	stopCh chan struct{}
 }
 
+// SetEnabled enables or disables indexing of new blocks.
+//
+// When indexing is enabled after being disabled, all blocks available in the runtime history are
+// (re)indexed in the background starting with the genesis block, while new blocks continue to be
+// indexed as they are finalized.
+func (s *Service) SetEnabled(enabled bool) {
+	s.enabledLock.Lock()
+	defer s.enabledLock.Unlock()
+
+	if s.enabled == enabled {
+		return
+	}
+	s.enabled = enabled
+
+	s.Logger.Info("updated tag indexer status",
+		"enabled", enabled,
+	)
+
+	// Notify the worker (if any).
+	select {
+	case s.enabledCh <- struct{}{}:
+	default:
+	}
+}
+
+// IsEnabled returns true iff indexing of new blocks is enabled.
+func (s *Service) IsEnabled() bool {
+	s.enabledLock.Lock()
+	defer s.enabledLock.Unlock()
+
+	return s.enabled
+}
+
+func (s *Service) indexBlock(ctx context.Context, storageBackend storage.Backend, blk *block.Block) error {
+	// Fetch transactions from storage.
+	//
+	// NOTE: Currently the indexer requires all transactions as well since it needs to
+	//       expose a notion of a "transaction index within a block" which is hard to
+	//       provide as batches can be merged in arbitrary order and the sequence can
+	//       only be known after the fact.
+	var (
+		txs  []*transaction.Transaction
+		tags transaction.Tags
+		err  error
+	)
+	if !blk.Header.IORoot.IsEmpty() {
+		off := cmnBackoff.NewExponentialBackOff()
+		off.MaxElapsedTime = storageRetryTimeout
+
+		err = backoff.Retry(func() error {
+			bctx, cancel := context.WithTimeout(ctx, storageRequestTimeout)
+			defer cancel()
+
+			// Prioritize nodes that signed the storage receipt.
+			bctx = storage.WithNodePriorityHintFromSignatures(bctx, blk.Header.StorageSignatures)
+
+			ioRoot := storage.Root{
+				Namespace: blk.Header.Namespace,
+				Version:   blk.Header.Round,
+				Type:      storage.RootTypeIO,
+				Hash:      blk.Header.IORoot,
+			}
+
+			tree := transaction.NewTree(storageBackend, ioRoot)
+			defer tree.Close()
+
+			txs, err = tree.GetTransactions(bctx)
+			if err != nil {
+				return err
+			}
+
+			tags, err = tree.GetTags(bctx)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		}, backoff.WithContext(off, ctx))
+
+		if err != nil {
+			return fmt.Errorf("can't get I/O root from storage: %w", err)
+		}
+	}
+
+	if err = s.backend.Index(ctx, blk.Header.Round, blk.Header.EncodedHash(), txs, tags); err != nil {
+		return fmt.Errorf("failed to index tags: %w", err)
+	}
+	return nil
+}
+
+// reindex indexes all blocks available in the runtime history, starting with the genesis block.
+func (s *Service) reindex(ctx context.Context, storageBackend storage.Backend) error {
+	genesisBlk, err := s.roothash.GetGenesisBlock(ctx, &roothash.RuntimeRequest{
+		RuntimeID: s.runtimeID,
+		Height:    consensus.HeightLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get genesis block: %w", err)
+	}
+	latestBlk, err := s.history.GetLatestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	s.Logger.Info("reindexing blocks",
+		"start_round", genesisBlk.Header.Round,
+		"end_round", latestBlk.Header.Round,
+	)
+
+	for round := genesisBlk.Header.Round; round <= latestBlk.Header.Round; round++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		blk, err := s.history.GetBlock(ctx, round)
+		switch {
+		case err == nil:
+		case errors.Is(err, roothash.ErrNotFound):
+			// Block may have been pruned.
+			continue
+		default:
+			return fmt.Errorf("failed to get block %d: %w", round, err)
+		}
+
+		if err = s.indexBlock(ctx, storageBackend, blk); err != nil {
+			s.Logger.Error("failed to reindex block",
+				"err", err,
+				"round", round,
+			)
+		}
+	}
+	return nil
+}
+
 func (s *Service) worker(storageBackend storage.Backend) {
 	defer s.BaseBackgroundService.Stop()
 
@@ -54,68 +198,62 @@ func (s *Service) worker(storageBackend storage.Backend) {
 	}
 	defer blocksSub.Close()
 
+	// Reindexing is performed in the background so that new blocks are indexed meanwhile. It is
+	// canceled when indexing is disabled again or the service is stopped.
+	var (
+		cancelReindex context.CancelFunc
+		reindexDoneCh chan struct{}
+	)
+	stopReindex := func() {
+		if cancelReindex == nil {
+			return
+		}
+		cancelReindex()
+		<-reindexDoneCh
+		cancelReindex = nil
+	}
+	defer stopReindex()
+
+	enabled := s.IsEnabled()
 	for {
 		select {
 		case <-s.stopCh:
 			s.Logger.Info("stop requested, terminating indexer")
 			return
-		case annBlk := <-blocksCh:
-			// New blocks to index.
-			blk := annBlk.Block
+		case <-s.enabledCh:
+			// Indexing status has changed.
+			wasEnabled := enabled
+			enabled = s.IsEnabled()
+			if !enabled {
+				stopReindex()
+				continue
+			}
+			if wasEnabled {
+				continue
+			}
 
-			// Fetch transactions from storage.
-			//
-			// NOTE: Currently the indexer requires all transactions as well since it needs to
-			//       expose a notion of a "transaction index within a block" which is hard to
-			//       provide as batches can be merged in arbitrary order and the sequence can
-			//       only be known after the fact.
-			var txs []*transaction.Transaction
-			var tags transaction.Tags
-			if !blk.Header.IORoot.IsEmpty() {
-				off := cmnBackoff.NewExponentialBackOff()
-				off.MaxElapsedTime = storageRetryTimeout
-
-				err = backoff.Retry(func() error {
-					bctx, cancel := context.WithTimeout(s.ctx, storageRequestTimeout)
-					defer cancel()
-
-					// Prioritize nodes that signed the storage receipt.
-					bctx = storage.WithNodePriorityHintFromSignatures(bctx, blk.Header.StorageSignatures)
-
-					ioRoot := storage.Root{
-						Namespace: blk.Header.Namespace,
-						Version:   blk.Header.Round,
-						Type:      storage.RootTypeIO,
-						Hash:      blk.Header.IORoot,
-					}
-
-					tree := transaction.NewTree(storageBackend, ioRoot)
-					defer tree.Close()
-
-					txs, err = tree.GetTransactions(bctx)
-					if err != nil {
-						return err
-					}
-
-					tags, err = tree.GetTags(bctx)
-					if err != nil {
-						return err
-					}
-
-					return nil
-				}, off)
-
-				if err != nil {
-					s.Logger.Error("can't get I/O root from storage",
-						"err", err,
-						"round", blk.Header.Round,
+			// Indexing has been enabled, reindex all blocks as we may have missed some.
+			var ctx context.Context
+			ctx, cancelReindex = context.WithCancel(s.ctx)
+			reindexDoneCh = make(chan struct{})
+			go func(doneCh chan struct{}) {
+				defer close(doneCh)
+
+				if rerr := s.reindex(ctx, storageBackend); rerr != nil && !errors.Is(rerr, context.Canceled) {
+					s.Logger.Error("failed to reindex blocks",
+						"err", rerr,
 					)
-					continue
 				}
+			}(reindexDoneCh)
+		case annBlk := <-blocksCh:
+			if !enabled {
+				continue
 			}
 
-			if err = s.backend.Index(s.ctx, blk.Header.Round, blk.Header.EncodedHash(), txs, tags); err != nil {
-				s.Logger.Error("failed to index tags",
+			// New blocks to index.
+			blk := annBlk.Block
+			if err = s.indexBlock(s.ctx, storageBackend, blk); err != nil {
+				s.Logger.Error("failed to index block",
 					"err", err,
 					"round", blk.Header.Round,
 				)
@@ -167,9 +305,12 @@ func New(
 		QueryableBackend:      backend,
 		runtimeID:             runtimeID,
 		backend:               backend,
+		history:               history,
 		roothash:              roothash,
 		ctx:                   ctx,
 		cancelCtx:             cancelCtx,
+		enabled:               true,
+		enabledCh:             make(chan struct{}, 1),
 		stopCh:                make(chan struct{}),
 	}
 	s.Logger = s.Logger.With("runtime_id", s.runtimeID.String())
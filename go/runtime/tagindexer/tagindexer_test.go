@@ -0,0 +1,152 @@
+package tagindexer
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+)
+
+const recvTimeout = 5 * time.Second
+
+// testRoothash is a roothash backend that only supports watching blocks and fetching the genesis
+// block for a set of runtimes.
+type testRoothash struct {
+	roothash.Backend
+
+	genesis    map[common.Namespace]*block.Block
+	blocks     map[common.Namespace]*pubsub.Broker
+	subscribed map[common.Namespace]chan struct{}
+}
+
+func (r *testRoothash) GetGenesisBlock(ctx context.Context, request *roothash.RuntimeRequest) (*block.Block, error) {
+	blk := r.genesis[request.RuntimeID]
+	if blk == nil {
+		return nil, roothash.ErrNotFound
+	}
+	return blk, nil
+}
+
+func (r *testRoothash) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	sub := r.blocks[runtimeID].Subscribe()
+	ch := make(chan *roothash.AnnotatedBlock)
+	sub.Unwrap(ch)
+	close(r.subscribed[runtimeID])
+	return ch, sub, nil
+}
+
+func TestServiceSetEnabled(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-tagindexer-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeIDs := []common.Namespace{
+		common.NewTestNamespaceFromSeed([]byte("tag indexer test ns 1"), 0),
+		common.NewTestNamespaceFromSeed([]byte("tag indexer test ns 2"), 0),
+	}
+	rh := &testRoothash{
+		genesis:    make(map[common.Namespace]*block.Block),
+		blocks:     make(map[common.Namespace]*pubsub.Broker),
+		subscribed: make(map[common.Namespace]chan struct{}),
+	}
+
+	var (
+		services  []*Service
+		histories []history.History
+	)
+	for i, runtimeID := range runtimeIDs {
+		rh.genesis[runtimeID] = block.NewGenesisBlock(runtimeID, 0)
+		rh.blocks[runtimeID] = pubsub.NewBroker(false)
+		rh.subscribed[runtimeID] = make(chan struct{})
+
+		rtDir := filepath.Join(dataDir, runtimeID.String())
+		err = os.MkdirAll(rtDir, 0o700)
+		require.NoError(err, "MkdirAll")
+
+		var h history.History
+		h, err = history.New(rtDir, runtimeID, history.NewDefaultConfig())
+		require.NoError(err, "history.New")
+		defer h.Close()
+		histories = append(histories, h)
+
+		var s *Service
+		s, err = New(rtDir, NewBleveBackend(), h, rh)
+		require.NoError(err, "New")
+		// Only the first runtime is initially indexed.
+		if i > 0 {
+			s.SetEnabled(false)
+		}
+		err = s.Start(nil)
+		require.NoError(err, "Start")
+		defer s.Stop()
+		services = append(services, s)
+	}
+	require.True(services[0].IsEnabled())
+	require.False(services[1].IsEnabled())
+
+	// Generate some blocks for both runtimes.
+	const numBlocks = 5
+	var blocks [][]*block.Block
+	for i, runtimeID := range runtimeIDs {
+		// Make sure the indexer is watching blocks before any are generated.
+		select {
+		case <-rh.subscribed[runtimeID]:
+		case <-time.After(recvTimeout):
+			t.Fatalf("indexer failed to subscribe to blocks")
+		}
+
+		blk := rh.genesis[runtimeID]
+		var rtBlocks []*block.Block
+		for round := 0; round < numBlocks; round++ {
+			if round > 0 {
+				blk = block.NewEmptyBlock(blk, 0, block.Normal)
+			}
+			annBlk := &roothash.AnnotatedBlock{Height: int64(round + 1), Block: blk}
+			err = histories[i].Commit(annBlk, &roothash.RoundResults{})
+			require.NoError(err, "Commit")
+			rh.blocks[runtimeID].Broadcast(annBlk)
+			rtBlocks = append(rtBlocks, blk)
+		}
+		blocks = append(blocks, rtBlocks)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	// Blocks of the first runtime should be indexed.
+	err = services[0].WaitBlockIndexed(ctx, numBlocks-1)
+	require.NoError(err, "WaitBlockIndexed")
+	for _, blk := range blocks[0] {
+		var round uint64
+		round, err = services[0].QueryBlock(ctx, blk.Header.EncodedHash())
+		require.NoError(err, "QueryBlock")
+		require.EqualValues(blk.Header.Round, round)
+	}
+
+	// Blocks of the second runtime should not be indexed.
+	_, err = services[1].QueryBlock(ctx, blocks[1][numBlocks-1].Header.EncodedHash())
+	require.Error(err, "QueryBlock should fail for runtimes that are not indexed")
+
+	// Enable indexing of the second runtime, all blocks should be indexed starting at genesis.
+	services[1].SetEnabled(true)
+	err = services[1].WaitBlockIndexed(ctx, numBlocks-1)
+	require.NoError(err, "WaitBlockIndexed")
+	for _, blk := range blocks[1] {
+		var round uint64
+		round, err = services[1].QueryBlock(ctx, blk.Header.EncodedHash())
+		require.NoError(err, "QueryBlock")
+		require.EqualValues(blk.Header.Round, round)
+	}
+}
@@ -291,6 +291,11 @@ type ConsensusParameters struct {
 	// distributed per epoch to entities that have any node considered
 	// in any election.
 	RewardFactorEpochElectionAny quantity.Quantity `json:"reward_factor_epoch_election_any"`
+
+	// EnforceAdmissionPolicy is true iff the runtime admission policy should
+	// also be enforced when electing committees, excluding nodes that are no
+	// longer admitted (e.g., due to a changed entity whitelist).
+	EnforceAdmissionPolicy bool `json:"enforce_admission_policy,omitempty"`
 }
 
 // SanityCheck does basic sanity checking on the genesis state.
@@ -176,6 +176,14 @@ type Backend interface {
 	// Allowance looks up the allowance for the given owner/beneficiary combination.
 	Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error)
 
+	// ActiveRewardStep returns the step of the reward schedule that is active
+	// at the given epoch.
+	//
+	// In case the epoch is past the end of the reward schedule, a step with
+	// a zero scale and an Until epoch of beacon.EpochInvalid is returned as
+	// no rewards are given.
+	ActiveRewardStep(ctx context.Context, query *RewardStepQuery) (*RewardStep, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*Genesis, error)
 
@@ -204,6 +212,12 @@ type OwnerQuery struct {
 	Owner  Address `json:"owner"`
 }
 
+// RewardStepQuery is a reward step query.
+type RewardStepQuery struct {
+	Height int64            `json:"height"`
+	Epoch  beacon.EpochTime `json:"epoch"`
+}
+
 // AllowanceQuery is an allowance query.
 type AllowanceQuery struct {
 	Height      int64   `json:"height"`
@@ -252,6 +266,7 @@ type Event struct {
 	Burn            *BurnEvent            `json:"burn,omitempty"`
 	Escrow          *EscrowEvent          `json:"escrow,omitempty"`
 	AllowanceChange *AllowanceChangeEvent `json:"allowance_change,omitempty"`
+	Slash           *SlashEvent           `json:"slash,omitempty"`
 }
 
 // AddEscrowEvent is the event emitted when stake is transferred into an escrow
@@ -280,6 +295,21 @@ func (e *TakeEscrowEvent) EventKind() string {
 	return "take_escrow"
 }
 
+// SlashEvent is the event emitted when an account is slashed for misbehavior.
+//
+// It is emitted together with the TakeEscrowEvent and additionally carries the
+// reason for slashing.
+type SlashEvent struct {
+	Owner  Address           `json:"owner"`
+	Reason SlashReason       `json:"reason"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *SlashEvent) EventKind() string {
+	return "slash"
+}
+
 // DebondingStartEvent is the event emitted when the debonding process has
 // started and the given number of active shares have been moved into the
 // debonding pool and started debonding.
@@ -375,6 +375,32 @@ func (cs *CommissionSchedule) AmendAndPruneAndValidate(amendment *CommissionSche
 	return nil
 }
 
+// SanityCheck performs a stateless sanity check of a proposed commission schedule amendment
+// against the given rules at the given epoch. It checks the step count limits, that steps are
+// ordered and aligned with the rate change interval, that rate changes are made in the future,
+// that bound changes are made at least RateBoundLead epochs in advance and, in case the schedule
+// declares both rates and bounds, that all rates stay within the declared bounds.
+//
+// The schedule is not modified. Note that whether an amendment is accepted also depends on the
+// current schedule of the account, so passing the sanity check does not guarantee acceptance.
+func (cs *CommissionSchedule) SanityCheck(rules *CommissionScheduleRules, now beacon.EpochTime) error {
+	if err := cs.validateComplexity(rules); err != nil {
+		return err
+	}
+	if err := cs.validateNondegenerate(rules); err != nil {
+		return err
+	}
+	if err := cs.validateAmendmentAcceptable(rules, now, false); err != nil {
+		return err
+	}
+	if len(cs.Rates) != 0 && len(cs.Bounds) != 0 {
+		if err := cs.validateWithinBound(now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CurrentRate returns the rate at the latest rate step that has started or nil if no step has started.
 func (cs *CommissionSchedule) CurrentRate(now beacon.EpochTime) *quantity.Quantity {
 	var latestStartedStep *CommissionRateStep
@@ -798,6 +798,108 @@ func TestCommissionSchedule(t *testing.T) {
 	require.Equal(t, beacon.EpochTime(10), cs.Bounds[0].Start, "prune 10 bounds start")
 }
 
+func TestCommissionScheduleSanityCheck(t *testing.T) {
+	rules := CommissionScheduleRules{
+		RateChangeInterval: 10,
+		RateBoundLead:      30,
+		MaxRateSteps:       2,
+		MaxBoundSteps:      2,
+	}
+	now := beacon.EpochTime(5)
+
+	rate := func(start beacon.EpochTime, r int64) CommissionRateStep {
+		return CommissionRateStep{Start: start, Rate: mustInitQuantity(t, r)}
+	}
+	bound := func(start beacon.EpochTime, min, max int64) CommissionRateBoundStep {
+		return CommissionRateBoundStep{Start: start, RateMin: mustInitQuantity(t, min), RateMax: mustInitQuantity(t, max)}
+	}
+
+	for _, tc := range []struct {
+		msg   string
+		cs    CommissionSchedule
+		valid bool
+	}{
+		{"empty", CommissionSchedule{}, true},
+		{
+			"valid rates only",
+			CommissionSchedule{Rates: []CommissionRateStep{rate(10, 50_000), rate(20, 40_000)}},
+			true,
+		},
+		{
+			"valid rates and bounds",
+			CommissionSchedule{
+				Rates:  []CommissionRateStep{rate(40, 50_000), rate(50, 40_000)},
+				Bounds: []CommissionRateBoundStep{bound(40, 0, 50_000), bound(60, 10_000, 40_000)},
+			},
+			true,
+		},
+		{
+			"too many rate steps",
+			CommissionSchedule{Rates: []CommissionRateStep{rate(10, 1), rate(20, 2), rate(30, 3)}},
+			false,
+		},
+		{
+			"too many bound steps",
+			CommissionSchedule{Bounds: []CommissionRateBoundStep{bound(40, 0, 1), bound(50, 0, 2), bound(60, 0, 3)}},
+			false,
+		},
+		{
+			"rate step not aligned",
+			CommissionSchedule{Rates: []CommissionRateStep{rate(15, 50_000)}},
+			false,
+		},
+		{
+			"rate steps not ordered",
+			CommissionSchedule{Rates: []CommissionRateStep{rate(20, 50_000), rate(10, 40_000)}},
+			false,
+		},
+		{
+			"rate over unity",
+			CommissionSchedule{Rates: []CommissionRateStep{rate(10, 100_001)}},
+			false,
+		},
+		{
+			"bound maximum less than minimum",
+			CommissionSchedule{Bounds: []CommissionRateBoundStep{bound(40, 50_000, 40_000)}},
+			false,
+		},
+		{
+			"rate change in the past",
+			CommissionSchedule{Rates: []CommissionRateStep{rate(0, 50_000)}},
+			false,
+		},
+		{
+			"bound change without enough lead time",
+			CommissionSchedule{Bounds: []CommissionRateBoundStep{bound(30, 0, 50_000)}},
+			false,
+		},
+		{
+			"rate out of bounds",
+			CommissionSchedule{
+				Rates:  []CommissionRateStep{rate(40, 50_000), rate(50, 60_000)},
+				Bounds: []CommissionRateBoundStep{bound(40, 0, 50_000)},
+			},
+			false,
+		},
+		{
+			"rate and bound start mismatch",
+			CommissionSchedule{
+				Rates:  []CommissionRateStep{rate(50, 50_000)},
+				Bounds: []CommissionRateBoundStep{bound(40, 0, 50_000)},
+			},
+			false,
+		},
+	} {
+		err := tc.cs.SanityCheck(&rules, now)
+		switch tc.valid {
+		case true:
+			require.NoError(t, err, tc.msg)
+		case false:
+			requireErrorShowDiagnostic(t, err, tc.msg)
+		}
+	}
+}
+
 func TestPrettyPrintCommissionRateStep(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+// VoteFeeDisbursement is the disbursement of the persisted last block fees to
+// the entities that voted for the previous block and to the next proposer.
+type VoteFeeDisbursement struct {
+	// PerVoter is the amount paid to each voting entity.
+	PerVoter quantity.Quantity `json:"per_voter"`
+	// NextProposer is the total amount paid to the next proposer.
+	NextProposer quantity.Quantity `json:"next_proposer"`
+}
+
+// ComputeVoteFeeDisbursement computes how the last block fees are disbursed
+// to the voting entities and the next proposer, given the number of eligible
+// validators and the number of distinct entities that voted.
+//
+// Any remainder not covered by the disbursement goes to the common pool.
+func (p *ConsensusParameters) ComputeVoteFeeDisbursement(
+	lastBlockFees *quantity.Quantity,
+	numEligibleValidators int,
+	numVotingEntities int,
+) (*VoteFeeDisbursement, error) {
+	if numEligibleValidators <= 0 {
+		return nil, fmt.Errorf("staking: invalid number of eligible validators: %d", numEligibleValidators)
+	}
+
+	// Compute the portion associated with each eligible validator's share of the fees, and within
+	// that, how much goes to the voter and how much goes to the next proposer.
+	perValidator := lastBlockFees.Clone()
+	var nEVQ quantity.Quantity
+	if err := nEVQ.FromInt64(int64(numEligibleValidators)); err != nil {
+		return nil, fmt.Errorf("import numEligibleValidators %d: %w", numEligibleValidators, err)
+	}
+	if err := perValidator.Quo(&nEVQ); err != nil {
+		return nil, fmt.Errorf("divide perValidator: %w", err)
+	}
+	denom := p.FeeSplitWeightVote.Clone()
+	if err := denom.Add(&p.FeeSplitWeightNextPropose); err != nil {
+		return nil, fmt.Errorf("add FeeSplitWeightNextPropose: %w", err)
+	}
+	shareNextProposer := perValidator.Clone()
+	if err := shareNextProposer.Mul(&p.FeeSplitWeightNextPropose); err != nil {
+		return nil, fmt.Errorf("multiply shareNextProposer: %w", err)
+	}
+	if err := shareNextProposer.Quo(denom); err != nil {
+		return nil, fmt.Errorf("divide shareNextProposer: %w", err)
+	}
+	shareVote := perValidator.Clone()
+	if err := shareVote.Sub(shareNextProposer); err != nil {
+		return nil, fmt.Errorf("subtract shareVote: %w", err)
+	}
+
+	// Multiply to get the next proposer's total payment.
+	var nVEQ quantity.Quantity
+	if err := nVEQ.FromInt64(int64(numVotingEntities)); err != nil {
+		return nil, fmt.Errorf("import numVotingEntities %d: %w", numVotingEntities, err)
+	}
+	nextProposerTotal := shareNextProposer.Clone()
+	if err := nextProposerTotal.Mul(&nVEQ); err != nil {
+		return nil, fmt.Errorf("multiply nextProposerTotal: %w", err)
+	}
+
+	return &VoteFeeDisbursement{
+		PerVoter:     *shareVote,
+		NextProposer: *nextProposerTotal,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestComputeVoteFeeDisbursement(t *testing.T) {
+	require := require.New(t)
+
+	params := &ConsensusParameters{
+		FeeSplitWeightPropose:     *quantity.NewFromUint64(2),
+		FeeSplitWeightVote:        *quantity.NewFromUint64(2),
+		FeeSplitWeightNextPropose: *quantity.NewFromUint64(1),
+	}
+
+	for _, tc := range []struct {
+		msg                  string
+		fees                 uint64
+		numEligible          int
+		numVoting            int
+		expectedPerVoter     uint64
+		expectedNextProposer uint64
+	}{
+		{"all validators voted", 100, 4, 4, 17, 32},
+		{"some validators voted", 100, 4, 3, 17, 24},
+		{"no validators voted", 100, 4, 0, 17, 0},
+		{"no fees", 0, 4, 4, 0, 0},
+	} {
+		d, err := params.ComputeVoteFeeDisbursement(quantity.NewFromUint64(tc.fees), tc.numEligible, tc.numVoting)
+		require.NoError(err, tc.msg)
+		require.Equal(*quantity.NewFromUint64(tc.expectedPerVoter), d.PerVoter, "%s: per voter", tc.msg)
+		require.Equal(*quantity.NewFromUint64(tc.expectedNextProposer), d.NextProposer, "%s: next proposer", tc.msg)
+
+		// The disbursement must never exceed the available fees.
+		total := d.PerVoter.Clone()
+		require.NoError(total.Mul(quantity.NewFromUint64(uint64(tc.numVoting))))
+		require.NoError(total.Add(&d.NextProposer))
+		require.True(total.Cmp(quantity.NewFromUint64(tc.fees)) <= 0, "%s: disbursement within fees", tc.msg)
+	}
+
+	_, err := params.ComputeVoteFeeDisbursement(quantity.NewFromUint64(100), 0, 0)
+	require.Error(err, "zero eligible validators should fail")
+}
@@ -16,6 +16,20 @@ type RewardStep struct {
 	Scale quantity.Quantity `json:"scale"`
 }
 
+// RewardStepAt returns the step of the reward schedule that is active at the
+// given epoch. A step is active for all epochs strictly before its Until epoch
+// that are not covered by an earlier step.
+//
+// In case the epoch is past the end of the schedule, nil is returned.
+func RewardStepAt(schedule []RewardStep, epoch beacon.EpochTime) *RewardStep {
+	for i, step := range schedule {
+		if epoch < step.Until {
+			return &schedule[i]
+		}
+	}
+	return nil
+}
+
 func init() {
 	// Denominated in one millionth of a percent.
 	RewardAmountDenominator = quantity.NewQuantity()
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
+func TestRewardStepAt(t *testing.T) {
+	require := require.New(t)
+
+	schedule := []RewardStep{
+		{Until: 10, Scale: mustInitQuantity(t, 1000)},
+		{Until: 20, Scale: mustInitQuantity(t, 500)},
+		{Until: 30, Scale: mustInitQuantity(t, 250)},
+	}
+
+	for _, tc := range []struct {
+		epoch         beacon.EpochTime
+		expectedScale int64
+		expectedUntil beacon.EpochTime
+	}{
+		{0, 1000, 10},
+		{9, 1000, 10},
+		// Until is exclusive.
+		{10, 500, 20},
+		{19, 500, 20},
+		{20, 250, 30},
+		{29, 250, 30},
+	} {
+		step := RewardStepAt(schedule, tc.epoch)
+		require.NotNil(step, "RewardStepAt(%d)", tc.epoch)
+		require.Equal(tc.expectedUntil, step.Until, "RewardStepAt(%d) until", tc.epoch)
+		require.Equal(mustInitQuantity(t, tc.expectedScale), step.Scale, "RewardStepAt(%d) scale", tc.epoch)
+	}
+
+	// Past the end of the schedule, there should be no active step.
+	require.Nil(RewardStepAt(schedule, 30), "RewardStepAt past the end of the schedule")
+	require.Nil(RewardStepAt(schedule, beacon.EpochInvalid), "RewardStepAt past the end of the schedule")
+
+	// Empty schedule should have no active step.
+	require.Nil(RewardStepAt(nil, 0), "RewardStepAt with empty schedule")
+}
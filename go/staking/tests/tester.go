@@ -1171,19 +1171,24 @@ func testSlashConsensusEquivocation(
 	require.NoError(err, "SubmitEvidence")
 
 	// Wait for the node to get slashed.
-WaitLoop:
-	for {
+	var gotTake, gotSlash bool
+	for !gotTake || !gotSlash {
 		select {
 		case ev := <-ch:
-			if ev.Escrow == nil {
-				continue
-			}
-
-			if e := ev.Escrow.Take; e != nil {
+			switch {
+			case ev.Escrow != nil && ev.Escrow.Take != nil:
+				e := ev.Escrow.Take
 				require.Equal(entAddr, e.Owner, "TakeEscrowEvent - owner must be entity's address")
 				// All stake must be slashed as defined in debugGenesisState.
 				require.Equal(entAcc.Escrow.Active.Balance, e.Amount, "TakeEscrowEvent - all stake slashed")
-				break WaitLoop
+				gotTake = true
+			case ev.Slash != nil:
+				e := ev.Slash
+				require.Equal(entAddr, e.Owner, "SlashEvent - owner must be entity's address")
+				require.Equal(api.SlashConsensusEquivocation, e.Reason, "SlashEvent - reason")
+				require.Equal(entAcc.Escrow.Active.Balance, e.Amount, "SlashEvent - all stake slashed")
+				require.NotZero(ev.Height, "SlashEvent - height")
+				gotSlash = true
 			}
 		case <-time.After(recvTimeout):
 			t.Fatalf("failed to receive slash event")
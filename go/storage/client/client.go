@@ -36,8 +36,8 @@ var (
 var ErrStorageNotAvailable = errors.New("storage/client: storage not available")
 
 const (
-	retryInterval = 1 * time.Second
-	maxRetries    = 15
+	defaultRetryInterval = 1 * time.Second
+	defaultMaxRetries    = 15
 )
 
 // Option is a storage client option.
@@ -55,6 +55,31 @@ func WithBackendOverride(nodeID signature.PublicKey, backend api.Backend) Option
 	}
 }
 
+// WithMaxRetries configures the maximum number of times a request is retried after all storage
+// nodes have failed to serve it.
+func WithMaxRetries(maxRetries uint64) Option {
+	return func(b *storageClientBackend) {
+		b.maxRetries = maxRetries
+	}
+}
+
+// WithRetryInterval configures the interval between request retries.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(b *storageClientBackend) {
+		b.retryInterval = interval
+	}
+}
+
+// WithRequestTimeout configures the timeout of a single request attempt against a storage node.
+// After the timeout expires, the next storage node is tried.
+//
+// The timeout does not apply to streaming requests (GetDiff, GetCheckpointChunk).
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(b *storageClientBackend) {
+		b.requestTimeout = timeout
+	}
+}
+
 // storageClientBackend contains all information about the client storage API
 // backend, including the backend state and the connected storage nodes' state.
 type storageClientBackend struct {
@@ -68,6 +93,23 @@ type storageClientBackend struct {
 	// backendOverrides is a map of per-node storage backend overrides. This map can only be mutated
 	// during initialization via options so no lock is needed.
 	backendOverrides map[signature.PublicKey]api.Backend
+
+	maxRetries     uint64
+	retryInterval  time.Duration
+	requestTimeout time.Duration
+}
+
+// requestContext returns the context that should be used for a single request attempt.
+func (b *storageClientBackend) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if b.requestTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, b.requestTimeout)
+}
+
+func (b *storageClientBackend) retrySchedule(ctx context.Context) backoff.BackOff {
+	sched := backoff.WithMaxRetries(backoff.NewConstantBackOff(b.retryInterval), b.maxRetries)
+	return backoff.WithContext(sched, ctx)
 }
 
 // Implements api.StorageClient.
@@ -141,8 +183,11 @@ func (b *storageClientBackend) writeWithClient( // nolint: gocyclo
 					backend = api.NewStorageClient(conn.ClientConn)
 				}
 
+				reqCtx, cancel := b.requestContext(ctx)
+				defer cancel()
+
 				var rerr error
-				resp, rerr = fn(ctx, backend, conn.Node)
+				resp, rerr = fn(reqCtx, backend, conn.Node)
 				if rerr != nil {
 					b.logger.Debug("storage write request error",
 						"err", rerr,
@@ -154,6 +199,9 @@ func (b *storageClientBackend) writeWithClient( // nolint: gocyclo
 				case status.Code(rerr) == codes.Unavailable:
 					// Storage node may be temporarily unavailable.
 					return rerr
+				case ctx.Err() == nil && (errors.Is(rerr, context.DeadlineExceeded) || status.Code(rerr) == codes.DeadlineExceeded):
+					// Request attempt has timed out, but the request may still succeed.
+					return rerr
 				case status.Code(rerr) == codes.PermissionDenied:
 					// Writes can fail around an epoch transition due to policy errors.
 					return rerr
@@ -168,8 +216,7 @@ func (b *storageClientBackend) writeWithClient( // nolint: gocyclo
 				}
 			}
 
-			sched := backoff.WithMaxRetries(backoff.NewConstantBackOff(retryInterval), maxRetries)
-			rerr := backoff.Retry(op, backoff.WithContext(sched, ctx))
+			rerr := backoff.Retry(op, b.retrySchedule(ctx))
 
 			ch <- &grpcResponse{
 				resp: resp,
@@ -309,7 +356,29 @@ func (b *storageClientBackend) Apply(ctx context.Context, request *api.ApplyRequ
 	)
 }
 
+// ApplyBatch applies a batch of operations.
+//
+// In case a runtime descriptor provider is configured, batches exceeding the runtime's
+// MaxApplyOps or MaxApplyWriteLogEntries limits are rejected with ErrLimitReached without
+// being sent to any storage node. Such batches cannot be split as storage nodes must produce
+// a single receipt covering all of the applied roots.
 func (b *storageClientBackend) ApplyBatch(ctx context.Context, request *api.ApplyBatchRequest) ([]*api.Receipt, error) {
+	if b.runtime != nil {
+		rt, err := b.runtime.ActiveDescriptor(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch registry descriptor: %w", err)
+		}
+
+		if uint64(len(request.Ops)) > rt.Storage.MaxApplyOps {
+			return nil, api.ErrLimitReached
+		}
+		for _, op := range request.Ops {
+			if uint64(len(op.WriteLog)) > rt.Storage.MaxApplyWriteLogEntries {
+				return nil, api.ErrLimitReached
+			}
+		}
+	}
+
 	expectedNewRoots := make([]hash.Hash, 0, len(request.Ops))
 	expectedNewRootTypes := make([]api.RootType, 0, len(request.Ops))
 	for _, op := range request.Ops {
@@ -404,8 +473,7 @@ func (b *storageClientBackend) readWithClient(
 		return err
 	}
 
-	sched := backoff.WithMaxRetries(backoff.NewConstantBackOff(retryInterval), maxRetries)
-	err := backoff.Retry(op, backoff.WithContext(sched, ctx))
+	err := backoff.Retry(op, b.retrySchedule(ctx))
 	return resp, err
 }
 
@@ -414,6 +482,8 @@ func (b *storageClientBackend) SyncGet(ctx context.Context, request *api.GetRequ
 		ctx,
 		request.Tree.Root.Namespace,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
+			ctx, cancel := b.requestContext(ctx)
+			defer cancel()
 			return c.SyncGet(ctx, request)
 		},
 	)
@@ -428,6 +498,8 @@ func (b *storageClientBackend) SyncGetPrefixes(ctx context.Context, request *api
 		ctx,
 		request.Tree.Root.Namespace,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
+			ctx, cancel := b.requestContext(ctx)
+			defer cancel()
 			return c.SyncGetPrefixes(ctx, request)
 		},
 	)
@@ -442,6 +514,8 @@ func (b *storageClientBackend) SyncIterate(ctx context.Context, request *api.Ite
 		ctx,
 		request.Tree.Root.Namespace,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
+			ctx, cancel := b.requestContext(ctx)
+			defer cancel()
 			return c.SyncIterate(ctx, request)
 		},
 	)
@@ -470,6 +544,8 @@ func (b *storageClientBackend) GetCheckpoints(ctx context.Context, request *chec
 		ctx,
 		request.Namespace,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
+			ctx, cancel := b.requestContext(ctx)
+			defer cancel()
 			return c.GetCheckpoints(ctx, request)
 		},
 	)
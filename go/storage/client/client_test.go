@@ -0,0 +1,270 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/nodes/grpc"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+var testNs = common.NewTestNamespaceFromSeed([]byte("storage client test ns"), 0)
+
+// staticNodesClient is a nodes client that returns a static set of connections.
+type staticNodesClient struct {
+	grpc.NodesClient
+
+	conns []*grpc.ConnWithNodeMeta
+}
+
+func (c *staticNodesClient) GetConnectionsWithMeta() []*grpc.ConnWithNodeMeta {
+	return c.conns
+}
+
+func (c *staticNodesClient) GetConnectionsMap() map[signature.PublicKey]*grpc.ConnWithNodeMeta {
+	m := make(map[signature.PublicKey]*grpc.ConnWithNodeMeta)
+	for _, conn := range c.conns {
+		m[conn.Node.ID] = conn
+	}
+	return m
+}
+
+// staticRuntime is a runtime descriptor provider that returns a static descriptor.
+type staticRuntime struct {
+	rt *registry.Runtime
+}
+
+func (r *staticRuntime) ActiveDescriptor(ctx context.Context) (*registry.Runtime, error) {
+	return r.rt, nil
+}
+
+// recordingBackend is a storage backend that signs receipts for any applied batch and records
+// the received requests.
+type recordingBackend struct {
+	api.Backend
+
+	signer   signature.Signer
+	requests []*api.ApplyBatchRequest
+}
+
+func (b *recordingBackend) ApplyBatch(ctx context.Context, request *api.ApplyBatchRequest) ([]*api.Receipt, error) {
+	b.requests = append(b.requests, request)
+
+	var (
+		rootTypes []api.RootType
+		roots     []hash.Hash
+	)
+	for _, op := range request.Ops {
+		rootTypes = append(rootTypes, op.RootType)
+		roots = append(roots, op.DstRoot)
+	}
+	receipt, err := api.SignReceipt(b.signer, request.Namespace, request.DstRound, rootTypes, roots)
+	if err != nil {
+		return nil, err
+	}
+	return []*api.Receipt{receipt}, nil
+}
+
+// syncBackend is a storage backend that serves SyncGet requests and counts the received requests.
+type syncBackend struct {
+	api.Backend
+
+	sync.Mutex
+	err   error
+	block bool
+	calls int
+}
+
+func (b *syncBackend) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
+	b.Lock()
+	b.calls++
+	b.Unlock()
+
+	if b.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &api.ProofResponse{}, nil
+}
+
+func (b *syncBackend) numCalls() int {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.calls
+}
+
+func newTestClient(rt *registry.Runtime, backends map[signature.PublicKey]api.Backend, opts ...Option) *storageClientBackend {
+	b := &storageClientBackend{
+		ctx:           context.Background(),
+		logger:        logging.GetLogger("storage/client/test"),
+		maxRetries:    defaultMaxRetries,
+		retryInterval: defaultRetryInterval,
+	}
+	if rt != nil {
+		b.runtime = &staticRuntime{rt: rt}
+	}
+
+	nc := &staticNodesClient{}
+	for id, backend := range backends {
+		nc.conns = append(nc.conns, &grpc.ConnWithNodeMeta{Node: &node.Node{ID: id}})
+		WithBackendOverride(id, backend)(b)
+	}
+	b.nodesClient = nc
+
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func TestApplyBatchLimits(t *testing.T) {
+	genesisTestHelpers.SetTestChainContext()
+	require := require.New(t)
+
+	rt := &registry.Runtime{
+		ID: testNs,
+		Storage: registry.StorageParameters{
+			MinWriteReplication:     1,
+			MaxApplyWriteLogEntries: 10,
+			MaxApplyOps:             2,
+		},
+	}
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	backend := &recordingBackend{signer: signer}
+	b := newTestClient(rt, map[signature.PublicKey]api.Backend{signer.Public(): backend})
+
+	var ops []api.ApplyOp
+	for i := 0; i < 3; i++ {
+		ops = append(ops, api.ApplyOp{
+			RootType: api.RootTypeState,
+			DstRoot:  hash.NewFromBytes([]byte{byte(i)}),
+			WriteLog: api.WriteLog{{Key: []byte{byte(i)}, Value: []byte("value")}},
+		})
+	}
+
+	// Batches within limits should be sent in a single request with a single receipt.
+	receipts, err := b.ApplyBatch(context.Background(), &api.ApplyBatchRequest{
+		Namespace: testNs,
+		DstRound:  1,
+		Ops:       ops[:2],
+	})
+	require.NoError(err, "ApplyBatch")
+	require.Len(backend.requests, 1, "ApplyBatch should send a single request")
+	require.Len(receipts, 1, "ApplyBatch should return a single receipt")
+	var body api.ReceiptBody
+	err = receipts[0].Open(&body)
+	require.NoError(err, "receipt.Open")
+	require.EqualValues(1, body.Round)
+	require.Equal([]hash.Hash{ops[0].DstRoot, ops[1].DstRoot}, body.Roots, "receipt should cover all roots")
+
+	// Batches exceeding MaxApplyOps should be rejected without sending.
+	backend.requests = nil
+	_, err = b.ApplyBatch(context.Background(), &api.ApplyBatchRequest{
+		Namespace: testNs,
+		DstRound:  1,
+		Ops:       ops,
+	})
+	require.ErrorIs(err, api.ErrLimitReached, "ApplyBatch should reject too many ops")
+	require.Empty(backend.requests, "ApplyBatch should not send too many ops")
+
+	// Write logs exceeding MaxApplyWriteLogEntries should be rejected without sending.
+	bigOp := ops[0]
+	bigOp.WriteLog = make(api.WriteLog, rt.Storage.MaxApplyWriteLogEntries+1)
+	_, err = b.ApplyBatch(context.Background(), &api.ApplyBatchRequest{
+		Namespace: testNs,
+		DstRound:  1,
+		Ops:       []api.ApplyOp{bigOp},
+	})
+	require.ErrorIs(err, api.ErrLimitReached, "ApplyBatch should reject too large write logs")
+	require.Empty(backend.requests, "ApplyBatch should not send too large write logs")
+}
+
+func TestReadFailover(t *testing.T) {
+	require := require.New(t)
+
+	signer1, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	signer2, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	failing := &syncBackend{err: errors.New("storage node failure")}
+	working := &syncBackend{}
+	b := newTestClient(nil, map[signature.PublicKey]api.Backend{
+		signer1.Public(): failing,
+		signer2.Public(): working,
+	})
+
+	// Make sure the failing node is tried first.
+	ctx := api.WithNodePriorityHint(context.Background(), []signature.PublicKey{signer1.Public()})
+	var selected *node.Node
+	ctx = api.WithNodeSelectionCallback(ctx, func(n *node.Node) {
+		selected = n
+	})
+
+	rsp, err := b.SyncGet(ctx, &api.GetRequest{})
+	require.NoError(err, "SyncGet should fail over to a working node")
+	require.NotNil(rsp)
+	require.Equal(1, failing.numCalls(), "failing node should be tried first")
+	require.Equal(1, working.numCalls(), "working node should be tried after the failing one")
+	require.NotNil(selected)
+	require.Equal(signer2.Public(), selected.ID, "working node should be selected")
+}
+
+func TestReadMaxRetries(t *testing.T) {
+	require := require.New(t)
+
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	failing := &syncBackend{err: errors.New("storage node failure")}
+	b := newTestClient(nil, map[signature.PublicKey]api.Backend{signer.Public(): failing},
+		WithMaxRetries(2),
+		WithRetryInterval(10*time.Millisecond),
+	)
+
+	_, err = b.SyncGet(context.Background(), &api.GetRequest{})
+	require.Error(err, "SyncGet should fail when all nodes fail")
+	require.Equal(3, failing.numCalls(), "request should be retried the configured number of times")
+}
+
+func TestReadRequestTimeout(t *testing.T) {
+	require := require.New(t)
+
+	signer1, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+	signer2, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	blocking := &syncBackend{block: true}
+	working := &syncBackend{}
+	b := newTestClient(nil, map[signature.PublicKey]api.Backend{
+		signer1.Public(): blocking,
+		signer2.Public(): working,
+	}, WithRequestTimeout(50*time.Millisecond))
+
+	// Make sure the blocking node is tried first.
+	ctx := api.WithNodePriorityHint(context.Background(), []signature.PublicKey{signer1.Public()})
+	_, err = b.SyncGet(ctx, &api.GetRequest{})
+	require.NoError(err, "SyncGet should fail over after the request timeout")
+	require.Equal(1, blocking.numCalls())
+	require.Equal(1, working.numCalls())
+}
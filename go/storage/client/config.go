@@ -0,0 +1,37 @@
+package client
+
+import (
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	// CfgMaxRetries configures the maximum number of times a storage request is retried after all
+	// storage nodes have failed to serve it.
+	CfgMaxRetries = "storage.client.max_retries"
+	// CfgRetryInterval configures the interval between storage request retries.
+	CfgRetryInterval = "storage.client.retry_interval"
+	// CfgRequestTimeout configures the timeout of a single storage request attempt against a
+	// storage node (0 means no timeout).
+	CfgRequestTimeout = "storage.client.request_timeout"
+)
+
+// Flags has the configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// NewOptionsFromConfig returns the storage client options configured via flags.
+func NewOptionsFromConfig() []Option {
+	return []Option{
+		WithMaxRetries(viper.GetUint64(CfgMaxRetries)),
+		WithRetryInterval(viper.GetDuration(CfgRetryInterval)),
+		WithRequestTimeout(viper.GetDuration(CfgRequestTimeout)),
+	}
+}
+
+func init() {
+	Flags.Uint64(CfgMaxRetries, defaultMaxRetries, "Maximum number of storage request retries after all storage nodes fail")
+	Flags.Duration(CfgRetryInterval, defaultRetryInterval, "Interval between storage request retries")
+	Flags.Duration(CfgRequestTimeout, 0, "Timeout of a single storage request attempt against a storage node (0 = no timeout)")
+
+	_ = viper.BindPFlags(Flags)
+}
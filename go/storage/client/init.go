@@ -28,10 +28,12 @@ func NewForNodesClient(
 	opts ...Option,
 ) (api.Backend, error) {
 	b := &storageClientBackend{
-		ctx:         ctx,
-		logger:      logging.GetLogger("storage/client"),
-		nodesClient: client,
-		runtime:     runtime,
+		ctx:           ctx,
+		logger:        logging.GetLogger("storage/client"),
+		nodesClient:   client,
+		runtime:       runtime,
+		maxRetries:    defaultMaxRetries,
+		retryInterval: defaultRetryInterval,
 	}
 
 	for _, opt := range opts {
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	badgerNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	fsNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/fs"
 )
 
 const (
@@ -23,6 +24,12 @@ const (
 	// DBFileBadgerDB is the default BadgerDB backing store filename.
 	DBFileBadgerDB = "mkvs_storage.badger.db"
 
+	// BackendNameFS is the name of the filesystem backed database backend.
+	BackendNameFS = "fs"
+
+	// DBFileFS is the default filesystem backing store directory name.
+	DBFileFS = "mkvs_storage.fs.db"
+
 	checkpointDir = "checkpoints"
 )
 
@@ -32,6 +39,8 @@ func DefaultFileName(backend string) string {
 	switch backend {
 	case BackendNameBadgerDB:
 		return DBFileBadgerDB
+	case BackendNameFS:
+		return DBFileFS
 	default:
 		panic("storage/database: can't get default filename for unknown backend")
 	}
@@ -59,6 +68,8 @@ func New(cfg *api.Config) (api.Backend, error) {
 	switch cfg.Backend {
 	case BackendNameBadgerDB:
 		ndb, err = badgerNodedb.New(ndbCfg)
+	case BackendNameFS:
+		ndb, err = fsNodedb.New(ndbCfg)
 	default:
 		err = errors.New("storage/database: unsupported backend")
 	}
@@ -18,6 +18,7 @@ import (
 func TestStorageDatabase(t *testing.T) {
 	for _, v := range []string{
 		BackendNameBadgerDB,
+		BackendNameFS,
 	} {
 		t.Run(v, func(t *testing.T) {
 			doTestImpl(t, v)
@@ -0,0 +1,966 @@
+// Package fs provides a filesystem-backed node database.
+//
+// Nodes are stored as content-addressed files and roots and write logs are
+// stored as CBOR-serialized files, which makes the database easy to inspect
+// with standard tools. This is useful for debugging and cheap archival but is
+// much slower than the Badger-backed node database.
+//
+// Since node files are content-addressed and may be shared between versions,
+// they are never garbage collected. Finalizing and pruning only remove the
+// roots and write logs, with nodes of pruned versions becoming unreachable.
+package fs
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+const (
+	dbVersion = 1
+
+	// multipartVersionNone is the value used for the multipart version in metadata
+	// when no multipart restore is in progress.
+	multipartVersionNone uint64 = 0
+
+	metadataFile     = "metadata"
+	multipartLogFile = "multipart"
+	nodesDir         = "nodes"
+	rootsDir         = "roots"
+	writeLogsDir     = "writelogs"
+
+	// writeLogSeparator separates the new and old root in write log file names.
+	writeLogSeparator = "-"
+)
+
+// New creates a new filesystem-backed node database.
+func New(cfg *api.Config) (api.NodeDB, error) {
+	db := &fsNodeDB{
+		logger:           logging.GetLogger("mkvs/db/fs"),
+		dir:              cfg.DB,
+		namespace:        cfg.Namespace,
+		readOnly:         cfg.ReadOnly,
+		noFsync:          cfg.NoFsync,
+		discardWriteLogs: cfg.DiscardWriteLogs,
+		rootsCache:       make(map[uint64]*rootsMetadata),
+	}
+	db.meta.path = filepath.Join(db.dir, metadataFile)
+
+	if cfg.MemoryOnly {
+		return nil, fmt.Errorf("mkvs/fs: memory-only mode is not supported")
+	}
+
+	if !db.readOnly {
+		for _, dir := range []string{db.dir, db.path(nodesDir), db.path(rootsDir), db.path(writeLogsDir)} {
+			if err := common.Mkdir(dir); err != nil {
+				return nil, fmt.Errorf("mkvs/fs: failed to create database directory: %w", err)
+			}
+		}
+	}
+
+	// Load database metadata.
+	if err := db.load(); err != nil {
+		return nil, fmt.Errorf("mkvs/fs: failed to load metadata: %w", err)
+	}
+
+	// Cleanup any multipart restore remnants, since they can't be used anymore.
+	if !db.readOnly {
+		if err := db.cleanMultipartLocked(true); err != nil {
+			return nil, fmt.Errorf("mkvs/fs: failed to clean leftovers from multipart restore: %w", err)
+		}
+	}
+
+	return db, nil
+}
+
+type fsNodeDB struct { // nolint: maligned
+	logger *logging.Logger
+
+	dir       string
+	namespace common.Namespace
+
+	readOnly         bool
+	noFsync          bool
+	discardWriteLogs bool
+
+	multipartVersion uint64
+	multipartLog     multipartLog
+
+	// lock must be held when reading or updating roots metadata and when
+	// committing batches.
+	lock       sync.RWMutex
+	rootsCache map[uint64]*rootsMetadata
+
+	meta metadata
+}
+
+func (d *fsNodeDB) path(elem ...string) string {
+	return filepath.Join(append([]string{d.dir}, elem...)...)
+}
+
+func (d *fsNodeDB) nodePath(h hash.Hash) string {
+	name := h.String()
+	return d.path(nodesDir, name[:2], name)
+}
+
+func (d *fsNodeDB) rootsPath(version uint64) string {
+	return d.path(rootsDir, strconv.FormatUint(version, 10))
+}
+
+func (d *fsNodeDB) writeLogsPath(version uint64) string {
+	return d.path(writeLogsDir, strconv.FormatUint(version, 10))
+}
+
+func (d *fsNodeDB) writeLogPath(version uint64, endRootHash, startRootHash typedHash) string {
+	return filepath.Join(d.writeLogsPath(version), endRootHash.String()+writeLogSeparator+startRootHash.String())
+}
+
+func (d *fsNodeDB) load() error {
+	exists, err := d.meta.load()
+	if err != nil {
+		return err
+	}
+	if exists {
+		// Metadata already exists, just verify that it is compatible with what we have here.
+		if d.meta.value.Version != dbVersion {
+			return fmt.Errorf("incompatible database version (expected: %d got: %d)",
+				dbVersion,
+				d.meta.value.Version,
+			)
+		}
+		if !d.meta.value.Namespace.Equal(&d.namespace) {
+			return fmt.Errorf("incompatible namespace (expected: %s got: %s)",
+				d.namespace,
+				d.meta.value.Namespace,
+			)
+		}
+		return nil
+	}
+	if d.readOnly {
+		return api.ErrReadOnly
+	}
+
+	// No metadata exists, create some.
+	d.meta.value.Version = dbVersion
+	d.meta.value.Namespace = d.namespace
+	return d.meta.save(d.noFsync)
+}
+
+func (d *fsNodeDB) sanityCheckNamespace(ns common.Namespace) error {
+	if !ns.Equal(&d.namespace) {
+		return api.ErrBadNamespace
+	}
+	return nil
+}
+
+// Assumes lock is held when called.
+func (d *fsNodeDB) loadRootsMetadataLocked(version uint64) (*rootsMetadata, error) {
+	if rootsMeta, ok := d.rootsCache[version]; ok {
+		return rootsMeta, nil
+	}
+
+	rootsMeta := &rootsMetadata{version: version}
+	data, err := ioutil.ReadFile(d.rootsPath(version))
+	switch {
+	case err == nil:
+		if err = cbor.UnmarshalTrusted(data, &rootsMeta); err != nil {
+			return nil, fmt.Errorf("mkvs/fs: error reading roots metadata: %w", err)
+		}
+	case os.IsNotExist(err):
+		rootsMeta.Roots = make(map[typedHash][]typedHash)
+	default:
+		return nil, fmt.Errorf("mkvs/fs: error reading roots metadata: %w", err)
+	}
+
+	d.rootsCache[version] = rootsMeta
+	return rootsMeta, nil
+}
+
+// Assumes lock is held when called.
+func (d *fsNodeDB) saveRootsMetadataLocked(rootsMeta *rootsMetadata) error {
+	d.rootsCache[rootsMeta.version] = rootsMeta
+	return writeFile(d.rootsPath(rootsMeta.version), cbor.Marshal(rootsMeta), d.noFsync)
+}
+
+// Assumes lock is held when called.
+func (d *fsNodeDB) checkRootLocked(root node.Root) error {
+	rootsMeta, err := d.loadRootsMetadataLocked(root.Version)
+	if err != nil {
+		return err
+	}
+	if _, exists := rootsMeta.Roots[typedHashFromRoot(root)]; !exists {
+		return api.ErrRootNotFound
+	}
+	return nil
+}
+
+// Assumes lock is held when called.
+func (d *fsNodeDB) cleanMultipartLocked(removeNodes bool) error {
+	var version uint64
+
+	if d.multipartVersion != multipartVersionNone {
+		version = d.multipartVersion
+	} else {
+		version = d.meta.getMultipartVersion()
+	}
+	if version == multipartVersionNone {
+		// No multipart in progress, but it's not an error to call in a situation like this.
+		return nil
+	}
+
+	if removeNodes {
+		log := d.multipartLog
+		if d.multipartVersion == multipartVersionNone {
+			// Restore was interrupted, load the log from disk.
+			data, err := ioutil.ReadFile(d.path(multipartLogFile))
+			switch {
+			case err == nil:
+				if err = cbor.UnmarshalTrusted(data, &log); err != nil {
+					return fmt.Errorf("mkvs/fs: corrupted multipart log: %w", err)
+				}
+			case os.IsNotExist(err):
+			default:
+				return err
+			}
+		}
+
+		if len(log.Nodes) > 0 || len(log.Roots) > 0 {
+			d.logger.Info("removing some nodes from a multipart restore")
+		}
+		for _, h := range log.Nodes {
+			if err := os.Remove(d.nodePath(h)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if len(log.Roots) > 0 {
+			rootsMeta, err := d.loadRootsMetadataLocked(version)
+			if err != nil {
+				return err
+			}
+			for _, rootHash := range log.Roots {
+				delete(rootsMeta.Roots, rootHash)
+			}
+			if err = d.saveRootsMetadataLocked(rootsMeta); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := os.Remove(d.path(multipartLogFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := d.meta.setMultipartVersion(multipartVersionNone, d.noFsync); err != nil {
+		return err
+	}
+
+	d.multipartVersion = multipartVersionNone
+	d.multipartLog = multipartLog{}
+	return nil
+}
+
+func (d *fsNodeDB) GetNode(root node.Root, ptr *node.Pointer) (node.Node, error) {
+	if ptr == nil || !ptr.IsClean() {
+		panic("mkvs/fs: attempted to get invalid pointer from node database")
+	}
+	if err := d.sanityCheckNamespace(root.Namespace); err != nil {
+		return nil, err
+	}
+	// If the version is earlier than the earliest version, we don't have the node (it was pruned).
+	if root.Version < d.meta.getEarliestVersion() {
+		return nil, api.ErrNodeNotFound
+	}
+
+	d.lock.Lock()
+	err := d.checkRootLocked(root)
+	d.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.getNode(ptr.Hash)
+}
+
+func (d *fsNodeDB) getNode(h hash.Hash) (node.Node, error) {
+	data, err := ioutil.ReadFile(d.nodePath(h))
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return nil, api.ErrNodeNotFound
+	default:
+		d.logger.Error("failed to read node from backing store",
+			"err", err,
+		)
+		return nil, fmt.Errorf("mkvs/fs: failed to read node from backing store: %w", err)
+	}
+
+	n, err := node.UnmarshalBinary(data)
+	if err != nil {
+		d.logger.Error("failed to unmarshal node",
+			"err", err,
+		)
+		return nil, fmt.Errorf("mkvs/fs: failed to unmarshal node: %w", err)
+	}
+	return n, nil
+}
+
+func (d *fsNodeDB) GetWriteLog(ctx context.Context, startRoot, endRoot node.Root) (writelog.Iterator, error) {
+	if d.discardWriteLogs {
+		return nil, api.ErrWriteLogNotFound
+	}
+	if !endRoot.Follows(&startRoot) {
+		return nil, api.ErrRootMustFollowOld
+	}
+	if err := d.sanityCheckNamespace(startRoot.Namespace); err != nil {
+		return nil, err
+	}
+	// If the version is earlier than the earliest version, we don't have the roots.
+	if endRoot.Version < d.meta.getEarliestVersion() {
+		return nil, api.ErrWriteLogNotFound
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	// Check if the root actually exists.
+	if err := d.checkRootLocked(endRoot); err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(d.writeLogsPath(endRoot.Version))
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return nil, api.ErrWriteLogNotFound
+	default:
+		return nil, fmt.Errorf("mkvs/fs: failed to list write logs: %w", err)
+	}
+
+	// Index write logs by the root they result in.
+	logsByEndRoot := make(map[typedHash][]typedHash)
+	for _, f := range files {
+		endRootHash, startRootHash, ok := parseWriteLogName(f.Name())
+		if !ok {
+			continue
+		}
+		logsByEndRoot[endRootHash] = append(logsByEndRoot[endRootHash], startRootHash)
+	}
+
+	// Start at the end root and search towards the start root. Same as the Badger backend, we
+	// refuse to traverse more than two hops as the common cases are single hop state updates
+	// and two hop I/O updates.
+	const maxAllowedHops = 2
+
+	type wlItem struct {
+		depth       uint8
+		endRootHash typedHash
+		logPaths    []string
+		logRoots    []typedHash
+	}
+	queue := []*wlItem{{depth: 0, endRootHash: typedHashFromRoot(endRoot)}}
+	startRootHash := typedHashFromRoot(startRoot)
+	for len(queue) > 0 {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		curItem := queue[0]
+		queue = queue[1:]
+
+		for _, prevRootHash := range logsByEndRoot[curItem.endRootHash] {
+			nextItem := wlItem{
+				depth:       curItem.depth + 1,
+				endRootHash: prevRootHash,
+				logPaths:    append(append([]string{}, curItem.logPaths...), d.writeLogPath(endRoot.Version, curItem.endRootHash, prevRootHash)),
+				logRoots:    append(append([]typedHash{}, curItem.logRoots...), curItem.endRootHash),
+			}
+			if nextItem.endRootHash == startRootHash {
+				// Path has been found, deserialize and stream write logs.
+				var index int
+				return api.ReviveHashedDBWriteLogs(ctx,
+					func() (node.Root, api.HashedDBWriteLog, error) {
+						if index >= len(nextItem.logPaths) {
+							return node.Root{}, nil, nil
+						}
+
+						root := node.Root{
+							Namespace: endRoot.Namespace,
+							Version:   endRoot.Version,
+							Type:      nextItem.logRoots[index].Type(),
+							Hash:      nextItem.logRoots[index].Hash(),
+						}
+
+						data, err := ioutil.ReadFile(nextItem.logPaths[index])
+						if err != nil {
+							return node.Root{}, nil, err
+						}
+
+						var log api.HashedDBWriteLog
+						if err = cbor.UnmarshalTrusted(data, &log); err != nil {
+							return node.Root{}, nil, err
+						}
+
+						index++
+						return root, log, nil
+					},
+					func(root node.Root, h hash.Hash) (*node.LeafNode, error) {
+						leaf, err := d.GetNode(root, &node.Pointer{Hash: h, Clean: true})
+						if err != nil {
+							return nil, err
+						}
+						return leaf.(*node.LeafNode), nil
+					},
+					func() {},
+				)
+			}
+
+			if nextItem.depth < maxAllowedHops {
+				queue = append(queue, &nextItem)
+			}
+		}
+	}
+
+	return nil, api.ErrWriteLogNotFound
+}
+
+func (d *fsNodeDB) GetLatestVersion(ctx context.Context) (uint64, error) {
+	version, _ := d.meta.getLastFinalizedVersion()
+	return version, nil
+}
+
+func (d *fsNodeDB) GetEarliestVersion(ctx context.Context) (uint64, error) {
+	return d.meta.getEarliestVersion(), nil
+}
+
+func (d *fsNodeDB) GetRootsForVersion(ctx context.Context, version uint64) (roots []node.Root, err error) {
+	// If the version is earlier than the earliest version, we don't have the roots.
+	if version < d.meta.getEarliestVersion() {
+		return nil, nil
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	rootsMeta, err := d.loadRootsMetadataLocked(version)
+	if err != nil {
+		return nil, err
+	}
+
+	for rootHash := range rootsMeta.Roots {
+		roots = append(roots, node.Root{
+			Namespace: d.namespace,
+			Version:   version,
+			Type:      rootHash.Type(),
+			Hash:      rootHash.Hash(),
+		})
+	}
+	return
+}
+
+func (d *fsNodeDB) HasRoot(root node.Root) bool {
+	if err := d.sanityCheckNamespace(root.Namespace); err != nil {
+		return false
+	}
+
+	// An empty root is always implicitly present.
+	if root.Hash.IsEmpty() {
+		return true
+	}
+
+	// If the version is earlier than the earliest version, we don't have the root.
+	if root.Version < d.meta.getEarliestVersion() {
+		return false
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err := d.checkRootLocked(root); err != nil {
+		if err != api.ErrRootNotFound {
+			panic(err)
+		}
+		return false
+	}
+	return true
+}
+
+func (d *fsNodeDB) Finalize(ctx context.Context, roots []node.Root) error { // nolint: gocyclo
+	if d.readOnly {
+		return api.ErrReadOnly
+	}
+
+	if len(roots) == 0 {
+		return fmt.Errorf("mkvs/fs: need at least one root to finalize")
+	}
+	version := roots[0].Version
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone && d.multipartVersion != version {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	// Make sure that the previous version has been finalized (if we are not restoring).
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if d.multipartVersion == multipartVersionNone && version > 0 && exists && lastFinalizedVersion < (version-1) {
+		return api.ErrNotFinalized
+	}
+	// Make sure that this version has not yet been finalized.
+	if exists && version <= lastFinalizedVersion {
+		return api.ErrAlreadyFinalized
+	}
+
+	// Determine the set of finalized roots. Finalization is transitive, so if
+	// a parent root is finalized the child should be considered finalized too.
+	finalizedRoots := make(map[typedHash]bool)
+	for _, root := range roots {
+		if root.Version != version {
+			return fmt.Errorf("mkvs/fs: roots to finalize don't have matching versions")
+		}
+		finalizedRoots[typedHashFromRoot(root)] = true
+	}
+
+	rootsMeta, err := d.loadRootsMetadataLocked(version)
+	if err != nil {
+		return err
+	}
+
+	for updated := true; updated; {
+		updated = false
+
+		for rootHash, derivedRoots := range rootsMeta.Roots {
+			for _, nextRoot := range derivedRoots {
+				if !finalizedRoots[rootHash] && finalizedRoots[nextRoot] {
+					finalizedRoots[rootHash] = true
+					updated = true
+				}
+			}
+		}
+	}
+
+	// Sanity check the input roots list.
+	for iroot := range finalizedRoots {
+		h := iroot.Hash()
+		if _, ok := rootsMeta.Roots[iroot]; !ok && !h.IsEmpty() {
+			return api.ErrRootNotFound
+		}
+	}
+
+	// Remove any non-finalized roots together with their write logs.
+	var rootsChanged bool
+	for rootHash := range rootsMeta.Roots {
+		if finalizedRoots[rootHash] {
+			continue
+		}
+
+		delete(rootsMeta.Roots, rootHash)
+		rootsChanged = true
+
+		if err = d.removeWriteLogsLocked(version, rootHash.String()+writeLogSeparator); err != nil {
+			return err
+		}
+	}
+
+	// Save roots metadata if changed.
+	if rootsChanged {
+		if err = d.saveRootsMetadataLocked(rootsMeta); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to save roots metadata: %w", err)
+		}
+	}
+
+	// Update last finalized version.
+	if err = d.meta.setLastFinalizedVersion(version, d.noFsync); err != nil {
+		return fmt.Errorf("mkvs/fs: failed to set last finalized version: %w", err)
+	}
+
+	// Clean multipart metadata if there is any.
+	if d.multipartVersion != multipartVersionNone {
+		if err = d.cleanMultipartLocked(false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Assumes lock is held when called.
+func (d *fsNodeDB) removeWriteLogsLocked(version uint64, prefix string) error {
+	if d.discardWriteLogs {
+		return nil
+	}
+
+	dir := d.writeLogsPath(version)
+	files, err := ioutil.ReadDir(dir)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return nil
+	default:
+		return err
+	}
+
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name(), prefix) {
+			continue
+		}
+		if err = os.Remove(filepath.Join(dir, f.Name())); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to remove write log: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *fsNodeDB) Prune(ctx context.Context, version uint64) error {
+	if d.readOnly {
+		return api.ErrReadOnly
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone {
+		return api.ErrMultipartInProgress
+	}
+
+	// Make sure that the version that we try to prune has been finalized.
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if !exists || lastFinalizedVersion < version {
+		return api.ErrNotFinalized
+	}
+	// Make sure that the version that we are trying to prune is the earliest version.
+	if version != d.meta.getEarliestVersion() {
+		return api.ErrNotEarliest
+	}
+
+	// Remove all roots and write logs in version. Nodes may be shared with later
+	// versions so they are left in place.
+	if err := os.Remove(d.rootsPath(version)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("mkvs/fs: failed to remove roots metadata: %w", err)
+	}
+	delete(d.rootsCache, version)
+	if err := os.RemoveAll(d.writeLogsPath(version)); err != nil {
+		return fmt.Errorf("mkvs/fs: failed to remove write logs: %w", err)
+	}
+
+	// Update metadata.
+	if err := d.meta.setEarliestVersion(version+1, d.noFsync); err != nil {
+		return fmt.Errorf("mkvs/fs: failed to set earliest version: %w", err)
+	}
+	return nil
+}
+
+func (d *fsNodeDB) StartMultipartInsert(version uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if version == multipartVersionNone {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	if d.multipartVersion != multipartVersionNone {
+		if d.multipartVersion != version {
+			return api.ErrMultipartInProgress
+		}
+		// Multipart already initialized at the same version, so this was
+		// probably called e.g. as part of a further checkpoint restore.
+		return nil
+	}
+
+	if err := d.meta.setMultipartVersion(version, d.noFsync); err != nil {
+		return err
+	}
+
+	d.multipartVersion = version
+
+	return nil
+}
+
+func (d *fsNodeDB) AbortMultipartInsert() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.cleanMultipartLocked(true)
+}
+
+func (d *fsNodeDB) NewBatch(oldRoot node.Root, version uint64, chunk bool) (api.Batch, error) {
+	if d.readOnly {
+		return nil, api.ErrReadOnly
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone && d.multipartVersion != version {
+		return nil, api.ErrInvalidMultipartVersion
+	}
+	if chunk != (d.multipartVersion != multipartVersionNone) {
+		return nil, api.ErrMultipartInProgress
+	}
+
+	return &fsBatch{
+		db:      d,
+		nodes:   make(map[hash.Hash][]byte),
+		oldRoot: oldRoot,
+		chunk:   chunk,
+	}, nil
+}
+
+func (d *fsNodeDB) Size() (int64, error) {
+	var size int64
+	for _, dir := range []string{nodesDir, rootsDir, writeLogsDir} {
+		err := filepath.Walk(d.path(dir), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				size += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return size, nil
+}
+
+func (d *fsNodeDB) Sync() error {
+	// All files are synced as they are written unless NoFsync is used, in
+	// which case there is no single handle that could be synced.
+	return nil
+}
+
+func (d *fsNodeDB) Close() {
+}
+
+type fsBatch struct {
+	api.BaseBatch
+
+	db *fsNodeDB
+
+	nodes   map[hash.Hash][]byte
+	oldRoot node.Root
+	chunk   bool
+
+	writeLog    writelog.WriteLog
+	annotations writelog.Annotations
+}
+
+func (ba *fsBatch) MaybeStartSubtree(subtree api.Subtree, depth node.Depth, subtreeRoot *node.Pointer) api.Subtree {
+	if subtree == nil {
+		return &fsSubtree{batch: ba}
+	}
+	return subtree
+}
+
+func (ba *fsBatch) PutWriteLog(writeLog writelog.WriteLog, annotations writelog.Annotations) error {
+	if ba.chunk {
+		return fmt.Errorf("mkvs/fs: cannot put write log in chunk mode")
+	}
+	if ba.db.discardWriteLogs {
+		return nil
+	}
+
+	ba.writeLog = writeLog
+	ba.annotations = annotations
+	return nil
+}
+
+func (ba *fsBatch) RemoveNodes(nodes []node.Node) error {
+	if ba.chunk {
+		return fmt.Errorf("mkvs/fs: cannot remove nodes in chunk mode")
+	}
+
+	// Nodes are content-addressed and never garbage collected.
+	return nil
+}
+
+func (ba *fsBatch) Commit(root node.Root) error { // nolint: gocyclo
+	ba.db.lock.Lock()
+	defer ba.db.lock.Unlock()
+
+	if ba.db.multipartVersion != multipartVersionNone && ba.db.multipartVersion != root.Version {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	if err := ba.db.sanityCheckNamespace(root.Namespace); err != nil {
+		return err
+	}
+	if !root.Follows(&ba.oldRoot) {
+		return api.ErrRootMustFollowOld
+	}
+
+	// Make sure that the version that we try to commit into has not yet been finalized.
+	lastFinalizedVersion, exists := ba.db.meta.getLastFinalizedVersion()
+	if exists && lastFinalizedVersion >= root.Version {
+		return api.ErrAlreadyFinalized
+	}
+
+	rootsMeta, err := ba.db.loadRootsMetadataLocked(root.Version)
+	if err != nil {
+		return err
+	}
+
+	rootHash := typedHashFromRoot(root)
+	if rootsMeta.Roots[rootHash] != nil && !ba.chunk {
+		// Root already exists, no need to do anything since if the hash matches, everything will
+		// be identical and we would just be duplicating work.
+		ba.Reset()
+		return ba.BaseBatch.Commit(root)
+	}
+
+	// Update the root link for the old root.
+	var oldRootsMeta *rootsMetadata
+	oldRootHash := typedHashFromRoot(ba.oldRoot)
+	if !ba.chunk && !ba.oldRoot.Hash.IsEmpty() {
+		if ba.oldRoot.Version < ba.db.meta.getEarliestVersion() && ba.oldRoot.Version != root.Version {
+			return api.ErrPreviousVersionMismatch
+		}
+
+		oldRootsMeta, err = ba.db.loadRootsMetadataLocked(ba.oldRoot.Version)
+		if err != nil {
+			return err
+		}
+		if _, ok := oldRootsMeta.Roots[oldRootHash]; !ok {
+			return api.ErrRootNotFound
+		}
+	}
+
+	// Persist nodes first so that the roots never reference missing nodes.
+	var newNodes []hash.Hash
+	for h, data := range ba.nodes {
+		path := ba.db.nodePath(h)
+		if _, err = os.Stat(path); err == nil {
+			continue
+		}
+		if err = common.Mkdir(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to create node directory: %w", err)
+		}
+		if err = writeFile(path, data, ba.db.noFsync); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to write node: %w", err)
+		}
+		newNodes = append(newNodes, h)
+	}
+
+	if ba.chunk {
+		// Record everything inserted so that it can be removed in case the restore fails.
+		log := &ba.db.multipartLog
+		log.Nodes = append(log.Nodes, newNodes...)
+		if rootsMeta.Roots[rootHash] == nil {
+			log.Roots = append(log.Roots, rootHash)
+		}
+		if err = writeFile(ba.db.path(multipartLogFile), cbor.Marshal(log), ba.db.noFsync); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to write multipart log: %w", err)
+		}
+	}
+
+	// Store write log.
+	if !ba.chunk && ba.writeLog != nil && ba.annotations != nil {
+		log := api.MakeHashedDBWriteLog(ba.writeLog, ba.annotations)
+		if err = common.Mkdir(ba.db.writeLogsPath(root.Version)); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to create write log directory: %w", err)
+		}
+		if err = writeFile(ba.db.writeLogPath(root.Version, rootHash, oldRootHash), cbor.Marshal(log), ba.db.noFsync); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to write write log: %w", err)
+		}
+	}
+
+	// Commit root metadata updates. This is done last, so in case we fail, we can still retry.
+	if rootsMeta.Roots[rootHash] == nil {
+		// Create root with no derived roots.
+		rootsMeta.Roots[rootHash] = []typedHash{}
+		if err = ba.db.saveRootsMetadataLocked(rootsMeta); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to save roots metadata: %w", err)
+		}
+	}
+	if oldRootsMeta != nil {
+		oldRootsMeta.Roots[oldRootHash] = append(oldRootsMeta.Roots[oldRootHash], rootHash)
+		if err = ba.db.saveRootsMetadataLocked(oldRootsMeta); err != nil {
+			return fmt.Errorf("mkvs/fs: failed to save old roots metadata: %w", err)
+		}
+	}
+
+	ba.Reset()
+
+	return ba.BaseBatch.Commit(root)
+}
+
+func (ba *fsBatch) Reset() {
+	ba.nodes = make(map[hash.Hash][]byte)
+	ba.writeLog = nil
+	ba.annotations = nil
+}
+
+type fsSubtree struct {
+	batch *fsBatch
+}
+
+func (s *fsSubtree) PutNode(depth node.Depth, ptr *node.Pointer) error {
+	data, err := ptr.Node.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	s.batch.nodes[ptr.Node.GetHash()] = data
+	return nil
+}
+
+func (s *fsSubtree) VisitCleanNode(depth node.Depth, ptr *node.Pointer) error {
+	return nil
+}
+
+func (s *fsSubtree) Commit() error {
+	return nil
+}
+
+func parseWriteLogName(name string) (endRootHash, startRootHash typedHash, ok bool) {
+	parts := strings.Split(name, writeLogSeparator)
+	if len(parts) != 2 {
+		return
+	}
+	if err := endRootHash.UnmarshalHex(parts[0]); err != nil {
+		return
+	}
+	if err := startRootHash.UnmarshalHex(parts[1]); err != nil {
+		return
+	}
+	ok = true
+	return
+}
+
+// writeFile atomically replaces the file at path with the given data.
+func writeFile(path string, data []byte, noFsync bool) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	defer os.Remove(tmpPath) // Fails if the file has already been renamed.
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if !noFsync {
+		if err = f.Sync(); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,203 @@
+package fs
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+const typedHashSize = hash.Size + 1
+
+// typedHash is a node hash prefixed with its root type.
+type typedHash [typedHashSize]byte
+
+// MarshalBinary encodes a typed hash into binary form.
+func (h *typedHash) MarshalBinary() (data []byte, err error) {
+	data = append([]byte{}, h[:]...)
+	return
+}
+
+// UnmarshalBinary decodes a binary marshaled typed hash.
+func (h *typedHash) UnmarshalBinary(data []byte) error {
+	if len(data) != typedHashSize {
+		return hash.ErrMalformed
+	}
+
+	copy(h[:], data)
+
+	return nil
+}
+
+// UnmarshalHex deserializes a hexadecimal text string into the given type.
+func (h *typedHash) UnmarshalHex(text string) error {
+	b, err := hex.DecodeString(text)
+	if err != nil {
+		return err
+	}
+
+	return h.UnmarshalBinary(b)
+}
+
+// Type returns the storage type of the root corresponding to this typed hash.
+func (h *typedHash) Type() node.RootType {
+	return node.RootType(h[0])
+}
+
+// Hash returns the hash portion of the typed hash.
+func (h *typedHash) Hash() (rh hash.Hash) {
+	copy(rh[:], h[1:])
+	return
+}
+
+// String returns the hex representation of a typed hash, used as a file name component.
+func (h typedHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// typedHashFromRoot creates a new typed hash corresponding to the given storage root.
+func typedHashFromRoot(root node.Root) (h typedHash) {
+	h[0] = byte(root.Type)
+	copy(h[1:], root.Hash[:])
+	return
+}
+
+// serializedMetadata is the on-disk serialized metadata.
+type serializedMetadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+	// Namespace is the namespace this database is for.
+	Namespace common.Namespace `json:"namespace"`
+
+	// EarliestVersion is the earliest version.
+	EarliestVersion uint64 `json:"earliest_version"`
+	// LastFinalizedVersion is the last finalized version.
+	LastFinalizedVersion *uint64 `json:"last_finalized_version"`
+	// MultipartVersion is the version for the in-progress multipart restore, or 0 if none was in progress.
+	MultipartVersion uint64 `json:"multipart_version"`
+}
+
+// metadata is the database metadata.
+type metadata struct {
+	sync.RWMutex
+
+	path  string
+	value serializedMetadata
+}
+
+func (m *metadata) load() (bool, error) {
+	data, err := ioutil.ReadFile(m.path)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return false, nil
+	default:
+		return false, err
+	}
+
+	if err = cbor.UnmarshalTrusted(data, &m.value); err != nil {
+		return false, fmt.Errorf("mkvs/fs: corrupted metadata: %w", err)
+	}
+	return true, nil
+}
+
+func (m *metadata) getEarliestVersion() uint64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.value.EarliestVersion
+}
+
+func (m *metadata) setEarliestVersion(version uint64, noFsync bool) error {
+	m.Lock()
+	defer m.Unlock()
+
+	// The earliest version can only increase, not decrease.
+	if version < m.value.EarliestVersion {
+		return nil
+	}
+
+	m.value.EarliestVersion = version
+	return m.saveLocked(noFsync)
+}
+
+func (m *metadata) getLastFinalizedVersion() (uint64, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if m.value.LastFinalizedVersion == nil {
+		return 0, false
+	}
+	return *m.value.LastFinalizedVersion, true
+}
+
+func (m *metadata) setLastFinalizedVersion(version uint64, noFsync bool) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.value.LastFinalizedVersion != nil && version <= *m.value.LastFinalizedVersion {
+		return nil
+	}
+
+	if m.value.LastFinalizedVersion == nil {
+		m.value.EarliestVersion = version
+	}
+
+	m.value.LastFinalizedVersion = &version
+	return m.saveLocked(noFsync)
+}
+
+func (m *metadata) getMultipartVersion() uint64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.value.MultipartVersion
+}
+
+func (m *metadata) setMultipartVersion(version uint64, noFsync bool) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.value.MultipartVersion = version
+	return m.saveLocked(noFsync)
+}
+
+func (m *metadata) save(noFsync bool) error {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.saveLocked(noFsync)
+}
+
+func (m *metadata) saveLocked(noFsync bool) error {
+	return writeFile(m.path, cbor.Marshal(m.value), noFsync)
+}
+
+// rootsMetadata manages the roots metadata for a given version.
+//
+// NOTE: Public fields of this structure are part of the on-disk format.
+type rootsMetadata struct {
+	_ struct{} `cbor:",toarray"`
+
+	// Roots is the map of a root created in a version to any derived roots (in this or later versions).
+	Roots map[typedHash][]typedHash
+
+	// version is the version this metadata is for.
+	version uint64
+}
+
+// multipartLog is the log of nodes and roots inserted during a multipart restore.
+//
+// NOTE: Public fields of this structure are part of the on-disk format.
+type multipartLog struct {
+	// Nodes are the hashes of nodes that did not exist before the restore started.
+	Nodes []hash.Hash `json:"nodes"`
+	// Roots are the roots committed during the restore.
+	Roots []typedHash `json:"roots"`
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	badgerDb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	fsDb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/fs"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 	mkvsTests "github.com/oasisprotocol/oasis-core/go/storage/mkvs/tests"
@@ -2293,6 +2294,32 @@ func TestBadgerBackend(t *testing.T) {
 	}, nil)
 }
 
+func TestFilesystemBackend(t *testing.T) {
+	testBackend(t, func(t *testing.T) (NodeDBFactory, func()) {
+		// Create a new random temporary directory under /tmp.
+		dir, err := ioutil.TempDir("", "mkvs.test.fs")
+		require.NoError(t, err, "TempDir")
+
+		// Create a filesystem-backed Node DB factory.
+		factory := func(ns common.Namespace) (db.NodeDB, error) {
+			return fsDb.New(&db.Config{
+				DB:        dir,
+				NoFsync:   true,
+				Namespace: ns,
+			})
+		}
+
+		cleanup := func() {
+			os.RemoveAll(dir)
+		}
+
+		return factory, cleanup
+	}, []string{
+		// Creating a file per node makes this test prohibitively slow.
+		"LargeUpdates",
+	})
+}
+
 func BenchmarkInsertCommitBatch1(b *testing.B) {
 	benchmarkInsertBatch(b, 1, true)
 }
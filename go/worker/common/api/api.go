@@ -4,8 +4,31 @@ import (
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
+// TEEState is the state of the hosted runtime's TEE attestation.
+type TEEState string
+
+const (
+	// TEEStatePending means that the runtime has not yet been attested.
+	TEEStatePending TEEState = "pending"
+	// TEEStateReady means that the runtime has been successfully attested.
+	TEEStateReady TEEState = "ready"
+	// TEEStateFailed means that the runtime attestation has failed.
+	TEEStateFailed TEEState = "failed"
+)
+
+// TEEStatus is the hosted runtime's TEE attestation status.
+type TEEStatus struct {
+	// State is the current TEE attestation state.
+	State TEEState `json:"state"`
+	// LastError is the error of the last failed attestation (if any).
+	LastError string `json:"last_error,omitempty"`
+}
+
 // Status is the common runtime worker status.
 type Status struct {
+	// Initialized indicates whether the committee node has been initialized.
+	Initialized bool `json:"initialized"`
+
 	// LatestRound is the latest runtime round as seen by the committee node.
 	LatestRound uint64 `json:"latest_round"`
 	// LatestHeight is the consensus layer height containing the runtime block for the latest round.
@@ -24,4 +47,8 @@ type Status struct {
 
 	// Peers is the list of peers in the runtime P2P network.
 	Peers []string `json:"peers"`
+
+	// TEE is the TEE attestation status of the hosted runtime in case the runtime is hosted by
+	// this node and requires a TEE.
+	TEE *TEEStatus `json:"tee,omitempty"`
 }
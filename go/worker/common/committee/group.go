@@ -556,10 +556,11 @@ func (g *Group) Start() error {
 	g.Lock()
 	defer g.Unlock()
 
+	scOpts := storageClient.NewOptionsFromConfig()
+
 	// Check if we have the local storage backend available (e.g., this node is also a storage node
 	// for this runtime). In this case we override the storage client's backend so that any updates
 	// don't go via gRPC but are redirected directly to the local backend instead.
-	var scOpts []storageClient.Option
 	if lsb, ok := g.runtime.Storage().(storage.LocalBackend); ok && g.runtime.HasRoles(node.RoleStorageWorker) {
 		// Make sure to unwrap the local backend as we need the raw local backend here.
 		if wrapped, ok := lsb.(storage.WrappedLocalBackend); ok {
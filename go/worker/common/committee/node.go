@@ -51,6 +51,13 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	teeAttestationFailureCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_tee_attestation_failure_count",
+			Help: "Number of failed hosted runtime TEE attestations.",
+		},
+		[]string{"runtime"},
+	)
 	epochNumber = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "oasis_worker_epoch_number",
@@ -64,6 +71,7 @@ var (
 		processedEventCount,
 		failedRoundCount,
 		epochTransitionCount,
+		teeAttestationFailureCount,
 		epochNumber,
 	}
 
@@ -101,6 +109,7 @@ type Node struct {
 	KeyManagerClient *keymanagerClient.Client
 	Consensus        consensus.Backend
 	Group            *Group
+	TEE              *TEEStatusTracker
 
 	ctx       context.Context
 	cancelCtx context.CancelFunc
@@ -169,6 +178,11 @@ func (n *Node) GetStatus(ctx context.Context) (*api.Status, error) {
 	defer n.CrossNode.Unlock()
 
 	var status api.Status
+	select {
+	case <-n.initCh:
+		status.Initialized = true
+	default:
+	}
 	if n.CurrentBlock != nil {
 		status.LatestRound = n.CurrentBlock.Header.Round
 		status.LatestHeight = n.CurrentBlockHeight
@@ -185,6 +199,7 @@ func (n *Node) GetStatus(ctx context.Context) (*api.Status, error) {
 	status.IsTransactionScheduler = epoch.IsTransactionScheduler(status.LatestRound)
 
 	status.Peers = n.Group.Peers()
+	status.TEE = n.TEE.Status()
 
 	return &status, nil
 }
@@ -504,6 +519,7 @@ func NewNode(
 		Identity:   identity,
 		KeyManager: keymanager,
 		Consensus:  consensus,
+		TEE:        NewTEEStatusTracker(runtime.ID()),
 		ctx:        ctx,
 		cancelCtx:  cancel,
 		stopCh:     make(chan struct{}),
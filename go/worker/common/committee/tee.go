@@ -0,0 +1,223 @@
+package committee
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/api"
+)
+
+// DefaultTEEAttestationTimeout is the default maximum amount of time a hosted runtime may take to
+// complete TEE attestation before it is considered failed.
+const DefaultTEEAttestationTimeout = 5 * time.Minute
+
+// TEEStatusTracker tracks the TEE attestation status of a hosted runtime based on the runtime
+// host events.
+type TEEStatusTracker struct {
+	sync.Mutex
+
+	timeout     time.Duration
+	timer       *time.Timer
+	status      *api.TEEStatus
+	constraints []byte
+
+	// capabilityTEE is the TEE capability last reported by the hosted runtime.
+	capabilityTEE *node.CapabilityTEE
+
+	labels prometheus.Labels
+	logger *logging.Logger
+}
+
+// SetTimeout sets the maximum amount of time a hosted runtime may take to complete TEE
+// attestation. It only affects attestations started after the timeout has been set.
+func (t *TEEStatusTracker) SetTimeout(timeout time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.timeout = timeout
+}
+
+// SetConstraints sets the TEE-specific constraints (e.g., the allowed enclave identities) of the
+// runtime's registry descriptor that the hosted runtime's TEE capability must satisfy. In case no
+// constraints are set, any TEE capability is accepted.
+//
+// The TEE capability last reported by the hosted runtime (if any) is re-checked against the new
+// constraints.
+func (t *TEEStatusTracker) SetConstraints(constraints []byte) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.constraints = constraints
+
+	if t.status == nil || t.capabilityTEE == nil {
+		return
+	}
+	err := t.verifyCapabilityLocked(t.capabilityTEE)
+	switch {
+	case err != nil && t.status.State != api.TEEStateFailed:
+		t.setFailedLocked(err)
+	case err == nil && t.status.State != api.TEEStateReady:
+		t.setReadyLocked()
+	}
+}
+
+// Reset marks the hosted runtime as requiring TEE attestation and resets its status to pending.
+//
+// It should be called before provisioning a hosted runtime that requires a TEE. Until it is called,
+// runtime host events are ignored.
+func (t *TEEStatusTracker) Reset() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.setPendingLocked()
+}
+
+func (t *TEEStatusTracker) setPendingLocked() {
+	t.stopTimerLocked()
+	t.capabilityTEE = nil
+	t.status = &api.TEEStatus{
+		State: api.TEEStatePending,
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(t.timeout, func() {
+		t.Lock()
+		defer t.Unlock()
+
+		// Make sure the timer was not superseded in the meantime.
+		if t.timer != timer {
+			return
+		}
+		t.timer = nil
+		t.setFailedLocked(fmt.Errorf("attestation not completed within %s", t.timeout))
+	})
+	t.timer = timer
+}
+
+func (t *TEEStatusTracker) setReadyLocked() {
+	t.stopTimerLocked()
+	t.status = &api.TEEStatus{
+		State: api.TEEStateReady,
+	}
+}
+
+func (t *TEEStatusTracker) setFailedLocked(err error) {
+	t.stopTimerLocked()
+	t.status = &api.TEEStatus{
+		State:     api.TEEStateFailed,
+		LastError: err.Error(),
+	}
+	teeAttestationFailureCount.With(t.labels).Inc()
+
+	t.logger.Error("hosted runtime TEE attestation failed",
+		"err", err,
+	)
+}
+
+func (t *TEEStatusTracker) stopTimerLocked() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+func (t *TEEStatusTracker) verifyCapabilityLocked(capabilityTEE *node.CapabilityTEE) error {
+	if t.constraints == nil {
+		return nil
+	}
+	if err := capabilityTEE.Verify(time.Now(), t.constraints); err != nil {
+		return fmt.Errorf("incompatible TEE capability: %w", err)
+	}
+	return nil
+}
+
+// HandleEvent updates the TEE attestation status based on the given runtime host event.
+func (t *TEEStatusTracker) HandleEvent(ev *host.Event) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.status == nil {
+		// Runtime does not require a TEE.
+		return
+	}
+
+	switch {
+	case ev.Started != nil:
+		if ev.Started.CapabilityTEE == nil {
+			t.setFailedLocked(fmt.Errorf("runtime started without a TEE capability"))
+			return
+		}
+		t.capabilityTEE = ev.Started.CapabilityTEE
+		if err := t.verifyCapabilityLocked(t.capabilityTEE); err != nil {
+			t.setFailedLocked(err)
+			return
+		}
+		t.setReadyLocked()
+	case ev.Updated != nil:
+		if ev.Updated.CapabilityTEE == nil {
+			return
+		}
+		t.capabilityTEE = ev.Updated.CapabilityTEE
+		if err := t.verifyCapabilityLocked(t.capabilityTEE); err != nil {
+			t.setFailedLocked(err)
+			return
+		}
+		t.setReadyLocked()
+	case ev.FailedToStart != nil:
+		t.capabilityTEE = nil
+		t.setFailedLocked(ev.FailedToStart.Error)
+	case ev.Stopped != nil:
+		// The runtime will need to be attested again once restarted.
+		lastError := t.status.LastError
+		t.setPendingLocked()
+		t.status.LastError = lastError
+	}
+}
+
+// Status returns the current TEE attestation status or nil in case the hosted runtime does not
+// require a TEE.
+func (t *TEEStatusTracker) Status() *api.TEEStatus {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.status == nil {
+		return nil
+	}
+	status := *t.status
+	return &status
+}
+
+// IsReady returns true iff the hosted runtime either does not require a TEE or its TEE capability
+// has been attested and is compatible with the configured constraints.
+func (t *TEEStatusTracker) IsReady() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.status == nil || t.status.State == api.TEEStateReady
+}
+
+// Stop stops the TEE status tracker.
+func (t *TEEStatusTracker) Stop() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.stopTimerLocked()
+}
+
+// NewTEEStatusTracker creates a new TEE attestation status tracker.
+func NewTEEStatusTracker(runtimeID common.Namespace) *TEEStatusTracker {
+	return &TEEStatusTracker{
+		timeout: DefaultTEEAttestationTimeout,
+		labels: prometheus.Labels{
+			"runtime": runtimeID.String(),
+		},
+		logger: logging.GetLogger("worker/common/committee/tee").With("runtime_id", runtimeID),
+	}
+}
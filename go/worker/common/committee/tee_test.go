@@ -0,0 +1,108 @@
+package committee
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/api"
+)
+
+func TestTEEStatusTracker(t *testing.T) {
+	require := require.New(t)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("tee status tracker test"), 0)
+	tracker := NewTEEStatusTracker(runtimeID)
+	defer tracker.Stop()
+	failures := teeAttestationFailureCount.With(tracker.labels)
+	initialFailures := testutil.ToFloat64(failures)
+
+	// Events should be ignored for runtimes that do not require a TEE.
+	tracker.HandleEvent(&host.Event{FailedToStart: &host.FailedToStartEvent{Error: errors.New("failed")}})
+	require.Nil(tracker.Status(), "status should not be reported for runtimes not requiring a TEE")
+	require.EqualValues(initialFailures, testutil.ToFloat64(failures))
+
+	tracker.Reset()
+	require.EqualValues(api.TEEStatePending, tracker.Status().State)
+
+	// Failed attestation.
+	tracker.HandleEvent(&host.Event{FailedToStart: &host.FailedToStartEvent{
+		Error: errors.New("failed to initialize TEE: attestation failed"),
+	}})
+	status := tracker.Status()
+	require.EqualValues(api.TEEStateFailed, status.State)
+	require.Equal("failed to initialize TEE: attestation failed", status.LastError)
+	require.EqualValues(initialFailures+1, testutil.ToFloat64(failures), "failure metric should be incremented")
+
+	// Successful attestation.
+	tracker.HandleEvent(&host.Event{Started: &host.StartedEvent{CapabilityTEE: &node.CapabilityTEE{}}})
+	status = tracker.Status()
+	require.EqualValues(api.TEEStateReady, status.State)
+	require.Empty(status.LastError)
+
+	// Attestation timeout.
+	tracker.SetTimeout(10 * time.Millisecond)
+	tracker.Reset()
+	require.Eventually(func() bool {
+		return tracker.Status().State == api.TEEStateFailed
+	}, time.Second, 5*time.Millisecond, "attestation should time out")
+	require.NotEmpty(tracker.Status().LastError)
+	require.EqualValues(initialFailures+2, testutil.ToFloat64(failures), "failure metric should be incremented")
+}
+
+func TestTEEStatusTrackerConstraints(t *testing.T) {
+	require := require.New(t)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("tee status tracker constraints test"), 0)
+	tracker := NewTEEStatusTracker(runtimeID)
+	defer tracker.Stop()
+	require.True(tracker.IsReady(), "runtimes not requiring a TEE should always be ready")
+
+	tracker.Reset()
+	tracker.SetConstraints(cbor.Marshal(sgx.Constraints{
+		Enclaves: []sgx.EnclaveIdentity{{}},
+	}))
+	require.False(tracker.IsReady(), "runtime should not be ready before attestation")
+
+	// Incompatible TEE capability.
+	tracker.HandleEvent(&host.Event{Started: &host.StartedEvent{CapabilityTEE: &node.CapabilityTEE{}}})
+	status := tracker.Status()
+	require.EqualValues(api.TEEStateFailed, status.State)
+	require.Contains(status.LastError, "incompatible TEE capability")
+	require.False(tracker.IsReady(), "runtime with an incompatible TEE capability should not be ready")
+
+	tracker.HandleEvent(&host.Event{Updated: &host.UpdatedEvent{CapabilityTEE: &node.CapabilityTEE{}}})
+	require.EqualValues(api.TEEStateFailed, tracker.Status().State)
+	require.False(tracker.IsReady(), "runtime with an incompatible TEE capability should not be ready")
+
+	// Without constraints, any TEE capability should be accepted, including the already reported
+	// one.
+	tracker.SetConstraints(nil)
+	require.EqualValues(api.TEEStateReady, tracker.Status().State)
+	require.True(tracker.IsReady(), "runtime should be ready once constraints are relaxed")
+	tracker.HandleEvent(&host.Event{Updated: &host.UpdatedEvent{CapabilityTEE: &node.CapabilityTEE{}}})
+	require.EqualValues(api.TEEStateReady, tracker.Status().State)
+	require.True(tracker.IsReady(), "runtime should be ready")
+
+	// Constraint changes should re-check the already reported TEE capability.
+	tracker.SetConstraints(cbor.Marshal(sgx.Constraints{
+		Enclaves: []sgx.EnclaveIdentity{{}},
+	}))
+	status = tracker.Status()
+	require.EqualValues(api.TEEStateFailed, status.State)
+	require.Contains(status.LastError, "incompatible TEE capability")
+	require.False(tracker.IsReady(), "runtime should not be ready once its enclave is no longer allowed")
+
+	// Constraints should not affect a runtime that is still pending attestation.
+	tracker.Reset()
+	tracker.SetConstraints(nil)
+	require.EqualValues(api.TEEStatePending, tracker.Status().State)
+}
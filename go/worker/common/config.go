@@ -10,6 +10,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/configparser"
 )
 
@@ -25,6 +26,11 @@ var (
 
 	cfgStorageCommitTimeout = "worker.storage_commit_timeout"
 
+	cfgRuntimeInitTimeout       = "worker.runtime_init.timeout"
+	cfgRuntimeInitSkipOnTimeout = "worker.runtime_init.skip_on_timeout"
+
+	cfgTEEAttestationTimeout = "worker.tee.attestation_timeout"
+
 	// Flags has the configuration flags.
 	Flags = flag.NewFlagSet("", flag.ContinueOnError)
 )
@@ -37,6 +43,17 @@ type Config struct { // nolint: maligned
 
 	StorageCommitTimeout time.Duration
 
+	// RuntimeInitTimeout is the maximum amount of time runtimes may take to initialize before
+	// they are reported as stuck (0 means no timeout).
+	RuntimeInitTimeout time.Duration
+	// RuntimeInitSkipOnTimeout specifies whether the worker should be considered initialized
+	// without the runtimes that failed to initialize within RuntimeInitTimeout.
+	RuntimeInitSkipOnTimeout bool
+
+	// TEEAttestationTimeout is the maximum amount of time a hosted runtime may take to complete
+	// TEE attestation before it is considered failed.
+	TEEAttestationTimeout time.Duration
+
 	logger *logging.Logger
 }
 
@@ -84,11 +101,14 @@ func NewConfig() (*Config, error) {
 	}
 
 	cfg := Config{
-		ClientPort:           uint16(viper.GetInt(CfgClientPort)),
-		ClientAddresses:      clientAddresses,
-		SentryAddresses:      sentryAddresses,
-		StorageCommitTimeout: viper.GetDuration(cfgStorageCommitTimeout),
-		logger:               logging.GetLogger("worker/config"),
+		ClientPort:               uint16(viper.GetInt(CfgClientPort)),
+		ClientAddresses:          clientAddresses,
+		SentryAddresses:          sentryAddresses,
+		StorageCommitTimeout:     viper.GetDuration(cfgStorageCommitTimeout),
+		RuntimeInitTimeout:       viper.GetDuration(cfgRuntimeInitTimeout),
+		RuntimeInitSkipOnTimeout: viper.GetBool(cfgRuntimeInitSkipOnTimeout),
+		TEEAttestationTimeout:    viper.GetDuration(cfgTEEAttestationTimeout),
+		logger:                   logging.GetLogger("worker/config"),
 	}
 
 	return &cfg, nil
@@ -100,6 +120,9 @@ func init() {
 	Flags.StringSlice(CfgSentryAddresses, []string{}, "Address(es) of sentry node(s) to connect to of the form [PubKey@]ip:port (where PubKey@ part represents base64 encoded node TLS public key)")
 
 	Flags.Duration(cfgStorageCommitTimeout, 10*time.Second, "Storage commit timeout")
+	Flags.Duration(cfgRuntimeInitTimeout, 10*time.Minute, "Runtime initialization timeout after which stuck runtimes are reported (0 = no timeout)")
+	Flags.Bool(cfgRuntimeInitSkipOnTimeout, false, "Continue without runtimes that fail to initialize within the runtime initialization timeout")
+	Flags.Duration(cfgTEEAttestationTimeout, committee.DefaultTEEAttestationTimeout, "Maximum time a hosted runtime may take to complete TEE attestation")
 
 	_ = viper.BindPFlags(Flags)
 }
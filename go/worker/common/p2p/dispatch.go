@@ -86,7 +86,7 @@ type queuedMsg struct {
 	msg    *Message
 }
 
-func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.PeerID, envelope *pubsub.Message) bool {
+func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.PeerID, envelope *pubsub.Message) pubsub.ValidationResult {
 	// Tease apart the pubsub message envelope and convert it to
 	// the expected format.
 
@@ -96,13 +96,29 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 		"received_from", envelope.ReceivedFrom,
 	)
 
+	// Make sure messages from other peers are within limits. Rate limits are applied to the peer
+	// that we received the message from as that is the peer that is sending us traffic.
+	//
+	// Messages over the limits are ignored instead of rejected, so that honest peers which are
+	// only relaying messages are not penalized.
+	if envelope.ReceivedFrom != h.p2p.host.ID() {
+		if err := h.p2p.limits.check(envelope.ReceivedFrom, len(envelope.GetData())); err != nil {
+			h.logger.Warn("ignoring message from peer",
+				"err", err,
+				"peer_id", peerID,
+				"received_from", envelope.ReceivedFrom,
+			)
+			return pubsub.ValidationIgnore
+		}
+	}
+
 	id, err := peerIDToPublicKey(peerID)
 	if err != nil {
 		h.logger.Error("error while extracting public key from peer ID",
 			"err", err,
 			"peer_id", peerID,
 		)
-		return false
+		return pubsub.ValidationReject
 	}
 
 	var msg Message
@@ -111,7 +127,7 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 			"err", err,
 			"peer_id", peerID,
 		)
-		return false
+		return pubsub.ValidationReject
 	}
 
 	// Dispatch the message.  Yes, from the topic validator.  The
@@ -126,13 +142,13 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 
 	// If the message will never become valid, do not relay.
 	if err = h.dispatchMessage(peerID, m, true); !p2pError.ShouldRelay(err) {
-		return false
+		return pubsub.ValidationReject
 	}
 
 	// Note: Messages that may become valid (in-line dispatch
 	// failed due to non-permanent error, retry started) will be
 	// relayed.
-	return true
+	return pubsub.ValidationAccept
 }
 
 func (h *topicHandler) dispatchMessage(peerID core.PeerID, m *queuedMsg, isInitial bool) (retErr error) {
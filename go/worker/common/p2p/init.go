@@ -27,6 +27,14 @@ const (
 	// CfgP2PConnectednessLowWater sets the ratio of connected to unconnected peers at which
 	// the peer manager will try to reconnect to disconnected nodes.
 	CfgP2PConnectednessLowWater = "worker.p2p.connectedness_low_water"
+	// CfgP2PMaxMessageSize sets the maximum size (in bytes) of P2P messages.
+	CfgP2PMaxMessageSize = "worker.p2p.max_message_size"
+	// CfgP2PPeerMessageRate sets the maximum rate (in messages per second) of P2P messages
+	// accepted from a single peer. Setting it to zero disables rate limiting.
+	CfgP2PPeerMessageRate = "worker.p2p.peer_message_rate"
+	// CfgP2PPeerMessageBurst sets the maximum burst size of P2P messages accepted from a single
+	// peer.
+	CfgP2PPeerMessageBurst = "worker.p2p.peer_message_burst"
 )
 
 // Enabled reads our enabled flag from viper.
@@ -46,6 +54,9 @@ func init() {
 	Flags.Int64(CfgP2PValidateConcurrency, 1024, "Set libp2p gossipsub per topic validator concurrency limit")
 	Flags.Int64(CfgP2PValidateThrottle, 8192, "Set libp2p gossipsub validator concurrency limit")
 	Flags.Float64(CfgP2PConnectednessLowWater, 0.2, "Set the low water mark at which the peer manager will try to reconnect to peers")
+	Flags.Int(CfgP2PMaxMessageSize, 1024*1024, "Set the maximum size (in bytes) of P2P messages")
+	Flags.Float64(CfgP2PPeerMessageRate, 0, "Set the maximum rate (in messages per second) of P2P messages accepted from a single peer (0 disables rate limiting)")
+	Flags.Uint64(CfgP2PPeerMessageBurst, 200, "Set the maximum burst size of P2P messages accepted from a single peer")
 
 	_ = viper.BindPFlags(Flags)
 }
@@ -0,0 +1,126 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	core "github.com/libp2p/go-libp2p-core"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxTrackedPeers is the number of tracked peers after which the rate limiter will start pruning
+// buckets of idle peers.
+const maxTrackedPeers = 1024
+
+var (
+	errMessageTooLarge = errors.New("message too large")
+	errRateLimited     = errors.New("peer message rate limit exceeded")
+
+	rejectedMessageCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_p2p_rejected_message_count",
+			Help: "Number of P2P messages rejected due to exceeding limits.",
+		},
+		[]string{"reason"},
+	)
+
+	p2pCollectors = []prometheus.Collector{
+		rejectedMessageCount,
+	}
+
+	metricsOnce sync.Once
+)
+
+// messageLimits are the limits applied to incoming P2P messages.
+type messageLimits struct {
+	maxMessageSize int
+	limiter        *peerRateLimiter
+}
+
+// check checks whether a message of the given size received from the given peer is within limits.
+func (l *messageLimits) check(peerID core.PeerID, size int) error {
+	if l.maxMessageSize > 0 && size > l.maxMessageSize {
+		rejectedMessageCount.With(prometheus.Labels{"reason": "size"}).Inc()
+		return fmt.Errorf("%w (size: %d max: %d)", errMessageTooLarge, size, l.maxMessageSize)
+	}
+	if !l.limiter.allow(peerID) {
+		rejectedMessageCount.With(prometheus.Labels{"reason": "rate"}).Inc()
+		return errRateLimited
+	}
+	return nil
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// peerRateLimiter is a per-peer token bucket rate limiter.
+type peerRateLimiter struct {
+	sync.Mutex
+
+	rate  float64
+	burst float64
+
+	buckets map[core.PeerID]*tokenBucket
+	now     func() time.Time
+}
+
+// allow returns true iff a message from the given peer is allowed under the rate limit.
+func (l *peerRateLimiter) allow(peerID core.PeerID) bool {
+	if l == nil || l.rate <= 0 {
+		return true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	b := l.buckets[peerID]
+	if b == nil {
+		if len(l.buckets) >= maxTrackedPeers {
+			l.pruneLocked(now)
+		}
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[peerID] = b
+	}
+
+	// Refill the bucket based on the elapsed time.
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// pruneLocked removes buckets of peers that have been idle long enough for their buckets to be
+// fully refilled as such buckets are equivalent to new buckets.
+func (l *peerRateLimiter) pruneLocked(now time.Time) {
+	for peerID, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, peerID)
+		}
+	}
+}
+
+// newPeerRateLimiter creates a new per-peer rate limiter allowing the given number of messages
+// per second with the given burst size. A zero rate disables rate limiting.
+func newPeerRateLimiter(rate float64, burst uint64) *peerRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &peerRateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[core.PeerID]*tokenBucket),
+		now:     time.Now,
+	}
+}
@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	core "github.com/libp2p/go-libp2p-core"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageLimits(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	limiter := newPeerRateLimiter(10, 5)
+	limiter.now = func() time.Time {
+		return now
+	}
+	limits := &messageLimits{
+		maxMessageSize: 1024,
+		limiter:        limiter,
+	}
+
+	peer1 := core.PeerID("peer 1")
+	peer2 := core.PeerID("peer 2")
+	sizeRejects := rejectedMessageCount.With(prometheus.Labels{"reason": "size"})
+	rateRejects := rejectedMessageCount.With(prometheus.Labels{"reason": "rate"})
+	initialSizeRejects := testutil.ToFloat64(sizeRejects)
+	initialRateRejects := testutil.ToFloat64(rateRejects)
+
+	// Oversized messages should be rejected.
+	err := limits.check(peer1, 1025)
+	require.ErrorIs(err, errMessageTooLarge, "oversized messages should be rejected")
+	require.EqualValues(initialSizeRejects+1, testutil.ToFloat64(sizeRejects))
+
+	// Messages up to the burst size should be accepted.
+	for i := 0; i < 5; i++ {
+		err = limits.check(peer1, 1024)
+		require.NoError(err, "messages within burst should be accepted")
+	}
+
+	// Too frequent messages should be rejected.
+	err = limits.check(peer1, 1)
+	require.ErrorIs(err, errRateLimited, "too frequent messages should be rejected")
+	require.EqualValues(initialRateRejects+1, testutil.ToFloat64(rateRejects))
+
+	// Other peers should not be affected.
+	err = limits.check(peer2, 1)
+	require.NoError(err, "messages from other peers should be accepted")
+
+	// Tokens should be refilled over time.
+	now = now.Add(200 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		err = limits.check(peer1, 1)
+		require.NoError(err, "messages should be accepted after refill")
+	}
+	err = limits.check(peer1, 1)
+	require.ErrorIs(err, errRateLimited, "too frequent messages should be rejected")
+	require.EqualValues(initialRateRejects+2, testutil.ToFloat64(rateRejects))
+
+	// Disabled rate limiting should accept everything.
+	limits.limiter = newPeerRateLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		err = limits.check(peer1, 1)
+		require.NoError(err, "messages should be accepted with rate limiting disabled")
+	}
+}
+
+func TestPeerRateLimiterPrune(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	limiter := newPeerRateLimiter(1, 1)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	for i := 0; i < maxTrackedPeers; i++ {
+		require.True(limiter.allow(core.PeerID(fmt.Sprintf("peer %d", i))))
+	}
+	require.Len(limiter.buckets, maxTrackedPeers)
+
+	// Once all buckets are refilled, they should be pruned when a new peer arrives.
+	now = now.Add(time.Second)
+	require.True(limiter.allow(core.PeerID("new peer")))
+	require.Len(limiter.buckets, 1)
+}
@@ -16,6 +16,7 @@ import (
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -52,6 +53,8 @@ type P2P struct {
 	registerAddresses []multiaddr.Multiaddr
 	topics            map[common.Namespace]*topicHandler
 
+	limits *messageLimits
+
 	logger *logging.Logger
 }
 
@@ -208,7 +211,12 @@ func New(ctx context.Context, identity *identity.Identity, consensus consensus.B
 		return nil, fmt.Errorf("worker/common/p2p: failed to initialize libp2p host: %w", err)
 	}
 
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(p2pCollectors...)
+	})
+
 	// Initialize the gossipsub router.
+	maxMessageSize := viper.GetInt(CfgP2PMaxMessageSize)
 	pubsub, err := pubsub.NewGossipSub(
 		ctx,
 		host,
@@ -218,6 +226,7 @@ func New(ctx context.Context, identity *identity.Identity, consensus consensus.B
 		pubsub.WithPeerOutboundQueueSize(viper.GetInt(CfgP2PPeerOutboundQueueSize)),
 		pubsub.WithValidateQueueSize(viper.GetInt(CfgP2PValidateQueueSize)),
 		pubsub.WithValidateThrottle(viper.GetInt(CfgP2PValidateThrottle)),
+		pubsub.WithMaxMessageSize(maxMessageSize),
 		pubsub.WithMessageIdFn(func(pmsg *pb.Message) string {
 			h := hash.NewFromBytes(pmsg.Data)
 			return string(h[:])
@@ -232,6 +241,14 @@ func New(ctx context.Context, identity *identity.Identity, consensus consensus.B
 		return nil, fmt.Errorf("worker/common/p2p: failed to get consensus genesis document: %w", err)
 	}
 
+	limits := &messageLimits{
+		maxMessageSize: maxMessageSize,
+		limiter: newPeerRateLimiter(
+			viper.GetFloat64(CfgP2PPeerMessageRate),
+			viper.GetUint64(CfgP2PPeerMessageBurst),
+		),
+	}
+
 	p := &P2P{
 		PeerManager:       newPeerManager(ctx, host, consensus),
 		ctx:               ctx,
@@ -240,6 +257,7 @@ func New(ctx context.Context, identity *identity.Identity, consensus consensus.B
 		pubsub:            pubsub,
 		registerAddresses: registerAddresses,
 		topics:            make(map[common.Namespace]*topicHandler),
+		limits:            limits,
 		logger:            logging.GetLogger("worker/common/p2p"),
 	}
 	p.host.Network().SetConnHandler(p.handleConnection)
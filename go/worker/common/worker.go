@@ -1,8 +1,11 @@
 package common
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
@@ -76,8 +79,20 @@ func (w *Worker) Start() error {
 
 	// Wait for all runtimes to be initialized.
 	go func() {
-		for _, rt := range w.runtimes {
-			<-rt.Initialized()
+		initChs := make(map[common.Namespace]<-chan struct{}, len(w.runtimes))
+		for id, rt := range w.runtimes {
+			initChs[id] = rt.Initialized()
+		}
+
+		_, ok := waitRuntimesInitialized(
+			w.logger,
+			initChs,
+			w.cfg.RuntimeInitTimeout,
+			w.cfg.RuntimeInitSkipOnTimeout,
+			w.ctx.Done(),
+		)
+		if !ok {
+			return
 		}
 
 		close(w.initCh)
@@ -97,6 +112,82 @@ func (w *Worker) Start() error {
 	return nil
 }
 
+// waitRuntimesInitialized waits for all of the runtimes, given as a map of runtime identifiers to
+// their initialization channels, to be initialized.
+//
+// Runtimes that do not initialize within the given timeout (if non-zero) are logged and returned.
+// In case skipOnTimeout is set, such runtimes are not waited for any further. The second return
+// value is false in case waiting has been aborted via stopCh.
+func waitRuntimesInitialized(
+	logger *logging.Logger,
+	initChs map[common.Namespace]<-chan struct{},
+	timeout time.Duration,
+	skipOnTimeout bool,
+	stopCh <-chan struct{},
+) ([]common.Namespace, bool) {
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	// Wait for runtimes in a deterministic order.
+	ids := make([]common.Namespace, 0, len(initChs))
+	for id := range initChs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+
+	// Wait for all runtimes until the timeout expires.
+	var pending []common.Namespace
+	for i, id := range ids {
+		select {
+		case <-initChs[id]:
+			continue
+		case <-stopCh:
+			return nil, false
+		case <-timeoutCh:
+			pending = ids[i:]
+		}
+		break
+	}
+
+	// The timeout has expired, report all runtimes that have not been initialized in the meantime
+	// before waiting for any of them, so that no stuck runtime goes unreported.
+	var timedOut []common.Namespace
+	for _, id := range pending {
+		select {
+		case <-initChs[id]:
+			continue
+		default:
+		}
+
+		logger.Error("runtime failed to initialize in time",
+			"runtime_id", id,
+			"timeout", timeout,
+		)
+		timedOut = append(timedOut, id)
+	}
+	if skipOnTimeout {
+		return timedOut, true
+	}
+
+	for _, id := range timedOut {
+		select {
+		case <-initChs[id]:
+			logger.Info("runtime initialized after timeout",
+				"runtime_id", id,
+			)
+		case <-stopCh:
+			return timedOut, false
+		}
+	}
+	return timedOut, true
+}
+
 // Stop halts the service.
 func (w *Worker) Stop() {
 	if !w.enabled {
@@ -182,7 +273,7 @@ func (w *Worker) NewUnmanagedCommitteeNode(runtime runtimeRegistry.Runtime, enab
 		p2p = w.P2P
 	}
 
-	return committee.NewNode(
+	node, err := committee.NewNode(
 		w.HostNode,
 		runtime,
 		w.Identity,
@@ -190,6 +281,14 @@ func (w *Worker) NewUnmanagedCommitteeNode(runtime runtimeRegistry.Runtime, enab
 		w.Consensus,
 		p2p,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if w.cfg.TEEAttestationTimeout > 0 {
+		node.TEE.SetTimeout(w.cfg.TEEAttestationTimeout)
+	}
+
+	return node, nil
 }
 
 func (w *Worker) registerRuntime(runtime runtimeRegistry.Runtime) error {
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+func TestWaitRuntimesInitialized(t *testing.T) {
+	require := require.New(t)
+
+	logger := logging.GetLogger("worker/common/test")
+	readyID := common.NewTestNamespaceFromSeed([]byte("worker test runtime ready"), 0)
+	stuckID := common.NewTestNamespaceFromSeed([]byte("worker test runtime stuck"), 0)
+
+	readyCh := make(chan struct{})
+	close(readyCh)
+	stuckCh := make(chan struct{})
+	initChs := map[common.Namespace]<-chan struct{}{
+		readyID: readyCh,
+		stuckID: stuckCh,
+	}
+
+	// Stuck runtimes should be reported and skipped if configured.
+	timedOut, ok := waitRuntimesInitialized(logger, initChs, 50*time.Millisecond, true, nil)
+	require.True(ok, "waiting should not be aborted")
+	require.Equal([]common.Namespace{stuckID}, timedOut, "stuck runtime should be reported")
+
+	// Stuck runtimes should be reported and waited for if not skipped.
+	doneCh := make(chan []common.Namespace)
+	go func() {
+		timedOut, _ := waitRuntimesInitialized(logger, initChs, 50*time.Millisecond, false, nil)
+		doneCh <- timedOut
+	}()
+	select {
+	case <-doneCh:
+		require.FailNow("waiting should not complete before the stuck runtime is initialized")
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(stuckCh)
+	select {
+	case timedOut = <-doneCh:
+		require.Equal([]common.Namespace{stuckID}, timedOut, "stuck runtime should be reported")
+	case <-time.After(5 * time.Second):
+		require.FailNow("waiting should complete after the stuck runtime is initialized")
+	}
+
+	// Initialized runtimes should not be reported.
+	timedOut, ok = waitRuntimesInitialized(logger, initChs, 50*time.Millisecond, false, nil)
+	require.True(ok, "waiting should not be aborted")
+	require.Empty(timedOut, "no runtimes should be reported")
+
+	// All stuck runtimes should be reported before waiting for any of them.
+	otherStuckID := common.NewTestNamespaceFromSeed([]byte("worker test runtime other stuck"), 0)
+	stopCh := make(chan struct{})
+	go func() {
+		timedOut, ok := waitRuntimesInitialized(logger, map[common.Namespace]<-chan struct{}{
+			readyID:      readyCh,
+			stuckID:      make(chan struct{}),
+			otherStuckID: make(chan struct{}),
+		}, 50*time.Millisecond, false, stopCh)
+		require.False(ok, "waiting should be aborted")
+		doneCh <- timedOut
+	}()
+	time.Sleep(200 * time.Millisecond)
+	close(stopCh)
+	select {
+	case timedOut = <-doneCh:
+		require.ElementsMatch([]common.Namespace{stuckID, otherStuckID}, timedOut, "all stuck runtimes should be reported")
+	case <-time.After(5 * time.Second):
+		require.FailNow("waiting should be aborted when stopped")
+	}
+
+	// Waiting should be aborted when stopped.
+	stopCh = make(chan struct{})
+	close(stopCh)
+	_, ok = waitRuntimesInitialized(logger, map[common.Namespace]<-chan struct{}{
+		stuckID: make(chan struct{}),
+	}, 0, false, stopCh)
+	require.False(ok, "waiting should be aborted")
+}
@@ -1,6 +1,34 @@
 package api
 
+import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
 // Tx is a runtime transaction being sent to the executor node.
 type Tx struct {
 	Data []byte `json:"data"`
 }
+
+// Status is the executor committee node status.
+type Status struct {
+	// State is the name of the current executor committee node state.
+	State string `json:"state"`
+	// Round is the round the executor committee node is currently working on.
+	Round uint64 `json:"round"`
+	// Epoch is the epoch of the current executor committee.
+	Epoch beacon.EpochTime `json:"epoch"`
+
+	// IsWorker indicates whether the node is a worker of the executor committee.
+	IsWorker bool `json:"is_worker"`
+	// IsBackupWorker indicates whether the node is a backup worker of the executor committee.
+	IsBackupWorker bool `json:"is_backup_worker"`
+
+	// ReceivedCommitments is the number of executor commitments received in the current round.
+	ReceivedCommitments uint64 `json:"received_commitments"`
+
+	// Discrepancy indicates whether the node is waiting for a discrepancy to be resolved.
+	Discrepancy bool `json:"discrepancy"`
+
+	// QueueSize is the number of transactions waiting to be scheduled.
+	QueueSize uint64 `json:"queue_size"`
+}
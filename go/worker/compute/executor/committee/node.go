@@ -39,6 +39,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
 	p2pError "github.com/oasisprotocol/oasis-core/go/worker/common/p2p/error"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
@@ -69,6 +70,13 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	receivedCommitmentCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_executor_received_commitment_count",
+			Help: "Number of received executor commitments.",
+		},
+		[]string{"runtime"},
+	)
 	abortedBatchCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "oasis_worker_aborted_batch_count",
@@ -120,6 +128,7 @@ var (
 	)
 	nodeCollectors = []prometheus.Collector{
 		discrepancyDetectedCount,
+		receivedCommitmentCount,
 		abortedBatchCount,
 		storageCommitLatency,
 		batchReadTime,
@@ -178,6 +187,9 @@ type Node struct { // nolint: maligned
 	// Guarded by .commonNode.CrossNode.
 	roundCtx       context.Context
 	roundCancelCtx context.CancelFunc
+	// Number of executor commitments received since the last block.
+	// Guarded by .commonNode.CrossNode.
+	roundCommitments uint64
 
 	stateTransitions *pubsub.Broker
 	// Bump this when we need to change what the worker selects over.
@@ -412,6 +424,45 @@ func (n *Node) handleInternalBatchLocked(
 	)
 }
 
+// GetStatus returns the executor committee node status.
+func (n *Node) GetStatus(ctx context.Context) (*api.Status, error) {
+	n.commonNode.CrossNode.Lock()
+	defer n.commonNode.CrossNode.Unlock()
+
+	return n.getStatusLocked(n.commonNode.Group.GetEpochSnapshot()), nil
+}
+
+// Guarded by n.commonNode.CrossNode.
+func (n *Node) getStatusLocked(epoch *committee.EpochSnapshot) *api.Status {
+	status := api.Status{
+		State:               string(n.state.Name()),
+		Epoch:               epoch.GetEpochNumber(),
+		IsWorker:            epoch.IsExecutorWorker(),
+		IsBackupWorker:      epoch.IsExecutorBackupWorker(),
+		ReceivedCommitments: n.roundCommitments,
+	}
+	if n.commonNode.CurrentBlock != nil {
+		status.Round = n.commonNode.CurrentBlock.Header.Round + 1
+	}
+
+	switch n.state.(type) {
+	case StateWaitingForEvent:
+		// Waiting for the discrepancy event.
+		status.Discrepancy = true
+	case StateProcessingBatch, StateWaitingForFinalize:
+		// Backup workers only process batches when resolving a discrepancy.
+		status.Discrepancy = status.IsBackupWorker && !status.IsWorker
+	}
+
+	n.schedulerMutex.RLock()
+	if n.scheduler != nil {
+		status.QueueSize = n.scheduler.UnscheduledSize()
+	}
+	n.schedulerMutex.RUnlock()
+
+	return &status
+}
+
 func (n *Node) bumpReselect() {
 	select {
 	case n.reselect <- struct{}{}:
@@ -493,6 +544,7 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 		(n.roundCancelCtx)()
 	}
 	n.roundCtx, n.roundCancelCtx = context.WithCancel(n.ctx)
+	n.roundCommitments = 0
 
 	// Perform actions based on current state.
 	switch state := n.state.(type) {
@@ -1052,8 +1104,14 @@ func (n *Node) handleScheduleBatch(force bool) {
 
 // Guarded by n.commonNode.CrossNode.
 func (n *Node) maybeStartProcessingBatchLocked(batch *unresolvedBatch) {
-	epoch := n.commonNode.Group.GetEpochSnapshot()
+	// Do not participate in the round in case the hosted runtime's TEE capability has not been
+	// confirmed to be compatible with the runtime's registry descriptor.
+	if !n.commonNode.TEE.IsReady() {
+		n.logger.Warn("hosted runtime TEE capability is not ready, ignoring batch")
+		return
+	}
 
+	epoch := n.commonNode.Group.GetEpochSnapshot()
 	switch {
 	case epoch.IsExecutorWorker():
 		// Worker, start processing immediately.
@@ -1535,6 +1593,9 @@ func (n *Node) signAndSubmitCommitment(roundCtx context.Context, body *commitmen
 // Guarded by n.commonNode.CrossNode.
 func (n *Node) HandleNewEventLocked(ev *roothash.Event) {
 	switch {
+	case ev.ExecutorCommitted != nil:
+		receivedCommitmentCount.With(n.getMetricLabels()).Inc()
+		n.roundCommitments++
 	case ev.ExecutionDiscrepancyDetected != nil:
 		n.logger.Warn("execution discrepancy detected")
 
@@ -1621,11 +1682,21 @@ func (n *Node) handleExternalBatchLocked(batch *unresolvedBatch, hdr block.Heade
 }
 
 func (n *Node) handleRuntimeHostEvent(ev *host.Event) {
+	n.commonNode.TEE.HandleEvent(ev)
+
 	switch {
 	case ev.Started != nil:
-		// We are now able to service requests for this runtime.
 		n.runtimeVersion = ev.Started.Version
 
+		// Refuse to participate in case the hosted runtime's TEE capability is not compatible
+		// with the runtime's registry descriptor.
+		if !n.commonNode.TEE.IsReady() {
+			n.logger.Error("hosted runtime TEE capability is not compatible, not participating")
+			n.roleProvider.SetUnavailable()
+			return
+		}
+
+		// We are now able to service requests for this runtime.
 		n.roleProvider.SetAvailable(func(nd *node.Node) error {
 			rt := nd.AddOrUpdateRuntime(n.commonNode.Runtime.ID())
 			rt.Version = n.runtimeVersion
@@ -1633,6 +1704,12 @@ func (n *Node) handleRuntimeHostEvent(ev *host.Event) {
 			return nil
 		})
 	case ev.Updated != nil:
+		if !n.commonNode.TEE.IsReady() {
+			n.logger.Error("hosted runtime TEE capability is not compatible, not participating")
+			n.roleProvider.SetUnavailable()
+			return
+		}
+
 		// Update runtime capabilities.
 		n.roleProvider.SetAvailable(func(nd *node.Node) error {
 			rt := nd.AddOrUpdateRuntime(n.commonNode.Runtime.ID())
@@ -1717,6 +1794,20 @@ func (n *Node) worker() {
 
 	n.logger.Info("starting committee node")
 
+	// Track TEE attestation status in case the hosted runtime requires a TEE.
+	rtDsc, err := n.commonNode.Runtime.RegistryDescriptor(n.ctx)
+	if err != nil {
+		n.logger.Error("failed to get runtime registry descriptor",
+			"err", err,
+		)
+		return
+	}
+	if rtDsc.TEEHardware != node.TEEHardwareInvalid {
+		n.commonNode.TEE.SetConstraints(rtDsc.Version.TEE)
+		n.commonNode.TEE.Reset()
+		defer n.commonNode.TEE.Stop()
+	}
+
 	// Provision the hosted runtime.
 	hrt, hrtNotifier, err := n.ProvisionHostedRuntime(n.ctx)
 	if err != nil {
@@ -1829,6 +1920,16 @@ func (n *Node) worker() {
 			// code, as it will be no longer be true that the scheduler
 			// variable never gets updated.
 
+			// Update the TEE constraints the hosted runtime must satisfy. In case the hosted
+			// runtime no longer satisfies them, stop participating until it is updated.
+			if runtime.TEEHardware != node.TEEHardwareInvalid {
+				n.commonNode.TEE.SetConstraints(runtime.Version.TEE)
+				if !n.commonNode.TEE.IsReady() {
+					n.logger.Error("hosted runtime TEE capability is not compatible, not participating")
+					n.roleProvider.SetUnavailable()
+				}
+			}
+
 			// Update per round weight limits.
 			n.schedulerMutex.Lock()
 			n.roundWeightLimits[transaction.WeightConsensusMessages] = uint64(runtime.Executor.MaxMessages)
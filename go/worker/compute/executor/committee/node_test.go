@@ -0,0 +1,79 @@
+package committee
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
+)
+
+func TestGetStatus(t *testing.T) {
+	require := require.New(t)
+
+	var ns common.Namespace
+	blk := block.NewGenesisBlock(ns, 0)
+	blk.Header.Round = 41
+
+	n := &Node{
+		commonNode: &committee.Node{
+			CurrentBlock: blk,
+		},
+		state: StateNotReady{},
+	}
+	epoch := &committee.EpochSnapshot{}
+
+	status := n.getStatusLocked(epoch)
+	require.EqualValues(NotReady, status.State)
+	require.EqualValues(42, status.Round, "round should be the round being worked on")
+	require.False(status.IsWorker)
+	require.False(status.IsBackupWorker)
+	require.False(status.Discrepancy)
+	require.EqualValues(0, status.QueueSize)
+	require.EqualValues(0, status.ReceivedCommitments)
+
+	// Received commitments should be reported.
+	n.roundCommitments = 2
+	status = n.getStatusLocked(epoch)
+	require.EqualValues(2, status.ReceivedCommitments, "received commitments should be reported")
+
+	// Waiting for a discrepancy event.
+	n.state = StateWaitingForEvent{}
+	status = n.getStatusLocked(epoch)
+	require.EqualValues(WaitingForEvent, status.State)
+	require.True(status.Discrepancy, "discrepancy should be reported while waiting for the event")
+
+	// Processing a batch as a non-backup worker is not discrepancy resolution.
+	n.state = StateWaitingForFinalize{}
+	status = n.getStatusLocked(epoch)
+	require.EqualValues(WaitingForFinalize, status.State)
+	require.False(status.Discrepancy)
+}
+
+func TestIncompatibleTEEDeclinesBatch(t *testing.T) {
+	require := require.New(t)
+
+	var ns common.Namespace
+	tee := committee.NewTEEStatusTracker(ns)
+	defer tee.Stop()
+	tee.Reset()
+	tee.HandleEvent(&host.Event{FailedToStart: &host.FailedToStartEvent{Error: errors.New("failed")}})
+
+	// The node is not part of any committee (it has no group), so it would fail in case it
+	// attempted to process the batch.
+	n := &Node{
+		commonNode: &committee.Node{
+			TEE: tee,
+		},
+		state:  StateWaitingForBatch{},
+		logger: logging.GetLogger("worker/executor/committee/test"),
+	}
+
+	n.maybeStartProcessingBatchLocked(&unresolvedBatch{})
+	require.Equal(StateWaitingForBatch{}, n.state, "batch should be declined with an incompatible TEE")
+}
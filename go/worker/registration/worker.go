@@ -2,6 +2,7 @@ package registration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -46,9 +47,19 @@ const (
 	// CfgRegistrationRotateCerts sets the number of epochs that a node's TLS
 	// certificate should be valid for.
 	CfgRegistrationRotateCerts = "worker.registration.rotate_certs"
+	// CfgRegistrationMaxAttempts configures the maximum number of attempts for the initial node
+	// registration (0 means unlimited).
+	CfgRegistrationMaxAttempts = "worker.registration.max_attempts"
+	// CfgRegistrationMaxBackoff configures the maximum interval between node registration
+	// attempts.
+	CfgRegistrationMaxBackoff = "worker.registration.max_backoff"
 )
 
 var (
+	// ErrRegistrationAttemptsExhausted is the error returned when the node registration has not
+	// succeeded within the configured maximum number of attempts.
+	ErrRegistrationAttemptsExhausted = errors.New("worker/registration: registration attempts exhausted")
+
 	deregistrationRequestStoreKey = []byte("deregistration requested")
 
 	// Flags has the configuration flags.
@@ -152,6 +163,9 @@ type Worker struct { // nolint: maligned
 
 	sentryAddresses []node.TLSAddress
 
+	maxAttempts uint64
+	maxBackoff  time.Duration
+
 	runtimeRegistry runtimeRegistry.Registry
 	beacon          beacon.Backend
 	registry        registry.Backend
@@ -180,6 +194,36 @@ func DebugForceAllowUnroutableAddresses() {
 	allowUnroutableAddresses = true
 }
 
+// newRegistrationBackOff creates the exponential (randomized) backoff used when retrying node
+// registrations. In case maxAttempts is non-zero, the backoff stops after the given number of
+// attempts. In case maxInterval is non-zero, it caps the interval between attempts.
+func newRegistrationBackOff(maxAttempts uint64, maxInterval time.Duration) backoff.BackOff {
+	boff := cmnBackoff.NewExponentialBackOff()
+	if maxInterval > 0 {
+		boff.MaxInterval = maxInterval
+		if boff.InitialInterval > maxInterval {
+			boff.InitialInterval = maxInterval
+		}
+	}
+	boff.Reset()
+
+	if maxAttempts == 0 {
+		return boff
+	}
+	// WithMaxRetries counts retries, not attempts.
+	return backoff.WithMaxRetries(boff, maxAttempts-1)
+}
+
+// retryRegistration retries the given operation until it succeeds, the context is canceled or
+// the backoff stops in which case ErrRegistrationAttemptsExhausted is returned.
+func retryRegistration(ctx context.Context, off backoff.BackOff, op backoff.Operation) error {
+	err := backoff.Retry(op, backoff.WithContext(off, ctx))
+	if err != nil && ctx.Err() == nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%w: %s", ErrRegistrationAttemptsExhausted, err)
+	}
+	return err
+}
+
 func (w *Worker) registrationLoop() { // nolint: gocyclo
 	// If we have any sentry nodes, let them know about our TLS certs.
 	if len(w.sentryAddresses) > 0 {
@@ -237,19 +281,19 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 
 		switch retry {
 		case true:
-			off = cmnBackoff.NewExponentialBackOff()
+			off = newRegistrationBackOff(w.maxAttempts, w.maxBackoff)
 		case false:
 			off = &backoff.StopBackOff{}
 		}
-		off = backoff.WithContext(off, w.ctx)
 
-		// WARNING: This can potentially infinite loop, on certain
-		// "shouldn't be possible" pathological failures.
+		// WARNING: Unless the maximum number of attempts is configured,
+		// this can potentially infinite loop, on certain "shouldn't be
+		// possible" pathological failures.
 		//
 		// w.ctx being canceled will break out of the loop correctly
 		// but it's entirely possible to sit around in an infinite
 		// retry loop with no hope of success.
-		return backoff.Retry(func() error {
+		return retryRegistration(w.ctx, off, func() error {
 			// Update the epoch if it happens to change while retrying.
 			var ok bool
 			select {
@@ -270,7 +314,7 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 				workerNodeRegistered.Set(0.0)
 			}
 			return err
-		}, off)
+		})
 	}
 
 	// (re-)register the node on entity registration update.
@@ -416,10 +460,10 @@ Loop:
 				w.logger.Error("failed to register node",
 					"err", err,
 				)
-				// This is by definition a cancellation as the first
-				// registration retries until success. So we can avoid
-				// another iteration of the loop to figure this out
-				// and abort early.
+				// This is either a cancellation or the configured number
+				// of attempts has been exhausted, as the first registration
+				// otherwise retries until success. So we can avoid another
+				// iteration of the loop to figure this out and abort early.
 				return
 			}
 			w.logger.Error("failed to re-register node",
@@ -964,6 +1008,8 @@ func New(
 		delegate:           delegate,
 		entityID:           entityID,
 		sentryAddresses:    workerCommonCfg.SentryAddresses,
+		maxAttempts:        viper.GetUint64(CfgRegistrationMaxAttempts),
+		maxBackoff:         viper.GetDuration(CfgRegistrationMaxBackoff),
 		registrationSigner: registrationSigner,
 		runtimeRegistry:    runtimeRegistry,
 		beacon:             beacon,
@@ -1049,6 +1095,8 @@ func init() {
 	Flags.String(CfgDebugRegistrationPrivateKey, "", "private key to use to sign node registrations")
 	Flags.Bool(CfgRegistrationForceRegister, false, "override a previously saved deregistration request")
 	Flags.Uint64(CfgRegistrationRotateCerts, 0, "rotate node TLS certificates every N epochs (0 to disable)")
+	Flags.Uint64(CfgRegistrationMaxAttempts, 0, "maximum number of initial node registration attempts (0 = unlimited)")
+	Flags.Duration(CfgRegistrationMaxBackoff, 0, "maximum interval between node registration attempts (0 = default)")
 	_ = Flags.MarkHidden(CfgDebugRegistrationPrivateKey)
 
 	_ = viper.BindPFlags(Flags)
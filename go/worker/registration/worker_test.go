@@ -0,0 +1,77 @@
+package registration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationBackOff(t *testing.T) {
+	require := require.New(t)
+
+	const maxInterval = 100 * time.Millisecond
+
+	// The schedule should be capped by the maximum interval (plus randomization) and should stop
+	// after the maximum number of attempts.
+	off := newRegistrationBackOff(5, maxInterval)
+	for i := 0; i < 4; i++ {
+		next := off.NextBackOff()
+		require.NotEqual(backoff.Stop, next, "backoff should not stop before the maximum attempts")
+		require.True(next > 0 && next <= 2*maxInterval, "backoff interval should be capped (got: %s)", next)
+	}
+	require.Equal(backoff.Stop, off.NextBackOff(), "backoff should stop after the maximum attempts")
+
+	// Without a maximum number of attempts, the backoff should never stop.
+	off = newRegistrationBackOff(0, maxInterval)
+	for i := 0; i < 100; i++ {
+		require.NotEqual(backoff.Stop, off.NextBackOff(), "backoff should never stop")
+	}
+}
+
+func TestRetryRegistration(t *testing.T) {
+	require := require.New(t)
+
+	const failures = 3
+
+	newOp := func(attempts *int, times *[]time.Time) backoff.Operation {
+		return func() error {
+			*attempts++
+			*times = append(*times, time.Now())
+			if *attempts <= failures {
+				return fmt.Errorf("failure %d", *attempts)
+			}
+			return nil
+		}
+	}
+
+	// The operation should eventually succeed.
+	var (
+		attempts int
+		times    []time.Time
+	)
+	err := retryRegistration(context.Background(), newRegistrationBackOff(0, 10*time.Millisecond), newOp(&attempts, &times))
+	require.NoError(err, "retryRegistration should eventually succeed")
+	require.Equal(failures+1, attempts, "operation should be retried until it succeeds")
+	// Each interval is at least half of the initial interval due to randomization.
+	require.True(times[len(times)-1].Sub(times[0]) >= failures*5*time.Millisecond, "attempts should be separated by backoff intervals")
+
+	// The operation should fail in case attempts are exhausted.
+	attempts = 0
+	times = nil
+	err = retryRegistration(context.Background(), newRegistrationBackOff(failures, 10*time.Millisecond), newOp(&attempts, &times))
+	require.ErrorIs(err, ErrRegistrationAttemptsExhausted, "retryRegistration should fail when attempts are exhausted")
+	require.Equal(failures, attempts, "operation should be attempted the maximum number of times")
+
+	// Cancellation should not be reported as exhaustion.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempts = 0
+	times = nil
+	err = retryRegistration(ctx, newRegistrationBackOff(0, 10*time.Millisecond), newOp(&attempts, &times))
+	require.Error(err, "retryRegistration should fail when canceled")
+	require.NotErrorIs(err, ErrRegistrationAttemptsExhausted, "cancellation should not be reported as exhaustion")
+}
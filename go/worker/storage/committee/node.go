@@ -281,6 +281,7 @@ func NewNode(
 		n.ctx,
 		n.storageNodesGrpc,
 		n.commonNode.Runtime,
+		client.NewOptionsFromConfig()...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("storage worker: failed to create client: %w", err)